@@ -8,11 +8,20 @@ and the EVM.
 package w3
 
 import (
+	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"reflect"
 	"strings"
+	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lmittmann/w3/w3types"
 	"golang.org/x/time/rate"
@@ -25,6 +34,15 @@ type Client struct {
 	// rate limiter
 	rl         *rate.Limiter
 	rlCostFunc func(methods []string) (cost int)
+
+	// poll interval of emulated subscriptions
+	pollInterval time.Duration
+
+	// sets request specific HTTP headers
+	headerFunc func(h http.Header, body []byte) error
+
+	// secret of JWT authentication
+	jwtSecret *[32]byte
 }
 
 // NewClient returns a new Client given an rpc.Client client.
@@ -32,7 +50,13 @@ func NewClient(client *rpc.Client, opts ...Option) *Client {
 	if client == nil {
 		panic("w3: client is nil")
 	}
-	c := &Client{client: client}
+	c := newClient(opts)
+	c.client = client
+	return c
+}
+
+func newClient(opts []Option) *Client {
+	c := &Client{pollInterval: defaultPollInterval}
 	for _, opt := range opts {
 		if opt == nil {
 			continue
@@ -48,11 +72,25 @@ func NewClient(client *rpc.Client, opts ...Option) *Client {
 // The supported URL schemes are "http", "https", "ws" and "wss". If rawurl is a
 // file name with no URL scheme, a local IPC socket connection is established.
 func Dial(rawurl string, opts ...Option) (*Client, error) {
-	client, err := rpc.Dial(rawurl)
+	c := newClient(opts)
+
+	var dialOpts []rpc.ClientOption
+	if c.headerFunc != nil {
+		dialOpts = append(dialOpts, rpc.WithHTTPClient(&http.Client{
+			Transport: &headerTransport{fn: c.headerFunc},
+		}))
+	}
+
+	if c.jwtSecret != nil {
+		dialOpts = append(dialOpts, rpc.WithHTTPAuth(jwtAuth(*c.jwtSecret)))
+	}
+
+	client, err := rpc.DialOptions(context.Background(), rawurl, dialOpts...)
 	if err != nil {
 		return nil, err
 	}
-	return NewClient(client, opts...), nil
+	c.client = client
+	return c, nil
 }
 
 // MustDial is like [Dial] but panics if the connection establishment fails.
@@ -76,7 +114,8 @@ func (c *Client) Close() error {
 // response.
 //
 // An error is returned if RPC request creation, networking, or RPC response
-// handling fails.
+// handling fails. Calls that implement the [w3types.RPCFollowUpCaller]
+// interface do their further requests after their response has been handled.
 func (c *Client) CallCtx(ctx context.Context, calls ...w3types.RPCCaller) error {
 	// no requests = nothing to do
 	if len(calls) <= 0 {
@@ -123,6 +162,9 @@ func (c *Client) CallCtx(ctx context.Context, calls ...w3types.RPCCaller) error
 	var callErrs CallErrors
 	for i, req := range calls {
 		err = req.HandleResponse(batchElems[i])
+		if fc, ok := req.(w3types.RPCFollowUpCaller); ok && err == nil {
+			err = fc.FollowUp(ctx, c.CallCtx)
+		}
 		if err != nil {
 			if callErrs == nil {
 				callErrs = make(CallErrors, len(calls))
@@ -141,17 +183,33 @@ func (c *Client) Call(calls ...w3types.RPCCaller) error {
 	return c.CallCtx(context.Background(), calls...)
 }
 
-// SubscribeCtx creates a new subscription and returns a [rpc.ClientSubscription].
-func (c *Client) SubscribeCtx(ctx context.Context, s w3types.RPCSubscriber) (*rpc.ClientSubscription, error) {
+// SubscribeCtx creates a new subscription and returns an
+// [ethereum.Subscription].
+//
+// If the RPC endpoint supports subscriptions, the returned subscription is a
+// [rpc.ClientSubscription]. If the RPC endpoint does not support subscriptions
+// (e.g. HTTP) and s implements the [w3types.RPCPollSubscriber] interface, the
+// subscription is emulated by polling a filter. The poll interval can be set
+// using the [WithPollInterval] option.
+func (c *Client) SubscribeCtx(ctx context.Context, s w3types.RPCSubscriber) (ethereum.Subscription, error) {
 	namespace, ch, params, err := s.CreateRequest()
 	if err != nil {
 		return nil, err
 	}
-	return c.client.Subscribe(ctx, namespace, ch, params...)
+	sub, err := c.client.Subscribe(ctx, namespace, ch, params...)
+	if errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		if ps, ok := s.(w3types.RPCPollSubscriber); ok {
+			return c.pollSubscribe(ctx, ps)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sub, nil
 }
 
 // Subscribe is like [Client.SubscribeCtx] with ctx equal to context.Background().
-func (c *Client) Subscribe(s w3types.RPCSubscriber) (*rpc.ClientSubscription, error) {
+func (c *Client) Subscribe(s w3types.RPCSubscriber) (ethereum.Subscription, error) {
 	return c.SubscribeCtx(context.Background(), s)
 }
 
@@ -219,3 +277,79 @@ func WithRateLimiter(rl *rate.Limiter, costFunc func(methods []string) (cost int
 		c.rlCostFunc = costFunc
 	}
 }
+
+// WithPollInterval sets the interval in which filters are polled to emulate
+// subscriptions, if the RPC endpoint does not support subscriptions (e.g.
+// HTTP). The default poll interval is 4s.
+func WithPollInterval(interval time.Duration) Option {
+	return func(c *Client) {
+		c.pollInterval = interval
+	}
+}
+
+// WithHeaderFunc sets a function that is called with the body of every HTTP
+// request to set request specific headers, e.g. a signature of the body. The
+// function must be safe for concurrent use.
+//
+// WithHeaderFunc only takes effect for clients that are connected to an "http"
+// or "https" URL using [Dial] or [MustDial].
+func WithHeaderFunc(fn func(h http.Header, body []byte) error) Option {
+	return func(c *Client) {
+		c.headerFunc = fn
+	}
+}
+
+// WithJWTSecret sets the secret that is used to authenticate every request
+// with a freshly issued JSON Web Token (JWT), as required by the authenticated
+// Engine API port of execution clients.
+//
+// WithJWTSecret only takes effect for clients that are connected to an "http",
+// "https", "ws" or "wss" URL using [Dial] or [MustDial].
+func WithJWTSecret(secret [32]byte) Option {
+	return func(c *Client) {
+		c.jwtSecret = &secret
+	}
+}
+
+// jwtHeader is the base64url encoded JWT header {"alg":"HS256","typ":"JWT"}.
+const jwtHeader = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9"
+
+// jwtAuth returns a [rpc.HTTPAuth] that sets the "Authorization" header to a
+// HS256 signed JWT with the current time as "iat" claim.
+func jwtAuth(secret [32]byte) rpc.HTTPAuth {
+	return func(h http.Header) error {
+		claims := fmt.Sprintf(`{"iat":%d}`, time.Now().Unix())
+		token := jwtHeader + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+
+		mac := hmac.New(sha256.New, secret[:])
+		mac.Write([]byte(token))
+		token += "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+
+		h.Set("Authorization", "Bearer "+token)
+		return nil
+	}
+}
+
+// headerTransport is a [http.RoundTripper] that sets request specific headers
+// using fn.
+type headerTransport struct {
+	fn func(h http.Header, body []byte) error
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		if body, err = io.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+		req.Body.Close()
+	}
+
+	req = req.Clone(req.Context())
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	if err := t.fn(req.Header, body); err != nil {
+		return nil, err
+	}
+	return http.DefaultTransport.RoundTrip(req)
+}
@@ -3,11 +3,20 @@ package w3_test
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"flag"
+	"io"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -128,6 +137,194 @@ func TestClientCall_CallErrors(t *testing.T) {
 	}
 }
 
+func TestClientCall_HeaderFunc(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read body: %v", err)
+		}
+		if want, got := strconv.Itoa(len(body)), r.Header.Get("X-Body-Length"); want != got {
+			t.Errorf("X-Body-Length: want %q, got %q", want, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL, w3.WithHeaderFunc(func(h http.Header, body []byte) error {
+		h.Set("X-Body-Length", strconv.Itoa(len(body)))
+		return nil
+	}))
+	defer client.Close()
+
+	var chainID uint64
+	if err := client.Call(eth.ChainID().Returns(&chainID)); err != nil {
+		t.Fatalf("Failed to call: %v", err)
+	}
+	if chainID != 1 {
+		t.Fatalf("Want chainID 1, got %d", chainID)
+	}
+}
+
+func TestClientCall_JWTSecret(t *testing.T) {
+	secret := [32]byte{0x01, 0x02, 0x03}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok {
+			t.Errorf("Authorization: missing bearer token")
+		}
+
+		parts := strings.Split(token, ".")
+		if len(parts) != 3 {
+			t.Errorf("Token: want 3 parts, got %d", len(parts))
+			return
+		}
+		mac := hmac.New(sha256.New, secret[:])
+		mac.Write([]byte(parts[0] + "." + parts[1]))
+		if want, got := base64.RawURLEncoding.EncodeToString(mac.Sum(nil)), parts[2]; want != got {
+			t.Errorf("Signature: want %q, got %q", want, got)
+		}
+
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		if err != nil {
+			t.Errorf("Failed to decode claims: %v", err)
+			return
+		}
+		var dec struct {
+			Iat int64 `json:"iat"`
+		}
+		if err := json.Unmarshal(claims, &dec); err != nil {
+			t.Errorf("Failed to unmarshal claims: %v", err)
+			return
+		}
+		if diff := time.Since(time.Unix(dec.Iat, 0)); diff < -time.Second || diff > 5*time.Second {
+			t.Errorf("iat: %d is not recent", dec.Iat)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL, w3.WithJWTSecret(secret))
+	defer client.Close()
+
+	var chainID uint64
+	if err := client.Call(eth.ChainID().Returns(&chainID)); err != nil {
+		t.Fatalf("Failed to call: %v", err)
+	}
+}
+
+func TestClientSubscribe_Poll(t *testing.T) {
+	srv := rpctest.NewServer(t, bytes.NewBufferString(
+		`> {"jsonrpc":"2.0","id":1,"method":"eth_newBlockFilter"}`+"\n"+
+			`< {"jsonrpc":"2.0","id":1,"result":"0x1"}`+"\n"+
+			`> {"jsonrpc":"2.0","id":2,"method":"eth_getFilterChanges","params":["0x1"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":2,"result":["0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"]}`+"\n"+
+			`> {"jsonrpc":"2.0","id":3,"method":"eth_getBlockByHash","params":["0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",false]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":3,"result":{"difficulty":"0x3ff800000","extraData":"0x476574682f76312e302e302f6c696e75782f676f312e342e32","gasLimit":"0x1388","gasUsed":"0x0","hash":"0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0x05a56e2d52c817161883f50c441c3228cfe54d9f","mixHash":"0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59","nonce":"0x539bd4979fef1ec4","number":"0x1","parentHash":"0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","size":"0x219","stateRoot":"0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3","timestamp":"0x55ba4224","totalDifficulty":"0x7ff800000","transactions":[],"transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","uncles":[]}}`+"\n"+
+			`> {"jsonrpc":"2.0","id":4,"method":"eth_uninstallFilter","params":["0x1"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":4,"result":true}`,
+	))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL(), w3.WithPollInterval(100*time.Millisecond))
+	defer client.Close()
+
+	headerCh := make(chan *types.Header)
+	sub, err := client.Subscribe(eth.NewHeads(headerCh))
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	select {
+	case header := <-headerCh:
+		if want := w3.H("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"); want != header.Hash() {
+			t.Fatalf("Header hash: want %s, got %s", want, header.Hash())
+		}
+	case err := <-sub.Err():
+		t.Fatalf("Subscription failed: %v", err)
+	}
+	sub.Unsubscribe()
+
+	if err, ok := <-sub.Err(); ok {
+		t.Fatalf("Want closed error channel, got %v", err)
+	}
+}
+
+func TestClientSubscribe_PollReinstall(t *testing.T) {
+	srv := rpctest.NewServer(t, bytes.NewBufferString(
+		`> {"jsonrpc":"2.0","id":1,"method":"eth_newBlockFilter"}`+"\n"+
+			`< {"jsonrpc":"2.0","id":1,"result":"0x1"}`+"\n"+
+			`> {"jsonrpc":"2.0","id":2,"method":"eth_getFilterChanges","params":["0x1"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":2,"error":{"code":-32000,"message":"filter not found"}}`+"\n"+
+			`> {"jsonrpc":"2.0","id":3,"method":"eth_newBlockFilter"}`+"\n"+
+			`< {"jsonrpc":"2.0","id":3,"result":"0x2"}`+"\n"+
+			`> {"jsonrpc":"2.0","id":4,"method":"eth_getFilterChanges","params":["0x2"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":4,"result":["0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"]}`+"\n"+
+			`> {"jsonrpc":"2.0","id":5,"method":"eth_getBlockByHash","params":["0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6",false]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":5,"result":{"difficulty":"0x3ff800000","extraData":"0x476574682f76312e302e302f6c696e75782f676f312e342e32","gasLimit":"0x1388","gasUsed":"0x0","hash":"0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6","logsBloom":"0x00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000","miner":"0x05a56e2d52c817161883f50c441c3228cfe54d9f","mixHash":"0x969b900de27b6ac6a67742365dd65f55a0526c41fd18e1b16f1a1215c2e66f59","nonce":"0x539bd4979fef1ec4","number":"0x1","parentHash":"0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3","receiptsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","sha3Uncles":"0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347","size":"0x219","stateRoot":"0xd67e4d450343046425ae4271474353857ab860dbc0a1dde64b41b5cd3a532bf3","timestamp":"0x55ba4224","totalDifficulty":"0x7ff800000","transactions":[],"transactionsRoot":"0x56e81f171bcc55a6ff8345e692c0f86e5b48e01b996cadc001622fb5e363b421","uncles":[]}}`+"\n"+
+			`> {"jsonrpc":"2.0","id":6,"method":"eth_uninstallFilter","params":["0x2"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":6,"result":true}`,
+	))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL(), w3.WithPollInterval(100*time.Millisecond))
+	defer client.Close()
+
+	headerCh := make(chan *types.Header)
+	sub, err := client.Subscribe(eth.NewHeads(headerCh))
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	select {
+	case header := <-headerCh:
+		if want := w3.H("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"); want != header.Hash() {
+			t.Fatalf("Header hash: want %s, got %s", want, header.Hash())
+		}
+	case err := <-sub.Err():
+		t.Fatalf("Subscription failed: %v", err)
+	}
+	sub.Unsubscribe()
+
+	if err, ok := <-sub.Err(); ok {
+		t.Fatalf("Want closed error channel, got %v", err)
+	}
+}
+
+func TestClientSubscribe_PollErr(t *testing.T) {
+	srv := rpctest.NewServer(t, bytes.NewBufferString(
+		`> {"jsonrpc":"2.0","id":1,"method":"eth_newBlockFilter"}`+"\n"+
+			`< {"jsonrpc":"2.0","id":1,"result":"0x1"}`+"\n"+
+			`> {"jsonrpc":"2.0","id":2,"method":"eth_getFilterChanges","params":["0x1"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":2,"error":{"code":-32603,"message":"internal error"}}`+"\n"+
+			`> {"jsonrpc":"2.0","id":3,"method":"eth_uninstallFilter","params":["0x1"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":3,"result":true}`,
+	))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL(), w3.WithPollInterval(100*time.Millisecond))
+	defer client.Close()
+
+	headerCh := make(chan *types.Header)
+	sub, err := client.Subscribe(eth.NewHeads(headerCh))
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	defer sub.Unsubscribe()
+
+	select {
+	case header := <-headerCh:
+		t.Fatalf("Want error, got header %s", header.Hash())
+	case err := <-sub.Err():
+		if want := "w3: call failed: internal error"; err == nil || err.Error() != want {
+			t.Fatalf("Err: want %q, got %v", want, err)
+		}
+	}
+}
+
 type testCaller struct {
 	RequestErr error
 	ReturnErr  error
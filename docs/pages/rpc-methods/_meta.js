@@ -1,8 +1,17 @@
 export default {
     eth: 'eth',
     debug: 'debug',
+    trace: 'trace',
+    ots: 'ots',
     txpool: 'txpool',
     admin: 'admin',
+    miner: 'miner',
     net: 'net',
     web3: 'web3',
+    anvil: 'anvil',
+    mev: 'mev',
+    aa: 'aa',
+    engine: 'engine',
+    optimism: 'optimism',
+    arbitrum: 'arbitrum',
 }
@@ -0,0 +1,43 @@
+package ens_test
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/ens"
+)
+
+// Resolve the address, a text record and the primary name of an ENS name in a
+// single batch request.
+func Example() {
+	client := w3.MustDial("https://ethereum-rpc.publicnode.com")
+	defer client.Close()
+
+	var (
+		addr common.Address
+		url  string
+		name string
+	)
+	if err := client.Call(
+		ens.Addr("vitalik.eth").Returns(&addr),
+		ens.Text("vitalik.eth", "url").Returns(&url),
+		ens.Name(w3.A("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")).Returns(&name),
+	); err != nil {
+		// ...
+		return
+	}
+	fmt.Printf("addr: %s\nurl: %s\nname: %s\n", addr, url, name)
+}
+
+// Compute the namehash of a normalized ENS name.
+func ExampleNamehash() {
+	name, err := ens.Normalize("Vitalik.ETH")
+	if err != nil {
+		// ...
+		return
+	}
+	fmt.Printf("%s: %s\n", name, ens.Namehash(name))
+	// Output:
+	// vitalik.eth: 0xee6c4522aab0003e8d14cd40a6af439055fd2577951148c14b6cea9a53475835
+}
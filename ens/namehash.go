@@ -0,0 +1,71 @@
+/*
+Package ens implements ENS name normalization, hashing and resolution.
+
+Names are resolved using the ENS Universal Resolver, which supports wildcard
+resolution (ENSIP-10). Offchain lookups (EIP-3668) of resolvers are followed
+using a [Fetcher]. All resolutions are [w3types.RPCCaller]'s that can be batched
+with other calls using [w3.Client.Call].
+*/
+package ens
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errEmptyLabel = errors.New("empty label")
+
+// Namehash returns the ENSIP-1 namehash of the given name. The name must be
+// normalized using [Normalize]. Labels of the form "[<hex labelhash>]" are
+// hashed as their labelhash.
+func Namehash(name string) (node common.Hash) {
+	if name == "" {
+		return
+	}
+
+	labels := strings.Split(name, ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := labelhash(labels[i])
+		node = crypto.Keccak256Hash(node[:], labelHash[:])
+	}
+	return node
+}
+
+// DNSEncode returns the DNS wire format encoding of the given name, as used by
+// ENSIP-10. The name must be normalized using [Normalize]. Labels longer than
+// 255 bytes are encoded as "[<hex labelhash>]".
+func DNSEncode(name string) ([]byte, error) {
+	if name == "" {
+		return []byte{0}, nil
+	}
+
+	labels := strings.Split(name, ".")
+	enc := make([]byte, 0, len(name)+2)
+	for _, label := range labels {
+		if label == "" {
+			return nil, errEmptyLabel
+		}
+		if len(label) > 255 {
+			labelHash := labelhash(label)
+			label = "[" + hex.EncodeToString(labelHash[:]) + "]"
+		}
+		enc = append(enc, byte(len(label)))
+		enc = append(enc, label...)
+	}
+	return append(enc, 0), nil
+}
+
+// labelhash returns the hash of the given label, or the hash encoded in the
+// label, if the label is of the form "[<hex labelhash>]".
+func labelhash(label string) (hash common.Hash) {
+	if len(label) == 66 && label[0] == '[' && label[65] == ']' {
+		if _, err := hex.Decode(hash[:], []byte(label[1:65])); err == nil {
+			return hash
+		}
+	}
+	return crypto.Keccak256Hash([]byte(label))
+}
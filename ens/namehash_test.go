@@ -0,0 +1,61 @@
+package ens_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/ens"
+)
+
+func TestNamehash(t *testing.T) {
+	tests := []struct {
+		Name string
+		Want common.Hash
+	}{
+		{Name: "", Want: common.Hash{}},
+		{Name: "eth", Want: w3.H("0x93cdeb708b7545dc668eb9280176169d1c33cfd8ed6f04690a0bcc88a93fc4ae")},
+		{Name: "foo.eth", Want: w3.H("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f")},
+		{Name: "[41b1a0649752af1b28b3dc29a1556eee781e4a4c3a1f7f53f90fa834de098c4d].eth", Want: w3.H("0xde9b09fd7c5f901e23a3f19fecc54828e9c848539801e86591bd9801b019f84f")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := ens.Namehash(test.Name); got != test.Want {
+				t.Fatalf("want %s, got %s", test.Want, got)
+			}
+		})
+	}
+}
+
+func TestDNSEncode(t *testing.T) {
+	longLabel := strings.Repeat("a", 256)
+	longLabelHash := crypto.Keccak256Hash([]byte(longLabel))
+
+	tests := []struct {
+		Name    string
+		Want    []byte
+		WantErr bool
+	}{
+		{Name: "", Want: []byte{0}},
+		{Name: "eth", Want: []byte("\x03eth\x00")},
+		{Name: "foo.eth", Want: []byte("\x03foo\x03eth\x00")},
+		{Name: longLabel + ".eth", Want: []byte("\x42[" + longLabelHash.Hex()[2:] + "]\x03eth\x00")},
+		{Name: "foo..eth", WantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := ens.DNSEncode(test.Name)
+			if gotErr := err != nil; test.WantErr != gotErr {
+				t.Fatalf("want err %v, got %v", test.WantErr, err)
+			}
+			if !bytes.Equal(test.Want, got) {
+				t.Fatalf("want %q, got %q", test.Want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package ens
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrInvalidName is returned by [Normalize] if a name cannot be normalized.
+var ErrInvalidName = errors.New("ens: invalid name")
+
+// Normalize returns the normalized form of the given name.
+//
+// Normalize is not a complete implementation of ENSIP-15. It implements the
+// ASCII rules of ENSIP-15, which are sufficient for ASCII names, and only a
+// subset of its Unicode rules: letters are lowercased, emoji presentation
+// selectors are removed, and control, whitespace, format (except zero width
+// joiner) and full stop characters, as well as leading combining marks, are
+// rejected. Unicode names are neither NFC normalized, nor are confusable or
+// mixed-script names detected, so Normalize may accept names that are invalid
+// under ENSIP-15, and return a different form than ENSIP-15 for valid ones.
+// Use [WithNormalizer] to resolve names with a complete ENSIP-15
+// implementation.
+func Normalize(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	labels := strings.Split(name, ".")
+	for i, label := range labels {
+		norm, err := normalizeLabel(label)
+		if err != nil {
+			return "", fmt.Errorf("%w %q: %v", ErrInvalidName, name, err)
+		}
+		labels[i] = norm
+	}
+	return strings.Join(labels, "."), nil
+}
+
+func normalizeLabel(label string) (string, error) {
+	if !utf8.ValidString(label) {
+		return "", errors.New("invalid UTF-8")
+	}
+
+	runes := make([]rune, 0, len(label))
+	for _, r := range label {
+		switch {
+		case r == '\ufe0f':
+			// emoji presentation selectors are not part of normalized names
+			continue
+		case r < utf8.RuneSelf:
+			r = unicode.ToLower(r)
+			if !isValidASCII(r) {
+				return "", fmt.Errorf("disallowed character %q", r)
+			}
+		case isDisallowed(r):
+			return "", fmt.Errorf("disallowed character %U", r)
+		default:
+			r = unicode.ToLower(r)
+		}
+		runes = append(runes, r)
+	}
+
+	if len(runes) <= 0 {
+		return "", errEmptyLabel
+	}
+	norm := string(runes)
+	if i := strings.LastIndexByte(norm, '_'); i >= 0 && strings.TrimLeft(norm[:i], "_") != "" {
+		return "", errors.New("underscore allowed only at start")
+	}
+	if len(runes) >= 4 && runes[2] == '-' && runes[3] == '-' {
+		return "", errors.New("invalid label extension")
+	}
+	if unicode.Is(unicode.Mn, runes[0]) {
+		return "", errors.New("leading combining mark")
+	}
+	return norm, nil
+}
+
+func isValidASCII(r rune) bool {
+	return 'a' <= r && r <= 'z' ||
+		'0' <= r && r <= '9' ||
+		r == '-' || r == '_' || r == '$'
+}
+
+func isDisallowed(r rune) bool {
+	switch r {
+	case '\u200d': // zero width joiner of emoji sequences
+		return false
+	case '\u3002', '\uff0e', '\uff61': // full stops
+		return true
+	}
+	return unicode.IsControl(r) ||
+		unicode.IsSpace(r) ||
+		unicode.Is(unicode.Cf, r) ||
+		unicode.Is(unicode.Co, r) ||
+		r == utf8.RuneError
+}
@@ -0,0 +1,53 @@
+package ens_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lmittmann/w3/ens"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		Name    string
+		Want    string
+		WantErr bool
+	}{
+		{Name: "", Want: ""},
+		{Name: "vitalik.eth", Want: "vitalik.eth"},
+		{Name: "Vitalik.ETH", Want: "vitalik.eth"},
+		{Name: "_sub.foo-bar.eth", Want: "_sub.foo-bar.eth"},
+		{Name: "$btc.eth", Want: "$btc.eth"},
+		{Name: "ÖBB.eth", Want: "öbb.eth"},
+		{Name: "👍\ufe0f.eth", Want: "👍.eth"},
+		{Name: "👨\u200d👩\u200d👧.eth", Want: "👨\u200d👩\u200d👧.eth"},
+		{Name: "foo..eth", WantErr: true},
+		{Name: ".eth", WantErr: true},
+		{Name: "foo bar.eth", WantErr: true},
+		{Name: "foo!.eth", WantErr: true},
+		{Name: "foo_bar.eth", WantErr: true},
+		{Name: "xn--ls8h.eth", WantErr: true},
+		{Name: "foo\u200b.eth", WantErr: true},
+		{Name: "foo\u3002eth", WantErr: true},
+		{Name: "\u0301a.eth", WantErr: true},
+		{Name: "\xff.eth", WantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := ens.Normalize(test.Name)
+			if test.WantErr {
+				if !errors.Is(err, ens.ErrInvalidName) {
+					t.Fatalf("want ErrInvalidName, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize: %v", err)
+			}
+			if test.Want != got {
+				t.Fatalf("want %q, got %q", test.Want, got)
+			}
+		})
+	}
+}
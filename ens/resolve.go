@@ -0,0 +1,280 @@
+package ens
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/ccip"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// UniversalResolver is the address of the ENS Universal Resolver on Ethereum
+// mainnet.
+var UniversalResolver = common.HexToAddress("0xeEeEEEeE14D718C2B47D9923Deab1335E144EeEe")
+
+// ErrNoResolver is returned if no resolver is set for a name or any of its
+// parent names.
+var ErrNoResolver = errors.New("ens: no resolver")
+
+var (
+	funcResolve      = w3.MustNewFunc("resolve(bytes name, bytes data)", "bytes result, address resolver")
+	funcFindResolver = w3.MustNewFunc("findResolver(bytes name)", "address resolver, bytes32 node, uint256 offset")
+
+	funcAddr        = w3.MustNewFunc("addr(bytes32 node)", "address")
+	funcAddrCoin    = w3.MustNewFunc("addr(bytes32 node, uint256 coinType)", "bytes")
+	funcText        = w3.MustNewFunc("text(bytes32 node, string key)", "string")
+	funcContenthash = w3.MustNewFunc("contenthash(bytes32 node)", "bytes")
+
+	// selectorResolverNotFound is the selector of the Universal Resolver error
+	// ResolverNotFound(bytes).
+	selectorResolverNotFound = [4]byte(crypto.Keccak256([]byte("ResolverNotFound(bytes)"))[:4])
+)
+
+// Addr requests the Ethereum address of the given name.
+func Addr(name string, opts ...Option) w3types.RPCCallerFactory[common.Address] {
+	return newResolveFactory[common.Address](name, funcAddr, nil, opts)
+}
+
+// MultichainAddr requests the ENSIP-9 address of the given name for the chain
+// with the given SLIP-44 coin type.
+func MultichainAddr(name string, coinType uint64, opts ...Option) w3types.RPCCallerFactory[[]byte] {
+	return newResolveFactory[[]byte](name, funcAddrCoin, []any{new(big.Int).SetUint64(coinType)}, opts)
+}
+
+// Text requests the text record with the given key of the given name.
+func Text(name, key string, opts ...Option) w3types.RPCCallerFactory[string] {
+	return newResolveFactory[string](name, funcText, []any{key}, opts)
+}
+
+// Contenthash requests the ENSIP-7 contenthash of the given name.
+func Contenthash(name string, opts ...Option) w3types.RPCCallerFactory[[]byte] {
+	return newResolveFactory[[]byte](name, funcContenthash, nil, opts)
+}
+
+// Resolver requests the address of the resolver of the given name. If the name
+// has no resolver, the resolver of its closest parent name is returned
+// (ENSIP-10).
+func Resolver(name string, opts ...Option) w3types.RPCCallerFactory[common.Address] {
+	return &resolverFactory{name: name, opts: newOptions(opts)}
+}
+
+// resolveFactory resolves a name using the resolve function of the Universal
+// Resolver, that calls the given resolver function fn of the resolver of the
+// name.
+type resolveFactory[T any] struct {
+	// args
+	name string
+	fn   *w3.Func
+	args []any
+	opts *options
+
+	// returns
+	call *ccip.Call
+	ret  *T
+}
+
+func newResolveFactory[T any](name string, fn *w3.Func, args []any, opts []Option) *resolveFactory[T] {
+	return &resolveFactory[T]{name: name, fn: fn, args: args, opts: newOptions(opts)}
+}
+
+func (f resolveFactory[T]) Returns(ret *T) w3types.RPCCaller {
+	f.ret = ret
+	return &f
+}
+
+func (f *resolveFactory[T]) CreateRequest() (rpc.BatchElem, error) {
+	name, err := f.opts.normalize(f.name)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+	dnsName, err := DNSEncode(name)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+	data, err := f.fn.EncodeArgs(append([]any{Namehash(name)}, f.args...)...)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+	input, err := funcResolve.EncodeArgs(dnsName, data)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+
+	f.call = f.opts.newCall(input)
+	return f.call.CreateRequest()
+}
+
+func (f *resolveFactory[T]) HandleResponse(elem rpc.BatchElem) error {
+	if err := f.call.HandleResponse(elem); err != nil {
+		return resolveErr(err)
+	}
+	if f.call.Pending() {
+		return nil
+	}
+	return f.decode()
+}
+
+func (f *resolveFactory[T]) FollowUp(ctx context.Context, call func(context.Context, ...w3types.RPCCaller) error) error {
+	if !f.call.Pending() {
+		return nil
+	}
+	if err := f.call.FollowUp(ctx, call); err != nil {
+		return resolveErr(err)
+	}
+	return f.decode()
+}
+
+func (f *resolveFactory[T]) decode() error {
+	var result []byte
+	if err := funcResolve.DecodeReturns(f.call.Output(), &result, nil); err != nil {
+		return err
+	}
+
+	var zero T
+	*f.ret = zero
+	if len(result) <= 0 {
+		return nil
+	}
+	return f.fn.DecodeReturns(result, f.ret)
+}
+
+// resolverFactory requests the resolver of a name using the findResolver
+// function of the Universal Resolver.
+type resolverFactory struct {
+	// args
+	name string
+	opts *options
+
+	// returns
+	call *ccip.Call
+	ret  *common.Address
+}
+
+func (f resolverFactory) Returns(ret *common.Address) w3types.RPCCaller {
+	f.ret = ret
+	return &f
+}
+
+func (f *resolverFactory) CreateRequest() (rpc.BatchElem, error) {
+	name, err := f.opts.normalize(f.name)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+	dnsName, err := DNSEncode(name)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+	input, err := funcFindResolver.EncodeArgs(dnsName)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+
+	f.call = f.opts.newCall(input)
+	return f.call.CreateRequest()
+}
+
+func (f *resolverFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := f.call.HandleResponse(elem); err != nil {
+		return resolveErr(err)
+	}
+	if f.call.Pending() {
+		return nil
+	}
+	return f.decode()
+}
+
+func (f *resolverFactory) FollowUp(ctx context.Context, call func(context.Context, ...w3types.RPCCaller) error) error {
+	if !f.call.Pending() {
+		return nil
+	}
+	if err := f.call.FollowUp(ctx, call); err != nil {
+		return resolveErr(err)
+	}
+	return f.decode()
+}
+
+func (f *resolverFactory) decode() error {
+	if err := funcFindResolver.DecodeReturns(f.call.Output(), f.ret, nil, nil); err != nil {
+		return err
+	}
+	if *f.ret == (common.Address{}) {
+		return ErrNoResolver
+	}
+	return nil
+}
+
+// resolveErr maps the errors of the Universal Resolver to the errors of this
+// package.
+func resolveErr(err error) error {
+	if data, ok := ccip.RevertData(err); ok && bytes.HasPrefix(data, selectorResolverNotFound[:]) {
+		return ErrNoResolver
+	}
+	return err
+}
+
+type options struct {
+	universalResolver common.Address
+	blockNumber       *big.Int
+	fetcher           Fetcher
+	normalize         func(name string) (string, error)
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{universalResolver: UniversalResolver, normalize: Normalize}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(o)
+	}
+	return o
+}
+
+func (o *options) newCall(input []byte) *ccip.Call {
+	to := o.universalResolver
+	return ccip.NewCall(&w3types.Message{To: &to, Input: input}, o.blockNumber, nil, o.fetcher, 0)
+}
+
+// An Option configures a resolution.
+type Option func(*options)
+
+// WithUniversalResolver sets the address of the Universal Resolver. By default,
+// the [UniversalResolver] of Ethereum mainnet is used.
+func WithUniversalResolver(addr common.Address) Option {
+	return func(o *options) { o.universalResolver = addr }
+}
+
+// WithBlockNumber sets the block number at which names are resolved. By
+// default, names are resolved at the latest block.
+func WithBlockNumber(blockNumber *big.Int) Option {
+	return func(o *options) { o.blockNumber = blockNumber }
+}
+
+// WithFetcher sets the [Fetcher] that fetches the responses of offchain
+// lookups. By default, gateways are requested via HTTP using
+// [http.DefaultClient].
+func WithFetcher(fetcher Fetcher) Option {
+	return func(o *options) { o.fetcher = fetcher }
+}
+
+// WithNormalizer sets the function that normalizes names before they are
+// resolved, and that verifies the normalization of primary names. By default,
+// [Normalize] is used, which does not implement all rules of ENSIP-15. Use a
+// complete ENSIP-15 implementation, e.g. Normalize of the package
+// github.com/adraffy/go-ens-normalize/ensip15, to resolve arbitrary Unicode
+// names.
+func WithNormalizer(normalize func(name string) (string, error)) Option {
+	return func(o *options) { o.normalize = normalize }
+}
+
+// Fetcher fetches the response of an EIP-3668 offchain lookup with the given
+// sender and call data from the given gateway URLs.
+type Fetcher interface {
+	Fetch(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error)
+}
@@ -0,0 +1,96 @@
+package ens_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/ens"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	addrVitalik  = w3.A("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")
+	addrResolver = w3.A("0x231b0Ee14048e9dCcD1d247744d114a4EB5E8E63")
+)
+
+func TestAddr(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Address]{
+		{
+			Golden:  "addr",
+			Call:    ens.Addr("Vitalik.eth"),
+			WantRet: addrVitalik,
+		},
+		{
+			Golden: "addr",
+			Call: ens.Addr("vitalik\u3002eth", ens.WithNormalizer(func(name string) (string, error) {
+				return "vitalik.eth", nil
+			})),
+			WantRet: addrVitalik,
+		},
+		{
+			Golden:  "addr_no_resolver",
+			Call:    ens.Addr("nonexistent.eth"),
+			WantErr: w3.CallErrors{ens.ErrNoResolver},
+		},
+		{
+			Golden: "addr_offchain_lookup",
+			Call: ens.Addr("offchain.eth", ens.WithFetcher(fetcherFunc(func(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error) {
+				want := []any{[]string{"https://gateway.example/{sender}/{data}.json"}, ens.UniversalResolver, w3.B("0xc0fe")}
+				if diff := cmp.Diff(want, []any{urls, sender, callData}); diff != "" {
+					t.Fatalf("(-want, +got)\n%s", diff)
+				}
+				return w3.B("0xbeef"), nil
+			}))),
+			WantRet: addrVitalik,
+		},
+	})
+}
+
+func TestMultichainAddr(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]byte]{
+		{
+			Golden:  "multichain_addr",
+			Call:    ens.MultichainAddr("vitalik.eth", 0),
+			WantRet: w3.B("0x76a91462e907b15cbf27d5425399ebf6f0fb50ebb88f1888ac"),
+		},
+	})
+}
+
+func TestText(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[string]{
+		{
+			Golden:  "text",
+			Call:    ens.Text("vitalik.eth", "url"),
+			WantRet: "https://vitalik.ca",
+		},
+	})
+}
+
+func TestContenthash(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]byte]{
+		{
+			Golden:  "contenthash",
+			Call:    ens.Contenthash("vitalik.eth"),
+			WantRet: w3.B("0xe3010170122081e99109634060bae2c1e3f359cda33b2232152b0e010d8e9e8d5e8e8b2e5e8e"),
+		},
+	})
+}
+
+func TestResolver(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Address]{
+		{
+			Golden:  "resolver",
+			Call:    ens.Resolver("vitalik.eth"),
+			WantRet: addrResolver,
+		},
+	})
+}
+
+type fetcherFunc func(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error)
+
+func (f fetcherFunc) Fetch(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error) {
+	return f(ctx, urls, sender, callData)
+}
@@ -0,0 +1,128 @@
+package ens
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/ccip"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	// ErrNoName is returned by [Name] if no primary name is set for an
+	// address.
+	ErrNoName = errors.New("ens: no primary name")
+
+	// ErrNameMismatch is returned by [Name] if the primary name of an address
+	// does not resolve to the address.
+	ErrNameMismatch = errors.New("ens: primary name does not resolve to address")
+)
+
+var (
+	funcReverse = w3.MustNewFunc("reverse(bytes lookupAddress, uint256 coinType)", "string primary, address resolver, address reverseResolver")
+
+	// selectorReverseAddressMismatch is the selector of the Universal Resolver
+	// error ReverseAddressMismatch(string,bytes).
+	selectorReverseAddressMismatch = [4]byte(crypto.Keccak256([]byte("ReverseAddressMismatch(string,bytes)"))[:4])
+
+	// coinTypeETH is the SLIP-44 coin type of Ethereum.
+	coinTypeETH = big.NewInt(60)
+)
+
+// Name requests the primary name of the given address using reverse
+// resolution (ENSIP-3). The primary name is only returned, if it is normalized
+// (see [WithNormalizer]) and its forward resolution resolves to the given
+// address.
+//
+// The forward resolution is verified by the reverse function of the Universal
+// Resolver.
+func Name(addr common.Address, opts ...Option) w3types.RPCCallerFactory[string] {
+	return &nameFactory{addr: addr, opts: newOptions(opts)}
+}
+
+// ReverseName returns the name of the reverse record of the given address,
+// e.g. "<hex address>.addr.reverse".
+func ReverseName(addr common.Address) string {
+	return strings.ToLower(addr.Hex()[2:]) + ".addr.reverse"
+}
+
+type nameFactory struct {
+	// args
+	addr common.Address
+	opts *options
+
+	// returns
+	call *ccip.Call
+	ret  *string
+}
+
+func (f nameFactory) Returns(ret *string) w3types.RPCCaller {
+	f.ret = ret
+	return &f
+}
+
+func (f *nameFactory) CreateRequest() (rpc.BatchElem, error) {
+	input, err := funcReverse.EncodeArgs(f.addr.Bytes(), coinTypeETH)
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+
+	f.call = f.opts.newCall(input)
+	return f.call.CreateRequest()
+}
+
+func (f *nameFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := f.call.HandleResponse(elem); err != nil {
+		return nameErr(err)
+	}
+	if f.call.Pending() {
+		return nil
+	}
+	return f.decode()
+}
+
+func (f *nameFactory) FollowUp(ctx context.Context, call func(context.Context, ...w3types.RPCCaller) error) error {
+	if !f.call.Pending() {
+		return nil
+	}
+	if err := f.call.FollowUp(ctx, call); err != nil {
+		return nameErr(err)
+	}
+	return f.decode()
+}
+
+func (f *nameFactory) decode() error {
+	var name string
+	if err := funcReverse.DecodeReturns(f.call.Output(), &name, nil, nil); err != nil {
+		return err
+	}
+	if name == "" {
+		return ErrNoName
+	}
+	if normName, err := f.opts.normalize(name); err != nil || normName != name {
+		return ErrNameMismatch
+	}
+
+	*f.ret = name
+	return nil
+}
+
+// nameErr maps the errors of the reverse function of the Universal Resolver to
+// the errors of this package.
+func nameErr(err error) error {
+	data, ok := ccip.RevertData(err)
+	switch {
+	case ok && bytes.HasPrefix(data, selectorResolverNotFound[:]):
+		return ErrNoName
+	case ok && bytes.HasPrefix(data, selectorReverseAddressMismatch[:]):
+		return ErrNameMismatch
+	}
+	return err
+}
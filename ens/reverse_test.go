@@ -0,0 +1,36 @@
+package ens_test
+
+import (
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/ens"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestName(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[string]{
+		{
+			Golden:  "name",
+			Call:    ens.Name(addrVitalik),
+			WantRet: "vitalik.eth",
+		},
+		{
+			Golden:  "name_none",
+			Call:    ens.Name(addrVitalik),
+			WantErr: w3.CallErrors{ens.ErrNoName},
+		},
+		{
+			Golden:  "name_mismatch",
+			Call:    ens.Name(addrVitalik),
+			WantErr: w3.CallErrors{ens.ErrNameMismatch},
+		},
+	})
+}
+
+func TestReverseName(t *testing.T) {
+	want := "d8da6bf26964af9d7eed9e03e53415d37aa96045.addr.reverse"
+	if got := ens.ReverseName(w3.A("0xd8dA6BF26964aF9D7eEd9e03E53415D37aA96045")); want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
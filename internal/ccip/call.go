@@ -0,0 +1,135 @@
+package ccip
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Call is an "eth_call" that follows the offchain lookups of the called
+// contract. The output of the call is set, once the call has no pending
+// offchain lookup.
+type Call struct {
+	msg         *w3types.Message
+	blockNumber *big.Int
+	overrides   w3types.State
+	fetcher     Fetcher
+	maxLookups  int
+
+	output []byte
+	lookup *OffchainLookup
+}
+
+// NewCall returns a new Call of the given message at the given block with the
+// given state overrides, that follows at most maxLookups consecutive offchain
+// lookups using fetcher.
+func NewCall(msg *w3types.Message, blockNumber *big.Int, overrides w3types.State, fetcher Fetcher, maxLookups int) *Call {
+	if fetcher == nil {
+		fetcher = new(HTTPFetcher)
+	}
+	if maxLookups <= 0 {
+		maxLookups = MaxLookups
+	}
+	return &Call{
+		msg:         msg,
+		blockNumber: blockNumber,
+		overrides:   overrides,
+		fetcher:     fetcher,
+		maxLookups:  maxLookups,
+	}
+}
+
+// Output returns the output of the call.
+func (c *Call) Output() []byte { return c.output }
+
+// Pending returns true, if the call has a pending offchain lookup.
+func (c *Call) Pending() bool { return c.lookup != nil }
+
+// CreateRequest implements the [w3types.RPCCaller] interface.
+func (c *Call) CreateRequest() (rpc.BatchElem, error) {
+	return c.request(c.msg), nil
+}
+
+// HandleResponse implements the [w3types.RPCCaller] interface. It returns no
+// error, if the call reverted with an offchain lookup of the called contract.
+// Offchain lookups of other contracts, e.g. bubbled up from nested calls, are
+// returned as ordinary reverts.
+func (c *Call) HandleResponse(elem rpc.BatchElem) error {
+	c.lookup = nil
+	if err := elem.Error; err != nil {
+		lookup, ok := DecodeOffchainLookup(err)
+		if !ok || c.msg.To == nil || lookup.Sender != *c.msg.To {
+			return err
+		}
+		c.lookup = lookup
+		return nil
+	}
+
+	c.output = *elem.Result.(*hexutil.Bytes)
+	return nil
+}
+
+// FollowUp implements the [w3types.RPCFollowUpCaller] interface.
+func (c *Call) FollowUp(ctx context.Context, call func(context.Context, ...w3types.RPCCaller) error) error {
+	for i := 0; c.lookup != nil; i++ {
+		if i >= c.maxLookups {
+			return ErrTooManyLookups
+		}
+
+		resp, err := c.fetcher.Fetch(ctx, c.lookup.URLs, c.lookup.Sender, c.lookup.CallData)
+		if err != nil {
+			return err
+		}
+		input, err := c.lookup.CallbackInput(resp)
+		if err != nil {
+			return err
+		}
+
+		callback := &callback{call: c, msg: &w3types.Message{
+			From:  c.msg.From,
+			To:    c.msg.To,
+			Input: input,
+		}}
+		if err := call(ctx, callback); err != nil {
+			return err
+		}
+		if callback.err != nil {
+			return callback.err
+		}
+	}
+	return nil
+}
+
+func (c *Call) request(msg *w3types.Message) rpc.BatchElem {
+	args := []any{msg, module.BlockNumberArg(c.blockNumber)}
+	if len(c.overrides) > 0 {
+		args = append(args, c.overrides)
+	}
+
+	return rpc.BatchElem{
+		Method: "eth_call",
+		Args:   args,
+		Result: new(hexutil.Bytes),
+	}
+}
+
+// callback is the call of the callback function of an offchain lookup. Its
+// response is handled by the original call.
+type callback struct {
+	call *Call
+	msg  *w3types.Message
+	err  error
+}
+
+func (cb *callback) CreateRequest() (rpc.BatchElem, error) {
+	return cb.call.request(cb.msg), nil
+}
+
+func (cb *callback) HandleResponse(elem rpc.BatchElem) error {
+	cb.err = cb.call.HandleResponse(elem)
+	return nil
+}
@@ -0,0 +1,61 @@
+package ccip_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/ccip"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var funcOffchainLookup = w3.MustNewFunc("OffchainLookup(address sender, string[] urls, bytes callData, bytes4 callbackFunction, bytes extraData)", "")
+
+func TestCallHandleResponse(t *testing.T) {
+	tests := []struct {
+		Name        string
+		Sender      string
+		WantPending bool
+		WantErr     bool
+	}{
+		{
+			Name:        "lookup",
+			Sender:      "0x000000000000000000000000000000000000c0Fe",
+			WantPending: true,
+		},
+		{
+			Name:    "sender_mismatch",
+			Sender:  "0x000000000000000000000000000000000000dEaD",
+			WantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			revert, err := funcOffchainLookup.EncodeArgs(w3.A(test.Sender), []string{"https://gateway.example"}, w3.B("0xc0fe"), [4]byte{}, w3.B("0xdead"))
+			if err != nil {
+				t.Fatalf("Failed to encode revert: %v", err)
+			}
+			revertErr := &dataError{data: hexutil.Encode(revert)}
+
+			call := ccip.NewCall(&w3types.Message{To: &sender}, nil, nil, nil, 0)
+			gotErr := call.HandleResponse(rpc.BatchElem{Error: revertErr})
+			if test.WantErr != (gotErr != nil) {
+				t.Fatalf("want err %v, got %v", test.WantErr, gotErr)
+			}
+			if gotErr != nil && !errors.Is(gotErr, revertErr) {
+				t.Fatalf("want revert error, got %v", gotErr)
+			}
+			if test.WantPending != call.Pending() {
+				t.Fatalf("want pending %v, got %v", test.WantPending, call.Pending())
+			}
+		})
+	}
+}
+
+type dataError struct{ data string }
+
+func (err *dataError) Error() string  { return "execution reverted" }
+func (err *dataError) ErrorData() any { return err.data }
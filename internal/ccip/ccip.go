@@ -0,0 +1,87 @@
+/*
+Package ccip implements EIP-3668 CCIP-Read offchain lookups of calls.
+*/
+package ccip
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/internal/abi"
+)
+
+// MaxLookups is the default maximum number of consecutive offchain lookups of
+// a call.
+const MaxLookups = 4
+
+var ErrTooManyLookups = errors.New("w3: too many offchain lookups")
+
+var (
+	// selectorOffchainLookup is the selector of the error
+	// OffchainLookup(address,string[],bytes,bytes4,bytes).
+	selectorOffchainLookup = [4]byte{0x55, 0x6f, 0x18, 0x30}
+
+	argsOffchainLookup = mustParse("address sender, string[] urls, bytes callData, bytes4 callbackFunction, bytes extraData")
+	argsCallback       = mustParse("bytes response, bytes extraData")
+)
+
+// OffchainLookup is the decoded OffchainLookup revert of a call.
+type OffchainLookup struct {
+	Sender           common.Address
+	URLs             []string
+	CallData         []byte
+	CallbackFunction [4]byte
+	ExtraData        []byte
+}
+
+// RevertData returns the revert data of the given RPC error of a call. It
+// returns false, if err has no revert data.
+func RevertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+	hexData, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return nil, false
+	}
+	data, err := hexutil.Decode(hexData)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// DecodeOffchainLookup decodes the OffchainLookup revert of the given RPC
+// error. It returns false, if err is not an OffchainLookup revert.
+func DecodeOffchainLookup(err error) (*OffchainLookup, bool) {
+	data, ok := RevertData(err)
+	if !ok || len(data) < 4 || [4]byte(data[:4]) != selectorOffchainLookup {
+		return nil, false
+	}
+
+	lookup := new(OffchainLookup)
+	if err := argsOffchainLookup.Decode(data[4:],
+		&lookup.Sender, &lookup.URLs, &lookup.CallData, &lookup.CallbackFunction, &lookup.ExtraData,
+	); err != nil {
+		return nil, false
+	}
+	return lookup, true
+}
+
+// CallbackInput returns the input of the callback call of the lookup with the
+// given gateway response.
+func (l *OffchainLookup) CallbackInput(response []byte) ([]byte, error) {
+	return argsCallback.EncodeWithSelector(l.CallbackFunction, response, l.ExtraData)
+}
+
+func mustParse(s string) abi.Arguments {
+	args, err := abi.Parse(s)
+	if err != nil {
+		panic(fmt.Sprintf("ccip: %v", err))
+	}
+	return args
+}
@@ -0,0 +1,110 @@
+package ccip
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxResponseSize is the maximum size of a gateway response body in bytes.
+const maxResponseSize = 4 << 20
+
+// Fetcher fetches the response of an offchain lookup from the given gateway
+// URLs.
+type Fetcher interface {
+	Fetch(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error)
+}
+
+// HTTPFetcher is a [Fetcher] that requests the gateways via HTTP as specified
+// by EIP-3668. The gateways are requested in order, until a gateway responds
+// successfully or with a 4xx status code. If all gateways fail, the errors of
+// all requests are returned. Response bodies larger than 4 MiB are rejected.
+type HTTPFetcher struct {
+	Client *http.Client // HTTP client (http.DefaultClient if nil)
+}
+
+// Fetch implements the [Fetcher] interface.
+func (f *HTTPFetcher) Fetch(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error) {
+	if len(urls) <= 0 {
+		return nil, errors.New("w3: offchain lookup without gateway URLs")
+	}
+
+	var errs []error
+	for _, url := range urls {
+		resp, err := f.fetch(ctx, url, sender, callData)
+		if err == nil {
+			return resp, nil
+		}
+		errs = append(errs, err)
+
+		var statusErr *statusError
+		if errors.As(err, &statusErr) && statusErr.code >= 400 && statusErr.code < 500 {
+			break
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, errors.Join(errs...)
+}
+
+func (f *HTTPFetcher) fetch(ctx context.Context, url string, sender common.Address, callData []byte) ([]byte, error) {
+	senderHex := strings.ToLower(sender.Hex())
+	dataHex := hexutil.Encode(callData)
+
+	var (
+		req *http.Request
+		err error
+	)
+	if strings.Contains(url, "{data}") {
+		url = strings.ReplaceAll(url, "{sender}", senderHex)
+		url = strings.ReplaceAll(url, "{data}", dataHex)
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	} else {
+		url = strings.ReplaceAll(url, "{sender}", senderHex)
+		body, _ := json.Marshal(map[string]string{"data": dataHex, "sender": senderHex})
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err == nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, &statusError{code: resp.StatusCode}
+	}
+
+	var body struct {
+		Data hexutil.Bytes `json:"data"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(&body); err != nil {
+		return nil, fmt.Errorf("w3: invalid gateway response: %w", err)
+	}
+	return body.Data, nil
+}
+
+type statusError struct{ code int }
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("w3: gateway responded with status %d", e.code)
+}
@@ -0,0 +1,122 @@
+package ccip_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/ccip"
+)
+
+var sender = w3.A("0x000000000000000000000000000000000000c0Fe")
+
+func TestHTTPFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/get/0x000000000000000000000000000000000000c0fe/0xdead.json":
+			if r.Method != http.MethodGet {
+				t.Errorf("want GET, got %s", r.Method)
+			}
+			io.WriteString(w, `{"data":"0xbeef"}`)
+		case "/post":
+			if r.Method != http.MethodPost {
+				t.Errorf("want POST, got %s", r.Method)
+			}
+			var body map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("invalid body: %v", err)
+			}
+			if body["sender"] != "0x000000000000000000000000000000000000c0fe" || body["data"] != "0xdead" {
+				t.Errorf("unexpected body: %v", body)
+			}
+			io.WriteString(w, `{"data":"0xbeef"}`)
+		case "/too-large":
+			io.WriteString(w, `{"data":"0x`+strings.Repeat("00", 4<<20)+`"}`)
+		case "/unavailable":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	// closedURL is the URL of a server that refuses connections
+	closedSrv := httptest.NewServer(http.NotFoundHandler())
+	closedURL := closedSrv.URL
+	closedSrv.Close()
+
+	tests := []struct {
+		Name    string
+		URLs    []string
+		Want    []byte
+		WantErr string
+	}{
+		{
+			Name: "get",
+			URLs: []string{srv.URL + "/get/{sender}/{data}.json"},
+			Want: w3.B("0xbeef"),
+		},
+		{
+			Name: "post",
+			URLs: []string{srv.URL + "/post"},
+			Want: w3.B("0xbeef"),
+		},
+		{
+			Name: "fallback_on_5xx",
+			URLs: []string{srv.URL + "/unavailable", srv.URL + "/post"},
+			Want: w3.B("0xbeef"),
+		},
+		{
+			Name: "fallback_on_transport_error",
+			URLs: []string{closedURL + "/post", srv.URL + "/post"},
+			Want: w3.B("0xbeef"),
+		},
+		{
+			Name: "fallback_on_invalid_response",
+			URLs: []string{srv.URL + "/too-large", srv.URL + "/post"},
+			Want: w3.B("0xbeef"),
+		},
+		{
+			Name:    "all_failed",
+			URLs:    []string{srv.URL + "/unavailable", srv.URL + "/unavailable"},
+			WantErr: "w3: gateway responded with status 503\nw3: gateway responded with status 503",
+		},
+		{
+			Name:    "stop_on_4xx",
+			URLs:    []string{srv.URL + "/not-found", srv.URL + "/post"},
+			WantErr: "w3: gateway responded with status 404",
+		},
+		{
+			Name:    "too_large",
+			URLs:    []string{srv.URL + "/too-large"},
+			WantErr: "w3: invalid gateway response: unexpected EOF",
+		},
+		{
+			Name:    "no_urls",
+			WantErr: "w3: offchain lookup without gateway URLs",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := new(ccip.HTTPFetcher).Fetch(context.Background(), test.URLs, sender, w3.B("0xdead"))
+			if test.WantErr != "" {
+				if err == nil || err.Error() != test.WantErr {
+					t.Fatalf("want err %q, got %v", test.WantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Fetch: %v", err)
+			}
+			if string(test.Want) != string(got) {
+				t.Fatalf("want %x, got %x", test.Want, got)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ type Factory[T any] struct {
 
 	argsWrapper ArgsWrapperFunc
 	retWrapper  RetWrapperFunc[T]
+	allowNull   bool
 }
 
 func NewFactory[T any](method string, args []any, opts ...Option[T]) *Factory[T] {
@@ -70,7 +71,12 @@ func (f Factory[T]) HandleResponse(elem rpc.BatchElem) error {
 
 	ret := *(elem.Result.(*json.RawMessage))
 	if len(ret) == 0 || bytes.Equal(ret, null) {
-		return errNotFound
+		if f.allowNull {
+			var zero T
+			*f.ret = zero
+			return nil
+		}
+		return ErrNotFound
 	}
 
 	if err := json.Unmarshal(ret, f.retWrapper(f.ret)); err != nil {
@@ -91,6 +97,15 @@ func WithRetWrapper[T any](fn RetWrapperFunc[T]) Option[T] {
 	}
 }
 
+// WithAllowNull sets the return value to its zero value if the result is null,
+// instead of returning [ErrNotFound]. Use it for methods that return null on
+// success.
+func WithAllowNull[T any]() Option[T] {
+	return func(f *Factory[T]) {
+		f.allowNull = true
+	}
+}
+
 func HexBigRetWrapper(ret **big.Int) any {
 	*ret = new(big.Int)
 	return (*hexutil.Big)(*ret)
@@ -7,7 +7,8 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 )
 
-var errNotFound = errors.New("not found")
+// ErrNotFound is returned by [Factory.HandleResponse] if the result is null.
+var ErrNotFound = errors.New("not found")
 
 func BlockNumberArg(blockNumber *big.Int) string {
 	if blockNumber == nil {
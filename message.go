@@ -0,0 +1,152 @@
+package w3
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var pendingBlockNumber = big.NewInt(-1)
+
+// FillMessageCtx fills the missing fields of msg using the given client and
+// returns the unsigned transaction of msg (see [w3types.Message.ToTx]).
+//
+// Missing fields are fetched in a single batch request:
+//   - Nonce, if zero, is set to the pending nonce of the sender. A nonce of zero
+//     can not be distinguished from a missing nonce, so use the [WithNonce]
+//     option to set a nonce explicitly (e.g. zero, or when filling the same
+//     message again).
+//   - GasTipCap, if nil, is set to the suggested gas tip cap, and GasFeeCap, if
+//     nil, is set to twice the base fee of the latest block plus the gas tip
+//     cap. Both are only filled if GasPrice is nil.
+//   - BlobGasFeeCap, if nil, is set to twice the current blob base fee. It is
+//     only filled if BlobHashes are given.
+//   - AccessList, if nil, is created using [eth.AccessList]. It is only filled
+//     if GasPrice is nil.
+//   - Gas, if zero, is estimated using [eth.EstimateGas]. If the access list is
+//     created, the gas is estimated in a second request, as the gas used
+//     depends on the access list.
+func FillMessageCtx(ctx context.Context, client *Client, msg *w3types.Message, opts ...FillOption) (*types.Transaction, error) {
+	o := new(fillOptions)
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(o)
+	}
+
+	if msg.Input == nil && msg.Func != nil {
+		input, err := msg.Func.EncodeArgs(msg.Args...)
+		if err != nil {
+			return nil, err
+		}
+		msg.Input = input
+	}
+
+	var (
+		fillNonce      = o.nonce == nil && msg.Nonce == 0
+		fillAccessList = msg.GasPrice == nil && msg.AccessList == nil
+		fillGas        = msg.Gas == 0
+
+		chainID      uint64
+		nonce        uint64
+		gas          uint64
+		gasTipCap    *big.Int
+		latestHeader *types.Header
+		blobBaseFee  *big.Int
+		accessList   *eth.AccessListResponse
+	)
+	var calls []w3types.RPCCaller
+	if o.chainID == nil {
+		calls = append(calls, eth.ChainID().Returns(&chainID))
+	}
+	if fillNonce {
+		calls = append(calls, eth.Nonce(msg.From, pendingBlockNumber).Returns(&nonce))
+	}
+	if fillGas && !fillAccessList {
+		calls = append(calls, eth.EstimateGas(msg, nil).Returns(&gas))
+	}
+	if msg.GasPrice == nil {
+		if msg.GasTipCap == nil {
+			calls = append(calls, eth.GasTipCap().Returns(&gasTipCap))
+		}
+		if msg.GasFeeCap == nil {
+			calls = append(calls, eth.HeaderByNumber(nil).Returns(&latestHeader))
+		}
+	}
+	if fillAccessList {
+		calls = append(calls, eth.AccessList(msg, nil).Returns(&accessList))
+	}
+	if len(msg.BlobHashes) > 0 && msg.BlobGasFeeCap == nil {
+		calls = append(calls, eth.BlobBaseFee().Returns(&blobBaseFee))
+	}
+	if err := client.CallCtx(ctx, calls...); err != nil {
+		return nil, err
+	}
+
+	if accessList != nil {
+		msg.AccessList = accessList.AccessList
+		if fillGas {
+			if err := client.CallCtx(ctx, eth.EstimateGas(msg, nil).Returns(&gas)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if o.nonce != nil {
+		msg.Nonce = *o.nonce
+	} else if fillNonce {
+		msg.Nonce = nonce
+	}
+	if fillGas {
+		msg.Gas = gas
+	}
+	if gasTipCap != nil {
+		msg.GasTipCap = gasTipCap
+		if msg.GasFeeCap != nil && msg.GasTipCap.Cmp(msg.GasFeeCap) > 0 {
+			msg.GasTipCap = msg.GasFeeCap
+		}
+	}
+	if latestHeader != nil {
+		baseFee := latestHeader.BaseFee
+		if baseFee == nil {
+			baseFee = new(big.Int)
+		}
+		msg.GasFeeCap = new(big.Int).Add(msg.GasTipCap, new(big.Int).Lsh(baseFee, 1))
+	}
+	if blobBaseFee != nil {
+		msg.BlobGasFeeCap = new(big.Int).Lsh(blobBaseFee, 1)
+	}
+
+	if o.chainID != nil {
+		return msg.ToTx(o.chainID)
+	}
+	return msg.ToTx(new(big.Int).SetUint64(chainID))
+}
+
+// FillMessage is like [FillMessageCtx] with ctx equal to context.Background().
+func FillMessage(client *Client, msg *w3types.Message, opts ...FillOption) (*types.Transaction, error) {
+	return FillMessageCtx(context.Background(), client, msg, opts...)
+}
+
+type fillOptions struct {
+	nonce   *uint64
+	chainID *big.Int
+}
+
+// A FillOption configures [FillMessageCtx].
+type FillOption func(*fillOptions)
+
+// WithNonce sets the nonce of the message to the given nonce, instead of
+// filling it with the pending nonce of the sender.
+func WithNonce(nonce uint64) FillOption {
+	return func(o *fillOptions) { o.nonce = &nonce }
+}
+
+// WithChainID sets the chain ID of the transaction, instead of fetching it
+// using [eth.ChainID].
+func WithChainID(chainID *big.Int) FillOption {
+	return func(o *fillOptions) { o.chainID = chainID }
+}
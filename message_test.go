@@ -0,0 +1,90 @@
+package w3_test
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestFillMessage(t *testing.T) {
+	f, err := os.Open("testdata/fill_message.golden")
+	if err != nil {
+		t.Fatalf("Failed to open golden file: %v", err)
+	}
+	defer f.Close()
+
+	srv := rpctest.NewServer(t, f)
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	to := w3.A("0x000000000000000000000000000000000000dEaD")
+	tx, err := w3.FillMessage(client, &w3types.Message{
+		From:  w3.A("0x000000000000000000000000000000000000c0Fe"),
+		To:    &to,
+		Value: w3.I("1 ether"),
+	})
+	if err != nil {
+		t.Fatalf("Failed to fill message: %v", err)
+	}
+
+	want := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     7,
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("3 gwei"),
+		Gas:       27_352,
+		To:        &to,
+		Value:     w3.I("1 ether"),
+		AccessList: types.AccessList{{
+			Address:     to,
+			StorageKeys: []common.Hash{w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")},
+		}},
+	})
+	if want.Hash() != tx.Hash() {
+		t.Fatalf("Tx: want %s, got %s", want.Hash(), tx.Hash())
+	}
+}
+
+func TestFillMessage_withNonce(t *testing.T) {
+	f, err := os.Open("testdata/fill_message_with_nonce.golden")
+	if err != nil {
+		t.Fatalf("Failed to open golden file: %v", err)
+	}
+	defer f.Close()
+
+	srv := rpctest.NewServer(t, f)
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	to := w3.A("0x000000000000000000000000000000000000dEaD")
+	tx, err := w3.FillMessage(client, &w3types.Message{
+		From:     w3.A("0x000000000000000000000000000000000000c0Fe"),
+		To:       &to,
+		Value:    w3.I("1 ether"),
+		GasPrice: w3.I("1 gwei"),
+	}, w3.WithNonce(0))
+	if err != nil {
+		t.Fatalf("Failed to fill message: %v", err)
+	}
+
+	want := types.NewTx(&types.LegacyTx{
+		Nonce:    0,
+		GasPrice: w3.I("1 gwei"),
+		Gas:      21_000,
+		To:       &to,
+		Value:    w3.I("1 ether"),
+	})
+	if want.Hash() != tx.Hash() {
+		t.Fatalf("Tx: want %s, got %s", want.Hash(), tx.Hash())
+	}
+}
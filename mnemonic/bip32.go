@@ -0,0 +1,58 @@
+package mnemonic
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errInvalidKey = errors.New("mnemonic: invalid derived key")
+
+// deriveKey derives the BIP-32 private key of the given path from the given
+// seed.
+func deriveKey(seed []byte, path accounts.DerivationPath) (*ecdsa.PrivateKey, error) {
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	mac.Write(seed)
+	sum := mac.Sum(nil)
+	key, chainCode := sum[:32], sum[32:]
+
+	n := crypto.S256().Params().N
+	if k := new(big.Int).SetBytes(key); k.Sign() == 0 || k.Cmp(n) >= 0 {
+		return nil, errInvalidKey
+	}
+
+	for _, index := range path {
+		mac = hmac.New(sha512.New, chainCode)
+		if index >= 0x80000000 {
+			// hardened child
+			mac.Write([]byte{0})
+			mac.Write(key)
+		} else {
+			parent, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			mac.Write(crypto.CompressPubkey(&parent.PublicKey))
+		}
+		mac.Write(binary.BigEndian.AppendUint32(nil, index))
+		sum = mac.Sum(nil)
+
+		il := new(big.Int).SetBytes(sum[:32])
+		if il.Cmp(n) >= 0 {
+			return nil, errInvalidKey
+		}
+		child := il.Add(il, new(big.Int).SetBytes(key))
+		child.Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, errInvalidKey
+		}
+		key, chainCode = child.FillBytes(make([]byte, 32)), sum[32:]
+	}
+	return crypto.ToECDSA(key)
+}
@@ -0,0 +1,60 @@
+package mnemonic
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+)
+
+// Test vectors 1-3 of BIP-32.
+var (
+	seedVec1 = "0x000102030405060708090a0b0c0d0e0f"
+	seedVec2 = "0xfffcf9f6f3f0edeae7e4e1dedbd8d5d2cfccc9c6c3c0bdbab7b4b1aeaba8a5a29f9c999693908d8a8784817e7b7875726f6c696663605d5a5754514e4b484542"
+	seedVec3 = "0x4b381541583be4423346c643850da4b320e46a87ae3d2a4e6da11eba819cd4acba45d239319ac14f863b8d5ab5a0d0c64d2e8a1e7d1457df2e5a3c51c73235be"
+)
+
+func TestDeriveKey(t *testing.T) {
+	tests := []struct {
+		Seed    string
+		Path    string
+		WantKey string
+	}{
+		{Seed: seedVec1, Path: "m", WantKey: "0xe8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{Seed: seedVec1, Path: "m/0'", WantKey: "0xedb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{Seed: seedVec1, Path: "m/0'/1", WantKey: "0x3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{Seed: seedVec1, Path: "m/0'/1/2'", WantKey: "0xcbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{Seed: seedVec1, Path: "m/0'/1/2'/2", WantKey: "0x0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{Seed: seedVec1, Path: "m/0'/1/2'/2/1000000000", WantKey: "0x471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+		{Seed: seedVec2, Path: "m", WantKey: "0x4b03d6fc340455b363f51020ad3ecca4f0850280cf436c70c727923f6db46c3e"},
+		{Seed: seedVec2, Path: "m/0", WantKey: "0xabe74a98f6c7eabee0428f53798f0ab8aa1bd37873999041703c742f15ac7e1e"},
+		{Seed: seedVec2, Path: "m/0/2147483647'", WantKey: "0x877c779ad9687164e9c2f4f0f4ff0340814392330693ce95a58fe18fd52e6e93"},
+		{Seed: seedVec2, Path: "m/0/2147483647'/1", WantKey: "0x704addf544a06e5ee4bea37098463c23613da32020d604506da8c0518e1da4b7"},
+		{Seed: seedVec2, Path: "m/0/2147483647'/1/2147483646'", WantKey: "0xf1c7c871a54a804afe328b4c83a1c33b8e5ff48f5087273f04efa83b247d6a2d"},
+		{Seed: seedVec2, Path: "m/0/2147483647'/1/2147483646'/2", WantKey: "0xbb7d39bdb83ecf58f2fd82b6d918341cbef428661ef01ab97c28a4842125ac23"},
+		{Seed: seedVec3, Path: "m", WantKey: "0x00ddb80b067e0d4993197fe10f2657a844a384589847602d56f0c629c81aae32"},
+		{Seed: seedVec3, Path: "m/0'", WantKey: "0x491f7a2eebc7b57028e0d3faa0acda02e75c33b03c48fb288c41e2ea44e1daef"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Path, func(t *testing.T) {
+			var path accounts.DerivationPath
+			if test.Path != "m" {
+				var err error
+				if path, err = accounts.ParseDerivationPath(test.Path); err != nil {
+					t.Fatalf("Failed to parse path: %v", err)
+				}
+			}
+
+			key, err := deriveKey(w3.B(test.Seed), path)
+			if err != nil {
+				t.Fatalf("Failed to derive key: %v", err)
+			}
+			if got := hexutil.Encode(crypto.FromECDSA(key)); test.WantKey != got {
+				t.Fatalf("Key: want %s, got %s", test.WantKey, got)
+			}
+		})
+	}
+}
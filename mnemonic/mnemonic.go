@@ -0,0 +1,31 @@
+/*
+Package mnemonic implements a [w3.Signer] for accounts of BIP-39 mnemonics.
+*/
+package mnemonic
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/lmittmann/w3"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// NewSigner returns a new [w3.KeySigner] for the private key that is derived
+// from the given BIP-39 mnemonic and passphrase using the given BIP-44
+// derivation path. If path is nil, the default path m/44'/60'/0'/0/0 is used.
+func NewSigner(mnemonic, passphrase string, path accounts.DerivationPath) (*w3.KeySigner, error) {
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("mnemonic: invalid mnemonic: %w", err)
+	}
+
+	if path == nil {
+		path = accounts.DefaultBaseDerivationPath
+	}
+	key, err := deriveKey(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return w3.NewKeySigner(key), nil
+}
@@ -0,0 +1,51 @@
+package mnemonic_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/mnemonic"
+)
+
+func TestNewSigner(t *testing.T) {
+	const words = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	tests := []struct {
+		Mnemonic string
+		Path     accounts.DerivationPath
+		Want     common.Address
+		WantErr  bool
+	}{
+		{
+			Mnemonic: words,
+			Want:     w3.A("0x9858EfFD232B4033E47d90003D41EC34EcaEda94"),
+		},
+		{
+			Mnemonic: words,
+			Path:     accounts.DerivationPath{0x80000000 + 44, 0x80000000 + 60, 0x80000000 + 0, 0, 1},
+			Want:     w3.A("0x6Fac4D18c912343BF86fa7049364Dd4E424Ab9C0"),
+		},
+		{
+			Mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon",
+			WantErr:  true,
+		},
+	}
+
+	for i, test := range tests {
+		signer, err := mnemonic.NewSigner(test.Mnemonic, "", test.Path)
+		if test.WantErr {
+			if err == nil {
+				t.Fatalf("%d: want error", i)
+			}
+			continue
+		} else if err != nil {
+			t.Fatalf("%d: failed to create signer: %v", i, err)
+		}
+
+		if want, got := test.Want, signer.Address(); want != got {
+			t.Fatalf("%d: address: want %s, got %s", i, want, got)
+		}
+	}
+}
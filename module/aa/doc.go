@@ -0,0 +1,5 @@
+/*
+Package aa implements RPC API bindings for the methods of ERC-4337 account
+abstraction bundlers.
+*/
+package aa
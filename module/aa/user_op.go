@@ -0,0 +1,183 @@
+package aa
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	EntryPoint06 = common.HexToAddress("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789") // Address of the EntryPoint v0.6
+	EntryPoint07 = common.HexToAddress("0x0000000071727De22E5E9d8BAf0edAc6f37da032") // Address of the EntryPoint v0.7
+)
+
+// SendUserOp sends the given user operation to the bundler for the given
+// EntryPoint and returns its hash.
+func SendUserOp(op *w3types.UserOperation, entryPoint common.Address) w3types.RPCCallerFactory[common.Hash] {
+	return module.NewFactory[common.Hash](
+		"eth_sendUserOperation",
+		[]any{op, entryPoint},
+	)
+}
+
+// EstimateUserOpGas estimates the gas limits of the given user operation for
+// the given EntryPoint. The signature of the operation does not need to be
+// valid, but should have the same length as a valid signature. The optional
+// overrides are applied to the state the operation is simulated on.
+func EstimateUserOpGas(op *w3types.UserOperation, entryPoint common.Address, overrides w3types.State) w3types.RPCCallerFactory[*GasEstimate] {
+	args := []any{op, entryPoint}
+	if overrides != nil {
+		args = append(args, overrides)
+	}
+	return module.NewFactory[*GasEstimate](
+		"eth_estimateUserOperationGas",
+		args,
+	)
+}
+
+// UserOpByHash requests the user operation with the given hash.
+func UserOpByHash(hash common.Hash) w3types.RPCCallerFactory[*UserOpByHashResponse] {
+	return module.NewFactory[*UserOpByHashResponse](
+		"eth_getUserOperationByHash",
+		[]any{hash},
+	)
+}
+
+// UserOpReceipt requests the receipt of the user operation with the given
+// hash.
+func UserOpReceipt(hash common.Hash) w3types.RPCCallerFactory[*Receipt] {
+	return module.NewFactory[*Receipt](
+		"eth_getUserOperationReceipt",
+		[]any{hash},
+	)
+}
+
+// SupportedEntryPoints requests the addresses of the EntryPoints that are
+// supported by the bundler.
+func SupportedEntryPoints() w3types.RPCCallerFactory[[]common.Address] {
+	return module.NewFactory[[]common.Address](
+		"eth_supportedEntryPoints",
+		[]any{},
+	)
+}
+
+// GasEstimate is the estimated gas limits of a user operation. The paymaster
+// gas limits are only set for EntryPoint v0.7 operations with a paymaster.
+type GasEstimate struct {
+	PreVerificationGas            uint64
+	VerificationGasLimit          uint64
+	CallGasLimit                  uint64
+	PaymasterVerificationGasLimit uint64
+	PaymasterPostOpGasLimit       uint64
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (g *GasEstimate) UnmarshalJSON(data []byte) error {
+	type gasEstimate struct {
+		PreVerificationGas            hexutil.Uint64 `json:"preVerificationGas"`
+		VerificationGasLimit          hexutil.Uint64 `json:"verificationGasLimit"`
+		CallGasLimit                  hexutil.Uint64 `json:"callGasLimit"`
+		PaymasterVerificationGasLimit hexutil.Uint64 `json:"paymasterVerificationGasLimit"`
+		PaymasterPostOpGasLimit       hexutil.Uint64 `json:"paymasterPostOpGasLimit"`
+	}
+
+	var dec gasEstimate
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	g.PreVerificationGas = uint64(dec.PreVerificationGas)
+	g.VerificationGasLimit = uint64(dec.VerificationGasLimit)
+	g.CallGasLimit = uint64(dec.CallGasLimit)
+	g.PaymasterVerificationGasLimit = uint64(dec.PaymasterVerificationGasLimit)
+	g.PaymasterPostOpGasLimit = uint64(dec.PaymasterPostOpGasLimit)
+	return nil
+}
+
+// UserOpByHashResponse is a user operation and the transaction that included
+// it.
+type UserOpByHashResponse struct {
+	UserOp      *w3types.UserOperation
+	EntryPoint  common.Address
+	BlockNumber *big.Int
+	BlockHash   common.Hash
+	TxHash      common.Hash
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (u *UserOpByHashResponse) UnmarshalJSON(data []byte) error {
+	type userOpByHashResponse struct {
+		UserOp      *w3types.UserOperation `json:"userOperation"`
+		EntryPoint  common.Address         `json:"entryPoint"`
+		BlockNumber *hexutil.Big           `json:"blockNumber"`
+		BlockHash   common.Hash            `json:"blockHash"`
+		TxHash      common.Hash            `json:"transactionHash"`
+	}
+
+	var dec userOpByHashResponse
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	u.UserOp = dec.UserOp
+	u.EntryPoint = dec.EntryPoint
+	u.BlockNumber = (*big.Int)(dec.BlockNumber)
+	u.BlockHash = dec.BlockHash
+	u.TxHash = dec.TxHash
+	return nil
+}
+
+// Receipt is the receipt of a user operation.
+type Receipt struct {
+	UserOpHash    common.Hash
+	EntryPoint    common.Address
+	Sender        common.Address
+	Nonce         *big.Int
+	Paymaster     common.Address // Paymaster of the operation, or the zero address
+	ActualGasCost *big.Int
+	ActualGasUsed uint64
+	Success       bool
+	Reason        []byte         // Revert data of the operation, if it failed
+	Logs          []*types.Log   // Logs emitted by the operation
+	Receipt       *types.Receipt // Receipt of the transaction that included the operation
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	type receipt struct {
+		UserOpHash    common.Hash    `json:"userOpHash"`
+		EntryPoint    common.Address `json:"entryPoint"`
+		Sender        common.Address `json:"sender"`
+		Nonce         *hexutil.Big   `json:"nonce"`
+		Paymaster     common.Address `json:"paymaster"`
+		ActualGasCost *hexutil.Big   `json:"actualGasCost"`
+		ActualGasUsed hexutil.Uint64 `json:"actualGasUsed"`
+		Success       bool           `json:"success"`
+		Reason        hexutil.Bytes  `json:"reason"`
+		Logs          []*types.Log   `json:"logs"`
+		Receipt       *types.Receipt `json:"receipt"`
+	}
+
+	var dec receipt
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	r.UserOpHash = dec.UserOpHash
+	r.EntryPoint = dec.EntryPoint
+	r.Sender = dec.Sender
+	r.Nonce = (*big.Int)(dec.Nonce)
+	r.Paymaster = dec.Paymaster
+	r.ActualGasCost = (*big.Int)(dec.ActualGasCost)
+	r.ActualGasUsed = uint64(dec.ActualGasUsed)
+	r.Success = dec.Success
+	r.Reason = dec.Reason
+	r.Logs = dec.Logs
+	r.Receipt = dec.Receipt
+	return nil
+}
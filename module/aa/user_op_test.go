@@ -0,0 +1,153 @@
+package aa_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/aa"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	userOp = &w3types.UserOperation{
+		Sender:                        w3.A("0x000000000000000000000000000000000000c0Fe"),
+		Nonce:                         big.NewInt(1),
+		Factory:                       w3.APtr("0x000000000000000000000000000000000000fAc7"),
+		FactoryData:                   w3.B("0xc0fe"),
+		CallData:                      w3.B("0xb61d27f6"),
+		CallGasLimit:                  100_000,
+		VerificationGasLimit:          200_000,
+		PreVerificationGas:            50_000,
+		MaxFeePerGas:                  w3.I("30 gwei"),
+		MaxPriorityFeePerGas:          w3.I("1 gwei"),
+		Paymaster:                     w3.APtr("0x000000000000000000000000000000000000Fee0"),
+		PaymasterVerificationGasLimit: 60_000,
+		PaymasterPostOpGasLimit:       10_000,
+		PaymasterData:                 w3.B("0x01"),
+		Signature:                     w3.B("0x5191"),
+	}
+	userOpV06 = &w3types.UserOperation{
+		Version:              w3types.EntryPointV06,
+		Sender:               w3.A("0x000000000000000000000000000000000000c0Fe"),
+		Nonce:                big.NewInt(1),
+		CallData:             w3.B("0xb61d27f6"),
+		CallGasLimit:         100_000,
+		VerificationGasLimit: 200_000,
+		PreVerificationGas:   50_000,
+		MaxFeePerGas:         w3.I("30 gwei"),
+		MaxPriorityFeePerGas: w3.I("1 gwei"),
+		Signature:            w3.B("0x5191"),
+	}
+	userOpHash = userOp.Hash(aa.EntryPoint07, 1)
+)
+
+func TestSendUserOp(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden:  "send_user_operation",
+			Call:    aa.SendUserOp(userOp, aa.EntryPoint07),
+			WantRet: userOpHash,
+		},
+		{
+			Golden:  "send_user_operation__v06",
+			Call:    aa.SendUserOp(userOpV06, aa.EntryPoint06),
+			WantRet: userOpV06.Hash(aa.EntryPoint06, 1),
+		},
+	})
+}
+
+func TestEstimateUserOpGas(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*aa.GasEstimate]{
+		{
+			Golden: "estimate_user_operation_gas",
+			Call:   aa.EstimateUserOpGas(userOp, aa.EntryPoint07, nil),
+			WantRet: &aa.GasEstimate{
+				PreVerificationGas:            50_000,
+				VerificationGasLimit:          200_000,
+				CallGasLimit:                  100_000,
+				PaymasterVerificationGasLimit: 60_000,
+				PaymasterPostOpGasLimit:       10_000,
+			},
+		},
+		{
+			Golden: "estimate_user_operation_gas__overrides",
+			Call: aa.EstimateUserOpGas(userOp, aa.EntryPoint07, w3types.State{
+				w3.A("0x000000000000000000000000000000000000c0Fe"): {Balance: w3.I("1 ether")},
+			}),
+			WantRet: &aa.GasEstimate{
+				PreVerificationGas:   50_000,
+				VerificationGasLimit: 200_000,
+				CallGasLimit:         100_000,
+			},
+		},
+	})
+}
+
+func TestUserOpByHash(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*aa.UserOpByHashResponse]{
+		{
+			Golden: "get_user_operation_by_hash",
+			Call:   aa.UserOpByHash(userOpHash),
+			WantRet: &aa.UserOpByHashResponse{
+				UserOp:      userOp,
+				EntryPoint:  aa.EntryPoint07,
+				BlockNumber: big.NewInt(12965001),
+				BlockHash:   w3.H("0xa32d159805750cbe428b799a49b85dcb2300f61d806786f317260e721727d162"),
+				TxHash:      w3.H("0xed382cb554ad10e94921d263a56c670669d6c380bbdacdbf96fed625b7132a1d"),
+			},
+		},
+		{
+			Golden:  "get_user_operation_by_hash__not_found",
+			Call:    aa.UserOpByHash(userOpHash),
+			WantErr: errors.New("w3: call failed: not found"),
+		},
+	})
+}
+
+func TestUserOpReceipt(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*aa.Receipt]{
+		{
+			Golden: "get_user_operation_receipt",
+			Call:   aa.UserOpReceipt(userOpHash),
+			WantRet: &aa.Receipt{
+				UserOpHash:    userOpHash,
+				EntryPoint:    aa.EntryPoint07,
+				Sender:        w3.A("0x000000000000000000000000000000000000c0Fe"),
+				Nonce:         big.NewInt(1),
+				Paymaster:     w3.A("0x000000000000000000000000000000000000Fee0"),
+				ActualGasCost: big.NewInt(1339174604643328),
+				ActualGasUsed: 240_000,
+				Success:       true,
+				Reason:        []byte{},
+				Logs:          []*types.Log{},
+				Receipt: &types.Receipt{
+					Type:              2,
+					Status:            types.ReceiptStatusSuccessful,
+					CumulativeGasUsed: 8726063,
+					Logs:              []*types.Log{},
+					TxHash:            w3.H("0xed382cb554ad10e94921d263a56c670669d6c380bbdacdbf96fed625b7132a1d"),
+					GasUsed:           240_000,
+					EffectiveGasPrice: w3.I("31504967822"),
+					BlockHash:         w3.H("0xa32d159805750cbe428b799a49b85dcb2300f61d806786f317260e721727d162"),
+					BlockNumber:       big.NewInt(12965001),
+					TransactionIndex:  98,
+				},
+			},
+		},
+	})
+}
+
+func TestSupportedEntryPoints(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]common.Address]{
+		{
+			Golden:  "supported_entry_points",
+			Call:    aa.SupportedEntryPoints(),
+			WantRet: []common.Address{aa.EntryPoint07, aa.EntryPoint06},
+		},
+	})
+}
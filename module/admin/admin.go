@@ -4,6 +4,7 @@ Package admin implements RPC API bindings for methods in the "admin" namespace.
 package admin
 
 import (
+	"encoding/json"
 	"math/big"
 	"net"
 
@@ -49,6 +50,41 @@ func RemoveTrustedPeer(url *enode.Node) w3types.RPCCallerFactory[bool] {
 	)
 }
 
+// Peers returns information about the connected peers.
+func Peers() w3types.RPCCallerFactory[[]*PeerInfo] {
+	return module.NewFactory[[]*PeerInfo](
+		"admin_peers",
+		[]any{},
+	)
+}
+
+// Datadir returns the absolute path of the data directory of the node.
+func Datadir() w3types.RPCCallerFactory[string] {
+	return module.NewFactory[string](
+		"admin_datadir",
+		[]any{},
+	)
+}
+
+// ExportChain exports the blocks from first to last to the given file on the
+// node and returns a bool indicating success. If first or last is nil, the
+// export starts at the genesis block or ends at the head block.
+func ExportChain(file string, first, last *big.Int) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"admin_exportChain",
+		[]any{file, first, last},
+	)
+}
+
+// ImportChain imports the blocks from the given file on the node and returns a
+// bool indicating success.
+func ImportChain(file string) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"admin_importChain",
+		[]any{file},
+	)
+}
+
 // NodeInfo returns information about the running node.
 func NodeInfo() w3types.RPCCallerFactory[*NodeInfoResponse] {
 	return module.NewFactory[*NodeInfoResponse](
@@ -78,3 +114,21 @@ type ProtocolInfo struct {
 	Head       common.Hash `json:"head"`
 	Network    int         `json:"network"`
 }
+
+type PeerInfo struct {
+	ENR       string                     `json:"enr"`
+	Enode     *enode.Node                `json:"enode"`
+	ID        string                     `json:"id"`
+	Name      string                     `json:"name"`
+	Caps      []string                   `json:"caps"`
+	Network   *PeerNetworkInfo           `json:"network"`
+	Protocols map[string]json.RawMessage `json:"protocols"`
+}
+
+type PeerNetworkInfo struct {
+	LocalAddress  string `json:"localAddress"`
+	RemoteAddress string `json:"remoteAddress"`
+	Inbound       bool   `json:"inbound"`
+	Trusted       bool   `json:"trusted"`
+	Static        bool   `json:"static"`
+}
@@ -1,12 +1,14 @@
 package admin_test
 
 import (
+	"encoding/json"
 	"math/big"
 	"net"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/lmittmann/w3"
 	"github.com/lmittmann/w3/module/admin"
@@ -80,3 +82,136 @@ func TestNodeInfo(t *testing.T) {
 		},
 	}, cmpopts.IgnoreUnexported(big.Int{}, enode.Node{}, enr.Record{}))
 }
+
+func TestPeers(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*admin.PeerInfo]{
+		{
+			Golden: "peers",
+			Call:   admin.Peers(),
+			WantRet: []*admin.PeerInfo{
+				{
+					Enode: enode.MustParse("enode://a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c@52.16.188.185:30303"),
+					ID:    "a979fb575495b8d6db44f750317d0f4622bf4c2aa3365d6af7c284339968eef29b69ad0dce72a4d8db5ebb4968de0e3bec910127f134779fbcb0cb6d3331163c",
+					Name:  "Geth/v1.17.1-stable/linux-amd64/go1.25.0",
+					Caps:  []string{"eth/68", "snap/1"},
+					Network: &admin.PeerNetworkInfo{
+						LocalAddress:  "192.168.1.2:30303",
+						RemoteAddress: "52.16.188.185:30303",
+						Static:        true,
+					},
+					Protocols: map[string]json.RawMessage{
+						"eth":  json.RawMessage(`{"version":68}`),
+						"snap": json.RawMessage(`{"version":1}`),
+					},
+				},
+			},
+		},
+	}, cmpopts.IgnoreUnexported(enode.Node{}, enr.Record{}))
+}
+
+func TestDatadir(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[string]{
+		{
+			Golden:  "datadir",
+			Call:    admin.Datadir(),
+			WantRet: "/home/user/.ethereum",
+		},
+	})
+}
+
+func TestExportChain(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "export_chain",
+			Call:    admin.ExportChain("/tmp/chain.rlp", nil, nil),
+			WantRet: true,
+		},
+		{
+			Golden:  "export_chain__range",
+			Call:    admin.ExportChain("/tmp/chain.rlp", big.NewInt(1), big.NewInt(100)),
+			WantRet: true,
+		},
+	})
+}
+
+func TestImportChain(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "import_chain",
+			Call:    admin.ImportChain("/tmp/chain.rlp"),
+			WantRet: true,
+		},
+	})
+}
+
+func TestStartHTTP(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "start_http",
+			Call:    admin.StartHTTP(nil),
+			WantRet: true,
+		},
+		{
+			Golden: "start_http__config",
+			Call: admin.StartHTTP(&admin.HTTPConfig{
+				Host:   "localhost",
+				Port:   8545,
+				CORS:   []string{"*"},
+				APIs:   []string{"eth", "debug"},
+				VHosts: []string{"localhost", "example.com"},
+			}),
+			WantRet: true,
+		},
+	})
+}
+
+func TestStopHTTP(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "stop_http",
+			Call:    admin.StopHTTP(),
+			WantRet: true,
+		},
+	})
+}
+
+func TestStartWS(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden: "start_ws",
+			Call: admin.StartWS(&admin.WSConfig{
+				Host: "0.0.0.0",
+				Port: 8546,
+				APIs: []string{"eth"},
+			}),
+			WantRet: true,
+		},
+	})
+}
+
+func TestStopWS(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "stop_ws",
+			Call:    admin.StopWS(),
+			WantRet: true,
+		},
+	})
+}
+
+func TestPeerEvents(t *testing.T) {
+	ch := make(chan *admin.PeerEvent)
+	namespace, gotCh, params, err := admin.PeerEvents(ch).CreateRequest()
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	if namespace != "admin" {
+		t.Fatalf("Namespace: want %q, got %q", "admin", namespace)
+	}
+	if gotCh != (chan<- *admin.PeerEvent)(ch) {
+		t.Fatal("Channel mismatch")
+	}
+	if diff := cmp.Diff([]any{"peerEvents"}, params); diff != "" {
+		t.Fatalf("Params: (-want, +got)\n%s", diff)
+	}
+}
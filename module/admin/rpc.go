@@ -0,0 +1,90 @@
+package admin
+
+import (
+	"strings"
+
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// StartHTTP starts the HTTP RPC endpoint of the node with the given config and
+// returns a bool indicating success. Zero values of the config are set to the
+// defaults of the node.
+func StartHTTP(cfg *HTTPConfig) w3types.RPCCallerFactory[bool] {
+	if cfg == nil {
+		cfg = new(HTTPConfig)
+	}
+	return module.NewFactory[bool](
+		"admin_startHTTP",
+		[]any{optStr(cfg.Host), optInt(cfg.Port), optList(cfg.CORS), optList(cfg.APIs), optList(cfg.VHosts)},
+	)
+}
+
+// StopHTTP stops the HTTP RPC endpoint of the node and returns a bool
+// indicating success.
+func StopHTTP() w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"admin_stopHTTP",
+		[]any{},
+	)
+}
+
+// StartWS starts the WebSocket RPC endpoint of the node with the given config
+// and returns a bool indicating success. Zero values of the config are set to
+// the defaults of the node.
+func StartWS(cfg *WSConfig) w3types.RPCCallerFactory[bool] {
+	if cfg == nil {
+		cfg = new(WSConfig)
+	}
+	return module.NewFactory[bool](
+		"admin_startWS",
+		[]any{optStr(cfg.Host), optInt(cfg.Port), optList(cfg.Origins), optList(cfg.APIs)},
+	)
+}
+
+// StopWS stops the WebSocket RPC endpoint of the node and returns a bool
+// indicating success.
+func StopWS() w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"admin_stopWS",
+		[]any{},
+	)
+}
+
+// HTTPConfig is the config of the HTTP RPC endpoint of a node.
+type HTTPConfig struct {
+	Host   string   // Listening address, e.g. "localhost"
+	Port   int      // Listening port, e.g. 8545
+	CORS   []string // Allowed CORS domains
+	APIs   []string // Enabled API namespaces, e.g. "eth" or "debug"
+	VHosts []string // Allowed virtual hostnames
+}
+
+// WSConfig is the config of the WebSocket RPC endpoint of a node.
+type WSConfig struct {
+	Host    string   // Listening address, e.g. "localhost"
+	Port    int      // Listening port, e.g. 8546
+	Origins []string // Allowed origins
+	APIs    []string // Enabled API namespaces, e.g. "eth" or "debug"
+}
+
+func optStr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func optInt(i int) *int {
+	if i == 0 {
+		return nil
+	}
+	return &i
+}
+
+func optList(l []string) *string {
+	if len(l) == 0 {
+		return nil
+	}
+	return optStr(strings.Join(l, ","))
+}
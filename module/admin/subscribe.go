@@ -0,0 +1,41 @@
+package admin
+
+import (
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// PeerEvents subscribes to notifications about peers that are added to or
+// dropped from the node, and messages that are sent to or received from peers.
+func PeerEvents(ch chan<- *PeerEvent) w3types.RPCSubscriber {
+	return &peerEventsSubscription{ch: ch}
+}
+
+type peerEventsSubscription struct {
+	ch chan<- *PeerEvent
+}
+
+func (s *peerEventsSubscription) CreateRequest() (string, any, []any, error) {
+	return "admin", s.ch, []any{"peerEvents"}, nil
+}
+
+// PeerEventType is the type of a [PeerEvent].
+type PeerEventType string
+
+const (
+	PeerEventTypeAdd     PeerEventType = "add"     // Peer was added
+	PeerEventTypeDrop    PeerEventType = "drop"    // Peer was dropped
+	PeerEventTypeMsgSend PeerEventType = "msgsend" // Message was sent to the peer
+	PeerEventTypeMsgRecv PeerEventType = "msgrecv" // Message was received from the peer
+)
+
+type PeerEvent struct {
+	Type          PeerEventType `json:"type"`
+	Peer          enode.ID      `json:"peer"`
+	Error         string        `json:"error"`
+	Protocol      string        `json:"protocol"`
+	MsgCode       *uint64       `json:"msg_code"`
+	MsgSize       *uint32       `json:"msg_size"`
+	LocalAddress  string        `json:"local"`
+	RemoteAddress string        `json:"remote"`
+}
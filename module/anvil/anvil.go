@@ -0,0 +1,206 @@
+package anvil
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Namespace is the namespace of the methods of a development node that modify
+// its state.
+type Namespace string
+
+const (
+	Anvil   Namespace = "anvil"   // Anvil methods, e.g. "anvil_setBalance"
+	Hardhat Namespace = "hardhat" // Hardhat methods, e.g. "hardhat_setBalance"
+)
+
+// SetBalance sets the balance of the given address.
+func (ns Namespace) SetBalance(addr common.Address, balance *big.Int) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		string(ns)+"_setBalance",
+		[]any{addr, (*hexutil.Big)(balance)},
+		module.WithAllowNull[any](),
+	)
+}
+
+// SetCode sets the code of the given address.
+func (ns Namespace) SetCode(addr common.Address, code []byte) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		string(ns)+"_setCode",
+		[]any{addr, hexutil.Bytes(code)},
+		module.WithAllowNull[any](),
+	)
+}
+
+// SetNonce sets the nonce of the given address.
+func (ns Namespace) SetNonce(addr common.Address, nonce uint64) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		string(ns)+"_setNonce",
+		[]any{addr, hexutil.Uint64(nonce)},
+		module.WithAllowNull[any](),
+	)
+}
+
+// SetStorageAt sets the value of the given storage slot of the given address.
+func (ns Namespace) SetStorageAt(addr common.Address, slot, value common.Hash) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		string(ns)+"_setStorageAt",
+		[]any{addr, (*hexutil.Big)(slot.Big()), value},
+		module.WithAllowNull[any](),
+	)
+}
+
+// SetState returns the calls that set the given state. The nonce of an account
+// is only set if it is non-zero, its balance and code only if they are non-nil.
+// Storage slots that are not part of the given state are not modified.
+//
+// Example:
+//
+//	err := client.Call(anvil.SetState(w3types.State{
+//		addr: {Balance: w3.I("1 ether")},
+//	})...)
+func (ns Namespace) SetState(state w3types.State) []w3types.RPCCaller {
+	addrs := make([]common.Address, 0, len(state))
+	for addr := range state {
+		addrs = append(addrs, addr)
+	}
+	slices.SortFunc(addrs, common.Address.Cmp)
+
+	var calls []w3types.RPCCaller
+	for _, addr := range addrs {
+		acc := state[addr]
+		if acc == nil {
+			continue
+		}
+		if acc.Nonce > 0 {
+			calls = append(calls, ns.SetNonce(addr, acc.Nonce).Returns(new(any)))
+		}
+		if acc.Balance != nil {
+			calls = append(calls, ns.SetBalance(addr, acc.Balance).Returns(new(any)))
+		}
+		if acc.Code != nil {
+			calls = append(calls, ns.SetCode(addr, acc.Code).Returns(new(any)))
+		}
+
+		slots := make([]common.Hash, 0, len(acc.Storage))
+		for slot := range acc.Storage {
+			slots = append(slots, slot)
+		}
+		slices.SortFunc(slots, common.Hash.Cmp)
+		for _, slot := range slots {
+			calls = append(calls, ns.SetStorageAt(addr, slot, acc.Storage[slot]).Returns(new(any)))
+		}
+	}
+	return calls
+}
+
+// ImpersonateAccount allows to send transactions from the given address
+// without its private key.
+func (ns Namespace) ImpersonateAccount(addr common.Address) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		string(ns)+"_impersonateAccount",
+		[]any{addr},
+		module.WithAllowNull[any](),
+	)
+}
+
+// StopImpersonatingAccount stops impersonating the given address.
+func (ns Namespace) StopImpersonatingAccount(addr common.Address) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		string(ns)+"_stopImpersonatingAccount",
+		[]any{addr},
+		module.WithAllowNull[any](),
+	)
+}
+
+// Mine mines the given number of blocks. If interval is non-zero, the
+// timestamps of the mined blocks are interval seconds apart.
+func (ns Namespace) Mine(blocks, interval uint64) w3types.RPCCallerFactory[any] {
+	args := []any{hexutil.Uint64(blocks)}
+	if interval > 0 {
+		args = append(args, hexutil.Uint64(interval))
+	}
+	return module.NewFactory(
+		string(ns)+"_mine",
+		args,
+		module.WithAllowNull[any](),
+	)
+}
+
+// Reset resets the node to the given fork. If fork is nil, forking is
+// disabled.
+func (ns Namespace) Reset(fork *Fork) w3types.RPCCallerFactory[any] {
+	args := []any{}
+	if fork != nil {
+		args = append(args, &resetArgs{Forking: fork})
+	}
+	return module.NewFactory(
+		string(ns)+"_reset",
+		args,
+		module.WithAllowNull[any](),
+	)
+}
+
+// Fork is the fork configuration of [Namespace.Reset].
+type Fork struct {
+	URL         string   `json:"jsonRpcUrl,omitempty"`  // URL of the RPC endpoint to fork from
+	BlockNumber *big.Int `json:"blockNumber,omitempty"` // Optional block number to fork from, default: latest
+}
+
+type resetArgs struct {
+	Forking *Fork `json:"forking"`
+}
+
+// SetBalance sets the balance of the given address.
+func SetBalance(addr common.Address, balance *big.Int) w3types.RPCCallerFactory[any] {
+	return Anvil.SetBalance(addr, balance)
+}
+
+// SetCode sets the code of the given address.
+func SetCode(addr common.Address, code []byte) w3types.RPCCallerFactory[any] {
+	return Anvil.SetCode(addr, code)
+}
+
+// SetNonce sets the nonce of the given address.
+func SetNonce(addr common.Address, nonce uint64) w3types.RPCCallerFactory[any] {
+	return Anvil.SetNonce(addr, nonce)
+}
+
+// SetStorageAt sets the value of the given storage slot of the given address.
+func SetStorageAt(addr common.Address, slot, value common.Hash) w3types.RPCCallerFactory[any] {
+	return Anvil.SetStorageAt(addr, slot, value)
+}
+
+// SetState returns the calls that set the given state. See
+// [Namespace.SetState] for details.
+func SetState(state w3types.State) []w3types.RPCCaller {
+	return Anvil.SetState(state)
+}
+
+// ImpersonateAccount allows to send transactions from the given address
+// without its private key.
+func ImpersonateAccount(addr common.Address) w3types.RPCCallerFactory[any] {
+	return Anvil.ImpersonateAccount(addr)
+}
+
+// StopImpersonatingAccount stops impersonating the given address.
+func StopImpersonatingAccount(addr common.Address) w3types.RPCCallerFactory[any] {
+	return Anvil.StopImpersonatingAccount(addr)
+}
+
+// Mine mines the given number of blocks. If interval is non-zero, the
+// timestamps of the mined blocks are interval seconds apart.
+func Mine(blocks, interval uint64) w3types.RPCCallerFactory[any] {
+	return Anvil.Mine(blocks, interval)
+}
+
+// Reset resets the node to the given fork. If fork is nil, forking is
+// disabled.
+func Reset(fork *Fork) w3types.RPCCallerFactory[any] {
+	return Anvil.Reset(fork)
+}
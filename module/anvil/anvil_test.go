@@ -0,0 +1,156 @@
+package anvil_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal"
+	"github.com/lmittmann/w3/module/anvil"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var addr = w3.A("0x000000000000000000000000000000000000c0fe")
+
+func TestSetBalance(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "set_balance",
+			Call:   anvil.SetBalance(addr, w3.I("1 ether")),
+		},
+		{
+			Golden:  "set_balance__hardhat",
+			Call:    anvil.Hardhat.SetBalance(addr, w3.I("1 ether")),
+			WantRet: true,
+		},
+		{
+			Golden:  "set_balance__err",
+			Call:    anvil.SetBalance(addr, big.NewInt(1)),
+			WantErr: errors.New("w3: call failed: invalid params"),
+		},
+	})
+}
+
+func TestSetCode(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "set_code",
+			Call:   anvil.SetCode(addr, w3.B("0x600160005260206000f3")),
+		},
+	})
+}
+
+func TestSetNonce(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "set_nonce",
+			Call:   anvil.SetNonce(addr, 42),
+		},
+	})
+}
+
+func TestSetStorageAt(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "set_storage_at",
+			Call:   anvil.SetStorageAt(addr, common.BigToHash(big.NewInt(1)), common.BigToHash(big.NewInt(42))),
+		},
+	})
+}
+
+func TestSetState(t *testing.T) {
+	runCallerTestCases(t, []callerTestCase{
+		{
+			Golden: "set_state",
+			Calls: anvil.SetState(w3types.State{
+				addr: {Balance: big.NewInt(3)},
+				w3.A("0x000000000000000000000000000000000000c0dE"): {
+					Nonce:   1,
+					Balance: big.NewInt(2),
+					Code:    []byte{0x00},
+					Storage: w3types.Storage{
+						common.BigToHash(big.NewInt(2)): common.BigToHash(big.NewInt(43)),
+						common.BigToHash(big.NewInt(1)): common.BigToHash(big.NewInt(42)),
+					},
+				},
+			}),
+		},
+	})
+}
+
+func TestImpersonateAccount(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "impersonate_account",
+			Call:   anvil.ImpersonateAccount(addr),
+		},
+	})
+}
+
+func TestStopImpersonatingAccount(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "stop_impersonating_account",
+			Call:   anvil.StopImpersonatingAccount(addr),
+		},
+	})
+}
+
+func TestMine(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "mine",
+			Call:   anvil.Mine(10, 0),
+		},
+		{
+			Golden:  "mine__interval",
+			Call:    anvil.Hardhat.Mine(10, 12),
+			WantRet: true,
+		},
+	})
+}
+
+func TestReset(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "reset",
+			Call:   anvil.Reset(nil),
+		},
+		{
+			Golden: "reset__fork",
+			Call: anvil.Reset(&anvil.Fork{
+				URL:         "https://rpc.ankr.com/eth",
+				BlockNumber: big.NewInt(18_000_000),
+			}),
+		},
+	})
+}
+
+type callerTestCase struct {
+	Golden  string              // File name in local "testdata/" directory without ".golden" extension
+	Calls   []w3types.RPCCaller // Calls to test
+	WantErr error               // Wanted error of the calls
+}
+
+func runCallerTestCases(t *testing.T, tests []callerTestCase) {
+	t.Helper()
+
+	for _, test := range tests {
+		t.Run(test.Golden, func(t *testing.T) {
+			srv := rpctest.NewFileServer(t, "testdata/"+test.Golden+".golden")
+			defer srv.Close()
+
+			client := w3.MustDial(srv.URL())
+			defer client.Close()
+
+			gotErr := client.Call(test.Calls...)
+			if diff := cmp.Diff(test.WantErr, gotErr, internal.EquateErrors()); diff != "" {
+				t.Fatalf("Err: (-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
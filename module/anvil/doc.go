@@ -0,0 +1,5 @@
+/*
+Package anvil implements RPC API bindings for the methods of development nodes
+in the "anvil", "hardhat" and "evm" namespaces.
+*/
+package anvil
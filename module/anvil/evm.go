@@ -0,0 +1,48 @@
+package anvil
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Snapshot creates a snapshot of the state of the node and returns its id. The
+// state can be restored using [Revert].
+func Snapshot() w3types.RPCCallerFactory[*big.Int] {
+	return module.NewFactory(
+		"evm_snapshot",
+		[]any{},
+		module.WithRetWrapper(module.HexBigRetWrapper),
+	)
+}
+
+// Revert restores the state of the node to the snapshot with the given id and
+// returns a bool indicating success.
+func Revert(id *big.Int) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"evm_revert",
+		[]any{(*hexutil.Big)(id)},
+	)
+}
+
+// IncreaseTime increases the time of the node by the given duration. The
+// duration is truncated to seconds.
+func IncreaseTime(d time.Duration) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		"evm_increaseTime",
+		[]any{hexutil.Uint64(d / time.Second)},
+		module.WithAllowNull[any](),
+	)
+}
+
+// SetNextBlockTimestamp sets the timestamp of the next block.
+func SetNextBlockTimestamp(t time.Time) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		"evm_setNextBlockTimestamp",
+		[]any{hexutil.Uint64(t.Unix())},
+		module.WithAllowNull[any](),
+	)
+}
@@ -0,0 +1,49 @@
+package anvil_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/lmittmann/w3/module/anvil"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestSnapshot(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*big.Int]{
+		{
+			Golden:  "snapshot",
+			Call:    anvil.Snapshot(),
+			WantRet: big.NewInt(1),
+		},
+	})
+}
+
+func TestRevert(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "revert",
+			Call:    anvil.Revert(big.NewInt(1)),
+			WantRet: true,
+		},
+	})
+}
+
+func TestIncreaseTime(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden:  "increase_time",
+			Call:    anvil.IncreaseTime(time.Hour),
+			WantRet: float64(3600),
+		},
+	})
+}
+
+func TestSetNextBlockTimestamp(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "set_next_block_timestamp",
+			Call:   anvil.SetNextBlockTimestamp(time.Unix(1700000000, 0)),
+		},
+	})
+}
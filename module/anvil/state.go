@@ -0,0 +1,27 @@
+package anvil
+
+import (
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// DumpState requests the serialized state of the node. The state can be
+// restored using [LoadState].
+func DumpState() w3types.RPCCallerFactory[[]byte] {
+	return module.NewFactory(
+		"anvil_dumpState",
+		[]any{},
+		module.WithRetWrapper(module.HexBytesRetWrapper),
+	)
+}
+
+// LoadState merges the given serialized state, that was returned by
+// [DumpState], into the state of the node and returns a bool indicating
+// success.
+func LoadState(state []byte) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"anvil_loadState",
+		[]any{hexutil.Bytes(state)},
+	)
+}
@@ -0,0 +1,31 @@
+package anvil_test
+
+import (
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/anvil"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var state = w3.B("0x1f8b0800000000000000ab56ca4ccf4bcc51b2aa05004c25e0d70d000000")
+
+func TestDumpState(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]byte]{
+		{
+			Golden:  "dump_state",
+			Call:    anvil.DumpState(),
+			WantRet: state,
+		},
+	})
+}
+
+func TestLoadState(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "load_state",
+			Call:    anvil.LoadState(state),
+			WantRet: true,
+		},
+	})
+}
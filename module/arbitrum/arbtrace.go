@@ -0,0 +1,118 @@
+package arbitrum
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/module/trace"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// The arbtrace methods trace blocks that were produced before the Nitro
+// upgrade. Nitro nodes forward them to an Arbitrum Classic node. Blocks since
+// the Nitro upgrade are traced with the methods of the debug module.
+
+// TraceCall requests the traces of the given types of the given message at the
+// given pre-Nitro block.
+func TraceCall(msg *w3types.Message, blockNumber *big.Int, types ...trace.Type) w3types.RPCCallerFactory[*trace.Result] {
+	return module.NewFactory(
+		"arbtrace_call",
+		[]any{msg, typesArg(types), module.BlockNumberArg(blockNumber)},
+		module.WithArgsWrapper[*trace.Result](func(args []any) ([]any, error) {
+			if err := encodeInput(args[0].(*w3types.Message)); err != nil {
+				return nil, err
+			}
+			return args, nil
+		}),
+	)
+}
+
+// TraceCallMany requests the traces of the given types of the given messages,
+// which are executed on top of each other at the given pre-Nitro block.
+func TraceCallMany(msgs []*w3types.Message, blockNumber *big.Int, types ...trace.Type) w3types.RPCCallerFactory[[]*trace.Result] {
+	calls := make([][]any, len(msgs))
+	for i, msg := range msgs {
+		calls[i] = []any{msg, typesArg(types)}
+	}
+	return module.NewFactory(
+		"arbtrace_callMany",
+		[]any{calls, module.BlockNumberArg(blockNumber)},
+		module.WithArgsWrapper[[]*trace.Result](func(args []any) ([]any, error) {
+			for _, call := range args[0].([][]any) {
+				if err := encodeInput(call[0].(*w3types.Message)); err != nil {
+					return nil, err
+				}
+			}
+			return args, nil
+		}),
+	)
+}
+
+// TraceTx requests the call traces of the pre-Nitro transaction with the given
+// hash.
+func TraceTx(txHash common.Hash) w3types.RPCCallerFactory[[]*debug.FlatCallTrace] {
+	return module.NewFactory[[]*debug.FlatCallTrace](
+		"arbtrace_transaction",
+		[]any{txHash},
+	)
+}
+
+// TraceBlock requests the call traces of all transactions of the pre-Nitro
+// block with the given number.
+func TraceBlock(blockNumber *big.Int) w3types.RPCCallerFactory[[]*debug.FlatCallTrace] {
+	return module.NewFactory[[]*debug.FlatCallTrace](
+		"arbtrace_block",
+		[]any{module.BlockNumberArg(blockNumber)},
+	)
+}
+
+// TraceFilter requests the call traces of pre-Nitro blocks that match the given
+// filter.
+func TraceFilter(filter *trace.FilterQuery) w3types.RPCCallerFactory[[]*debug.FlatCallTrace] {
+	return module.NewFactory[[]*debug.FlatCallTrace](
+		"arbtrace_filter",
+		[]any{filter},
+	)
+}
+
+// TraceReplayTx requests the traces of the given types of the pre-Nitro
+// transaction with the given hash.
+func TraceReplayTx(txHash common.Hash, types ...trace.Type) w3types.RPCCallerFactory[*trace.Result] {
+	return module.NewFactory[*trace.Result](
+		"arbtrace_replayTransaction",
+		[]any{txHash, typesArg(types)},
+	)
+}
+
+// TraceReplayBlockTxs requests the traces of the given types of all
+// transactions of the pre-Nitro block with the given number.
+func TraceReplayBlockTxs(blockNumber *big.Int, types ...trace.Type) w3types.RPCCallerFactory[[]*trace.Result] {
+	return module.NewFactory[[]*trace.Result](
+		"arbtrace_replayBlockTransactions",
+		[]any{module.BlockNumberArg(blockNumber), typesArg(types)},
+	)
+}
+
+func typesArg(types []trace.Type) []trace.Type {
+	if types == nil {
+		return []trace.Type{trace.TypeTrace}
+	}
+	return types
+}
+
+// encodeInput sets the input of the given message to its encoded function
+// arguments, if the input is not set.
+func encodeInput(msg *w3types.Message) error {
+	if msg.Input != nil || msg.Func == nil {
+		return nil
+	}
+
+	input, err := msg.Func.EncodeArgs(msg.Args...)
+	if err != nil {
+		return err
+	}
+	msg.Input = input
+	return nil
+}
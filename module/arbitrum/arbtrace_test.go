@@ -0,0 +1,87 @@
+package arbitrum_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/arbitrum"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/module/trace"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	addrC0fe = w3.A("0x000000000000000000000000000000000000c0Fe")
+	addrDead = w3.A("0x000000000000000000000000000000000000dEaD")
+)
+
+func TestTraceCall(t *testing.T) {
+	funcBalanceOf := w3.MustNewFunc("balanceOf(address)", "uint256")
+	input := w3.B("0x70a08231000000000000000000000000000000000000000000000000000000000000c0fe")
+
+	rpctest.RunTestCases(t, []rpctest.TestCase[*trace.Result]{
+		{
+			Golden: "arbtrace_call",
+			Call: arbitrum.TraceCall(&w3types.Message{
+				From: addrC0fe,
+				To:   &addrDead,
+				Func: funcBalanceOf,
+				Args: []any{addrC0fe},
+			}, big.NewInt(1)),
+			WantRet: &trace.Result{
+				Output: common.BigToHash(big.NewInt(42)).Bytes(),
+				Trace: []*debug.FlatCallTrace{{
+					Type:         "call",
+					CallType:     "call",
+					From:         addrC0fe,
+					To:           addrDead,
+					Gas:          500_000_000,
+					Value:        new(big.Int),
+					Input:        input,
+					Output:       common.BigToHash(big.NewInt(42)).Bytes(),
+					TraceAddress: []uint64{},
+				}},
+			},
+		},
+	})
+}
+
+func TestTraceTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.FlatCallTrace]{
+		{
+			Golden: "arbtrace_transaction",
+			Call:   arbitrum.TraceTx(w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")),
+			WantRet: []*debug.FlatCallTrace{{
+				Type:         "call",
+				CallType:     "call",
+				From:         addrC0fe,
+				To:           addrDead,
+				Gas:          21_000,
+				Value:        big.NewInt(1),
+				Input:        []byte{},
+				Output:       []byte{},
+				TraceAddress: []uint64{},
+				BlockNumber:  1,
+				BlockHash:    w3.H("0x0000000000000000000000000000000000000000000000000000000000000002"),
+				TxHash:       w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+			}},
+		},
+	})
+}
+
+func TestTraceFilter(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.FlatCallTrace]{
+		{
+			Golden: "arbtrace_filter",
+			Call: arbitrum.TraceFilter(&trace.FilterQuery{
+				FromBlock: big.NewInt(1),
+				ToBlock:   big.NewInt(92_735),
+				ToAddress: []common.Address{addrDead},
+			}),
+			WantRet: []*debug.FlatCallTrace{},
+		},
+	})
+}
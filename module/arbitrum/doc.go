@@ -0,0 +1,7 @@
+/*
+Package arbitrum implements RPC API bindings for Arbitrum Nitro chains, such as
+Arbitrum One: transactions and receipts with Arbitrum specific fields, gas
+estimation using the NodeInterface virtual contract, and the "arbtrace"
+namespace for traces of pre-Nitro blocks.
+*/
+package arbitrum
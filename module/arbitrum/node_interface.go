@@ -0,0 +1,86 @@
+package arbitrum
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	// NodeInterface is the address of the NodeInterface virtual contract. It
+	// is not deployed on chain, but only accessible via calls to a node.
+	NodeInterface = common.HexToAddress("0x00000000000000000000000000000000000000C8")
+
+	// FuncGasEstimateComponents is the gasEstimateComponents function of the
+	// NodeInterface virtual contract, that estimates the gas of a call and the
+	// part of it that pays for the L1 data fee.
+	FuncGasEstimateComponents = w3.MustNewFunc(
+		"gasEstimateComponents(address to, bool contractCreation, bytes data)",
+		"uint64 gasEstimate, uint64 gasEstimateForL1, uint256 baseFee, uint256 l1BaseFeeEstimate",
+	)
+
+	// FuncGasEstimateL1Component is the gasEstimateL1Component function of the
+	// NodeInterface virtual contract, that estimates the part of the gas of a
+	// call that pays for the L1 data fee.
+	FuncGasEstimateL1Component = w3.MustNewFunc(
+		"gasEstimateL1Component(address to, bool contractCreation, bytes data)",
+		"uint64 gasEstimateForL1, uint256 baseFee, uint256 l1BaseFeeEstimate",
+	)
+)
+
+// GasEstimateComponents requests the gas estimate of the given message from the
+// NodeInterface virtual contract. The returns are gasEstimate (uint64),
+// gasEstimateForL1 (uint64), baseFee (*big.Int) and l1BaseFeeEstimate
+// (*big.Int).
+//
+// Example:
+//
+//	var (
+//		gasEstimate, gasEstimateForL1 uint64
+//		baseFee, l1BaseFeeEstimate     *big.Int
+//	)
+//	err := client.Call(
+//		arbitrum.GasEstimateComponents(msg).Returns(&gasEstimate, &gasEstimateForL1, &baseFee, &l1BaseFeeEstimate),
+//	)
+func GasEstimateComponents(msg *w3types.Message) *eth.CallFuncFactory {
+	return nodeInterfaceCall(FuncGasEstimateComponents, msg)
+}
+
+// GasEstimateL1Component requests the part of the gas estimate of the given
+// message that pays for the L1 data fee from the NodeInterface virtual
+// contract. The returns are gasEstimateForL1 (uint64), baseFee (*big.Int) and
+// l1BaseFeeEstimate (*big.Int).
+func GasEstimateL1Component(msg *w3types.Message) *eth.CallFuncFactory {
+	return nodeInterfaceCall(FuncGasEstimateL1Component, msg)
+}
+
+func nodeInterfaceCall(f w3types.Func, msg *w3types.Message) *eth.CallFuncFactory {
+	return eth.CallFunc(NodeInterface, &msgFunc{f}, msg).
+		From(msg.From).
+		Value(msg.Value)
+}
+
+// msgFunc wraps a NodeInterface function and encodes a message as its
+// arguments.
+type msgFunc struct {
+	w3types.Func
+}
+
+func (f *msgFunc) EncodeArgs(args ...any) ([]byte, error) {
+	msg := args[0].(*w3types.Message)
+
+	input := msg.Input
+	if input == nil && msg.Func != nil {
+		var err error
+		if input, err = msg.Func.EncodeArgs(msg.Args...); err != nil {
+			return nil, err
+		}
+	}
+
+	var to common.Address
+	if msg.To != nil {
+		to = *msg.To
+	}
+	return f.Func.EncodeArgs(to, msg.To == nil, input)
+}
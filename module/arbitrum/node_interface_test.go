@@ -0,0 +1,79 @@
+package arbitrum_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/arbitrum"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestGasEstimateComponents(t *testing.T) {
+	srv := rpctest.NewFileServer(t, "testdata/gas_estimate_components.golden")
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	var (
+		gasEstimate, gasEstimateForL1 uint64
+		baseFee, l1BaseFeeEstimate    big.Int
+	)
+	if err := client.Call(
+		arbitrum.GasEstimateComponents(&w3types.Message{
+			From: w3.A("0x000000000000000000000000000000000000c0Fe"),
+			To:   w3.APtr("0x000000000000000000000000000000000000dEaD"),
+			Func: w3.MustNewFunc("transfer(address,uint256)", "bool"),
+			Args: []any{w3.A("0x000000000000000000000000000000000000c0Fe"), big.NewInt(1)},
+		}).Returns(&gasEstimate, &gasEstimateForL1, &baseFee, &l1BaseFeeEstimate),
+	); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	if want := uint64(52_000); want != gasEstimate {
+		t.Fatalf("gasEstimate: want %d, got %d", want, gasEstimate)
+	}
+	if want := uint64(15_000); want != gasEstimateForL1 {
+		t.Fatalf("gasEstimateForL1: want %d, got %d", want, gasEstimateForL1)
+	}
+	if want := w3.I("0.01 gwei"); want.Cmp(&baseFee) != 0 {
+		t.Fatalf("baseFee: want %v, got %v", want, &baseFee)
+	}
+	if want := w3.I("8 gwei"); want.Cmp(&l1BaseFeeEstimate) != 0 {
+		t.Fatalf("l1BaseFeeEstimate: want %v, got %v", want, &l1BaseFeeEstimate)
+	}
+}
+
+func TestGasEstimateL1Component(t *testing.T) {
+	srv := rpctest.NewFileServer(t, "testdata/gas_estimate_l1_component.golden")
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	var (
+		gasEstimateForL1           uint64
+		baseFee, l1BaseFeeEstimate big.Int
+	)
+	if err := client.Call(
+		arbitrum.GasEstimateL1Component(&w3types.Message{
+			From:  w3.A("0x000000000000000000000000000000000000c0Fe"),
+			Value: big.NewInt(1),
+			Input: []byte{0x60, 0x00},
+		}).Returns(&gasEstimateForL1, &baseFee, &l1BaseFeeEstimate),
+	); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	if want := uint64(15_000); want != gasEstimateForL1 {
+		t.Fatalf("gasEstimateForL1: want %d, got %d", want, gasEstimateForL1)
+	}
+	if want := w3.I("0.01 gwei"); want.Cmp(&baseFee) != 0 {
+		t.Fatalf("baseFee: want %v, got %v", want, &baseFee)
+	}
+	if want := w3.I("8 gwei"); want.Cmp(&l1BaseFeeEstimate) != 0 {
+		t.Fatalf("l1BaseFeeEstimate: want %v, got %v", want, &l1BaseFeeEstimate)
+	}
+}
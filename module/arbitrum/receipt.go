@@ -0,0 +1,59 @@
+package arbitrum
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// TxReceipt requests the receipt of the transaction with the given hash,
+// including the Arbitrum specific fields.
+func TxReceipt(txHash common.Hash) w3types.RPCCallerFactory[*Receipt] {
+	return module.NewFactory[*Receipt](
+		"eth_getTransactionReceipt",
+		[]any{txHash},
+	)
+}
+
+// BlockReceipts requests all receipts of the transactions in the given block,
+// including the Arbitrum specific fields.
+func BlockReceipts(blockNumber *big.Int) w3types.RPCCallerFactory[[]*Receipt] {
+	return module.NewFactory[[]*Receipt](
+		"eth_getBlockReceipts",
+		[]any{module.BlockNumberArg(blockNumber)},
+	)
+}
+
+// Receipt is a transaction receipt of an Arbitrum chain.
+type Receipt struct {
+	*types.Receipt
+
+	GasUsedForL1  uint64 // Part of the gas used, that pays for the L1 data fee
+	L1BlockNumber uint64 // Number of the L1 block the L2 block was derived from
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	type receipt struct {
+		GasUsedForL1  hexutil.Uint64 `json:"gasUsedForL1"`
+		L1BlockNumber hexutil.Uint64 `json:"l1BlockNumber"`
+	}
+
+	r.Receipt = new(types.Receipt)
+	if err := json.Unmarshal(data, r.Receipt); err != nil {
+		return err
+	}
+
+	var dec receipt
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	r.GasUsedForL1 = uint64(dec.GasUsedForL1)
+	r.L1BlockNumber = uint64(dec.L1BlockNumber)
+	return nil
+}
@@ -0,0 +1,36 @@
+package arbitrum_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/arbitrum"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestTxReceipt(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*arbitrum.Receipt]{
+		{
+			Golden: "tx_receipt",
+			Call:   arbitrum.TxReceipt(w3.H("0x0000000000000000000000000000000000000000000000000000000000000041")),
+			WantRet: &arbitrum.Receipt{
+				Receipt: &types.Receipt{
+					Type:              types.DynamicFeeTxType,
+					Status:            types.ReceiptStatusSuccessful,
+					CumulativeGasUsed: 69_000,
+					Logs:              []*types.Log{},
+					TxHash:            w3.H("0x0000000000000000000000000000000000000000000000000000000000000041"),
+					GasUsed:           52_000,
+					EffectiveGasPrice: w3.I("0.01 gwei"),
+					BlockHash:         w3.H("0x0000000000000000000000000000000000000000000000000000000000000021"),
+					BlockNumber:       big.NewInt(208_000_010),
+					TransactionIndex:  3,
+				},
+				GasUsedForL1:  15_000,
+				L1BlockNumber: 21_134_000,
+			},
+		},
+	})
+}
@@ -0,0 +1,134 @@
+package arbitrum
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Arbitrum specific transaction types.
+const (
+	DepositTxType         = 0x64
+	UnsignedTxType        = 0x65
+	ContractTxType        = 0x66
+	RetryTxType           = 0x68
+	SubmitRetryableTxType = 0x69
+	InternalTxType        = 0x6a
+	LegacyTxType          = 0x78
+)
+
+// Tx requests the transaction with the given hash, including the Arbitrum
+// specific fields.
+func Tx(txHash common.Hash) w3types.RPCCallerFactory[*Transaction] {
+	return module.NewFactory[*Transaction](
+		"eth_getTransactionByHash",
+		[]any{txHash},
+	)
+}
+
+// Transaction is a transaction of an Arbitrum chain. Besides the Ethereum
+// transaction types, it can be of one of the Arbitrum specific transaction
+// types, whose fields are only set for the respective type.
+type Transaction struct {
+	Type             uint8
+	Hash             common.Hash
+	BlockHash        *common.Hash
+	BlockNumber      *big.Int
+	TransactionIndex *uint64
+	ChainID          *big.Int
+	From             common.Address
+	To               *common.Address
+	Nonce            uint64
+	Gas              uint64
+	GasPrice         *big.Int
+	GasFeeCap        *big.Int
+	GasTipCap        *big.Int
+	Value            *big.Int
+	Input            []byte
+
+	RequestID           *common.Hash    // Set for deposit, unsigned, contract, retry and submit retryable txs
+	RefundTo            *common.Address // Set for retry and submit retryable txs
+	MaxRefund           *big.Int        // Set for retry txs
+	SubmissionFeeRefund *big.Int        // Set for retry txs
+	TicketID            *common.Hash    // Set for retry txs
+	L1BaseFee           *big.Int        // Set for submit retryable txs
+	DepositValue        *big.Int        // Set for submit retryable txs
+	RetryTo             *common.Address // Set for submit retryable txs
+	RetryValue          *big.Int        // Set for submit retryable txs
+	RetryData           []byte          // Set for submit retryable txs
+	Beneficiary         *common.Address // Set for submit retryable txs
+	MaxSubmissionFee    *big.Int        // Set for submit retryable txs
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (tx *Transaction) UnmarshalJSON(data []byte) error {
+	type transaction struct {
+		Type             hexutil.Uint64  `json:"type"`
+		Hash             common.Hash     `json:"hash"`
+		BlockHash        *common.Hash    `json:"blockHash"`
+		BlockNumber      *hexutil.Big    `json:"blockNumber"`
+		TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
+		ChainID          *hexutil.Big    `json:"chainId"`
+		From             common.Address  `json:"from"`
+		To               *common.Address `json:"to"`
+		Nonce            hexutil.Uint64  `json:"nonce"`
+		Gas              hexutil.Uint64  `json:"gas"`
+		GasPrice         *hexutil.Big    `json:"gasPrice"`
+		GasFeeCap        *hexutil.Big    `json:"maxFeePerGas"`
+		GasTipCap        *hexutil.Big    `json:"maxPriorityFeePerGas"`
+		Value            *hexutil.Big    `json:"value"`
+		Input            hexutil.Bytes   `json:"input"`
+
+		RequestID           *common.Hash    `json:"requestId"`
+		RefundTo            *common.Address `json:"refundTo"`
+		MaxRefund           *hexutil.Big    `json:"maxRefund"`
+		SubmissionFeeRefund *hexutil.Big    `json:"submissionFeeRefund"`
+		TicketID            *common.Hash    `json:"ticketId"`
+		L1BaseFee           *hexutil.Big    `json:"l1BaseFee"`
+		DepositValue        *hexutil.Big    `json:"depositValue"`
+		RetryTo             *common.Address `json:"retryTo"`
+		RetryValue          *hexutil.Big    `json:"retryValue"`
+		RetryData           hexutil.Bytes   `json:"retryData"`
+		Beneficiary         *common.Address `json:"beneficiary"`
+		MaxSubmissionFee    *hexutil.Big    `json:"maxSubmissionFee"`
+	}
+
+	var dec transaction
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	tx.Type = uint8(dec.Type)
+	tx.Hash = dec.Hash
+	tx.BlockHash = dec.BlockHash
+	tx.BlockNumber = (*big.Int)(dec.BlockNumber)
+	tx.TransactionIndex = (*uint64)(dec.TransactionIndex)
+	tx.ChainID = (*big.Int)(dec.ChainID)
+	tx.From = dec.From
+	tx.To = dec.To
+	tx.Nonce = uint64(dec.Nonce)
+	tx.Gas = uint64(dec.Gas)
+	tx.GasPrice = (*big.Int)(dec.GasPrice)
+	tx.GasFeeCap = (*big.Int)(dec.GasFeeCap)
+	tx.GasTipCap = (*big.Int)(dec.GasTipCap)
+	tx.Value = (*big.Int)(dec.Value)
+	tx.Input = dec.Input
+
+	tx.RequestID = dec.RequestID
+	tx.RefundTo = dec.RefundTo
+	tx.MaxRefund = (*big.Int)(dec.MaxRefund)
+	tx.SubmissionFeeRefund = (*big.Int)(dec.SubmissionFeeRefund)
+	tx.TicketID = dec.TicketID
+	tx.L1BaseFee = (*big.Int)(dec.L1BaseFee)
+	tx.DepositValue = (*big.Int)(dec.DepositValue)
+	tx.RetryTo = dec.RetryTo
+	tx.RetryValue = (*big.Int)(dec.RetryValue)
+	tx.RetryData = dec.RetryData
+	tx.Beneficiary = dec.Beneficiary
+	tx.MaxSubmissionFee = (*big.Int)(dec.MaxSubmissionFee)
+	return nil
+}
@@ -0,0 +1,45 @@
+package arbitrum_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/arbitrum"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*arbitrum.Transaction]{
+		{
+			Golden: "get_transaction_by_hash",
+			Call:   arbitrum.Tx(w3.H("0x0000000000000000000000000000000000000000000000000000000000000041")),
+			WantRet: &arbitrum.Transaction{
+				Type:             arbitrum.SubmitRetryableTxType,
+				Hash:             w3.H("0x0000000000000000000000000000000000000000000000000000000000000041"),
+				BlockHash:        ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000021")),
+				BlockNumber:      big.NewInt(208_000_010),
+				TransactionIndex: ptr[uint64](1),
+				ChainID:          big.NewInt(42161),
+				From:             w3.A("0x000000000000000000000000000000000000c0Fe"),
+				To:               w3.APtr("0x000000000000000000000000000000000000006E"),
+				Gas:              100_000,
+				GasPrice:         w3.I("0.01 gwei"),
+				GasFeeCap:        w3.I("0.01 gwei"),
+				Value:            new(big.Int),
+				Input:            []byte{},
+				RequestID:        ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")),
+				RefundTo:         w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+				L1BaseFee:        w3.I("8 gwei"),
+				DepositValue:     w3.I("1 ether"),
+				RetryTo:          w3.APtr("0x000000000000000000000000000000000000dEaD"),
+				RetryValue:       big.NewInt(1),
+				RetryData:        []byte{},
+				Beneficiary:      w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+				MaxSubmissionFee: w3.I("0.0001 ether"),
+			},
+		},
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
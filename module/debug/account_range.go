@@ -0,0 +1,132 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// AccountRange requests a page of at most maxResults accounts of the state at
+// the block with the given number, starting at the account with the address
+// hash start. If noCode is true, the code of the accounts is omitted. If
+// noStorage is true, the storage of the accounts is omitted.
+func AccountRange(blockNumber *big.Int, start common.Hash, maxResults int, noCode, noStorage bool) w3types.RPCCallerFactory[*AccountRangeResponse] {
+	return module.NewFactory[*AccountRangeResponse](
+		"debug_accountRange",
+		[]any{module.BlockNumberArg(blockNumber), start, maxResults, noCode, noStorage, true},
+	)
+}
+
+// IterAccountRange returns an iterator over all accounts of the state at the
+// block with the given number, that are requested in pages of at most pageSize
+// accounts using [AccountRange]. The iteration stops after the first error.
+func IterAccountRange(ctx context.Context, client *w3.Client, blockNumber *big.Int, pageSize int, noCode, noStorage bool) iter.Seq2[*DumpAccount, error] {
+	return func(yield func(*DumpAccount, error) bool) {
+		var start common.Hash
+		for {
+			var page *AccountRangeResponse
+			if err := client.CallCtx(ctx, AccountRange(blockNumber, start, pageSize, noCode, noStorage).Returns(&page)); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, acc := range page.Accounts {
+				if !yield(acc, nil) {
+					return
+				}
+			}
+			if page.Next == nil {
+				return
+			}
+			start = *page.Next
+		}
+	}
+}
+
+type AccountRangeResponse struct {
+	Root     common.Hash
+	Accounts []*DumpAccount // Accounts in ascending order of their address hash
+	Next     *common.Hash   // Address hash of the first account of the next page, nil if this is the last page
+}
+
+type DumpAccount struct {
+	Address     *common.Address // Address, nil if the node does not know the preimage of the address hash
+	AddressHash common.Hash
+	Balance     *big.Int
+	Nonce       uint64
+	StorageRoot common.Hash
+	CodeHash    common.Hash
+	Code        []byte
+	Storage     map[common.Hash]common.Hash
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *AccountRangeResponse) UnmarshalJSON(data []byte) error {
+	type dumpAccount struct {
+		Address     *common.Address        `json:"address"`
+		AddressHash hexutil.Bytes          `json:"key"`
+		Balance     string                 `json:"balance"`
+		Nonce       uint64                 `json:"nonce"`
+		Root        hexutil.Bytes          `json:"root"`
+		CodeHash    hexutil.Bytes          `json:"codeHash"`
+		Code        hexutil.Bytes          `json:"code"`
+		Storage     map[common.Hash]string `json:"storage"`
+	}
+	type accountRange struct {
+		Root     string                  `json:"root"`
+		Accounts map[string]*dumpAccount `json:"accounts"`
+		Next     []byte                  `json:"next"`
+	}
+
+	var dec accountRange
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	r.Root = common.HexToHash(dec.Root)
+	r.Accounts = make([]*DumpAccount, 0, len(dec.Accounts))
+	for _, acc := range dec.Accounts {
+		balance, ok := new(big.Int).SetString(acc.Balance, 10)
+		if !ok {
+			return fmt.Errorf("invalid balance %q", acc.Balance)
+		}
+
+		var storage map[common.Hash]common.Hash
+		if acc.Storage != nil {
+			storage = make(map[common.Hash]common.Hash, len(acc.Storage))
+			for slot, val := range acc.Storage {
+				storage[slot] = common.HexToHash(val)
+			}
+		}
+
+		r.Accounts = append(r.Accounts, &DumpAccount{
+			Address:     acc.Address,
+			AddressHash: common.BytesToHash(acc.AddressHash),
+			Balance:     balance,
+			Nonce:       acc.Nonce,
+			StorageRoot: common.BytesToHash(acc.Root),
+			CodeHash:    common.BytesToHash(acc.CodeHash),
+			Code:        acc.Code,
+			Storage:     storage,
+		})
+	}
+	slices.SortFunc(r.Accounts, func(a, b *DumpAccount) int {
+		return bytes.Compare(a.AddressHash[:], b.AddressHash[:])
+	})
+
+	if dec.Next != nil {
+		next := common.BytesToHash(dec.Next)
+		r.Next = &next
+	}
+	return nil
+}
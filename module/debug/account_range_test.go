@@ -0,0 +1,80 @@
+package debug_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	accountRangeRoot = w3.H("0x00000000000000000000000000000000000000000000000000000000000000f1")
+	accountRangeAcc0 = &debug.DumpAccount{
+		AddressHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000010"),
+		Balance:     big.NewInt(0),
+		StorageRoot: w3.H("0x00000000000000000000000000000000000000000000000000000000000000e1"),
+		CodeHash:    w3.H("0x00000000000000000000000000000000000000000000000000000000000000c1"),
+		Code:        w3.B("0x6000"),
+		Storage: map[common.Hash]common.Hash{
+			w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"): w3.H("0x000000000000000000000000000000000000000000000000000000000000002a"),
+		},
+	}
+	accountRangeAcc1 = &debug.DumpAccount{
+		Address:     w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+		AddressHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000020"),
+		Balance:     w3.I("1 ether"),
+		Nonce:       1,
+		StorageRoot: types.EmptyRootHash,
+		CodeHash:    types.EmptyCodeHash,
+	}
+	accountRangeAcc2 = &debug.DumpAccount{
+		Address:     w3.APtr("0x000000000000000000000000000000000000dEaD"),
+		AddressHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000030"),
+		Balance:     big.NewInt(1),
+		StorageRoot: types.EmptyRootHash,
+		CodeHash:    types.EmptyCodeHash,
+	}
+)
+
+func TestAccountRange(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*debug.AccountRangeResponse]{
+		{
+			Golden: "accountRange",
+			Call:   debug.AccountRange(big.NewInt(1), common.Hash{}, 2, false, false),
+			WantRet: &debug.AccountRangeResponse{
+				Root:     accountRangeRoot,
+				Accounts: []*debug.DumpAccount{accountRangeAcc0, accountRangeAcc1},
+				Next:     ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000030")),
+			},
+		},
+	})
+}
+
+func TestIterAccountRange(t *testing.T) {
+	srv := rpctest.NewFileServer(t, "testdata/iterAccountRange.golden")
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	var gotAccounts []*debug.DumpAccount
+	for acc, err := range debug.IterAccountRange(context.Background(), client, big.NewInt(1), 2, false, false) {
+		if err != nil {
+			t.Fatalf("Failed to iterate accounts: %v", err)
+		}
+		gotAccounts = append(gotAccounts, acc)
+	}
+
+	wantAccounts := []*debug.DumpAccount{accountRangeAcc0, accountRangeAcc1, accountRangeAcc2}
+	if diff := cmp.Diff(wantAccounts, gotAccounts,
+		cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 }),
+	); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
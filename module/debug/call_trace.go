@@ -14,7 +14,7 @@ import (
 func CallTraceCall(msg *w3types.Message, blockNumber *big.Int, overrides w3types.State) w3types.RPCCallerFactory[*CallTrace] {
 	return module.NewFactory(
 		"debug_traceCall",
-		[]any{msg, module.BlockNumberArg(blockNumber), &traceConfig{Tracer: "callTracer", Overrides: overrides}},
+		[]any{msg, module.BlockNumberArg(blockNumber), &TracerConfig{Tracer: "callTracer", Overrides: overrides}},
 		module.WithArgsWrapper[*CallTrace](msgArgsWrapper),
 	)
 }
@@ -23,7 +23,7 @@ func CallTraceCall(msg *w3types.Message, blockNumber *big.Int, overrides w3types
 func CallTraceTx(txHash common.Hash, overrides w3types.State) w3types.RPCCallerFactory[*CallTrace] {
 	return module.NewFactory[*CallTrace](
 		"debug_traceTransaction",
-		[]any{txHash, &traceConfig{Tracer: "callTracer", Overrides: overrides}},
+		[]any{txHash, &TracerConfig{Tracer: "callTracer", Overrides: overrides}},
 	)
 }
 
@@ -38,6 +38,7 @@ type CallTrace struct {
 	Output       []byte
 	Error        string
 	RevertReason string
+	Logs         []*CallLog // Only set if the tracer config has WithLog
 	Calls        []*CallTrace
 }
 
@@ -54,6 +55,7 @@ func (c *CallTrace) UnmarshalJSON(data []byte) error {
 		Output       hexutil.Bytes  `json:"output"`
 		Error        string         `json:"error"`
 		RevertReason string         `json:"revertReason"`
+		Logs         []*CallLog     `json:"logs"`
 		Calls        []*CallTrace   `json:"calls"`
 	}
 
@@ -74,11 +76,36 @@ func (c *CallTrace) UnmarshalJSON(data []byte) error {
 	c.Output = dec.Output
 	c.Error = dec.Error
 	c.RevertReason = dec.RevertReason
+	c.Logs = dec.Logs
 	c.Calls = dec.Calls
 	return nil
 }
 
-type traceConfig struct {
-	Tracer    string        `json:"tracer"`
-	Overrides w3types.State `json:"stateOverrides,omitempty"`
+// CallLog is a log emitted by a call of a [CallTrace].
+type CallLog struct {
+	Address  common.Address
+	Topics   []common.Hash
+	Data     []byte
+	Position uint64 // Number of sub-calls of the call before the log was emitted
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (l *CallLog) UnmarshalJSON(data []byte) error {
+	type log struct {
+		Address  common.Address `json:"address"`
+		Topics   []common.Hash  `json:"topics"`
+		Data     hexutil.Bytes  `json:"data"`
+		Position hexutil.Uint64 `json:"position"`
+	}
+
+	var dec log
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	l.Address = dec.Address
+	l.Topics = dec.Topics
+	l.Data = dec.Data
+	l.Position = uint64(dec.Position)
+	return nil
 }
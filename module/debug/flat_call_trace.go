@@ -0,0 +1,107 @@
+package debug
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FlatCallTrace is a call frame of the "flatCallTracer", which is compatible
+// with the call traces of the Parity "trace" namespace.
+type FlatCallTrace struct {
+	Type         string         // "call", "create" or "suicide"
+	CallType     string         // "call", "staticcall", "delegatecall" or "callcode"
+	From         common.Address // Caller, or self-destructed contract
+	To           common.Address // Callee, created contract, or refund address
+	Gas          uint64
+	GasUsed      uint64
+	Value        *big.Int // Value, or refunded balance
+	Input        []byte   // Input, or init code
+	Output       []byte   // Output, or code of the created contract
+	Error        string
+	Subtraces    uint64
+	TraceAddress []uint64
+	BlockNumber  uint64
+	BlockHash    common.Hash
+	TxHash       common.Hash
+	TxIndex      uint64
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (c *FlatCallTrace) UnmarshalJSON(data []byte) error {
+	type action struct {
+		CallType      string         `json:"callType"`
+		From          common.Address `json:"from"`
+		To            common.Address `json:"to"`
+		Gas           hexutil.Uint64 `json:"gas"`
+		Value         *hexutil.Big   `json:"value"`
+		Input         hexutil.Bytes  `json:"input"`
+		Init          hexutil.Bytes  `json:"init"`
+		Address       common.Address `json:"address"`
+		RefundAddress common.Address `json:"refundAddress"`
+		Balance       *hexutil.Big   `json:"balance"`
+	}
+	type result struct {
+		GasUsed hexutil.Uint64 `json:"gasUsed"`
+		Output  hexutil.Bytes  `json:"output"`
+		Address common.Address `json:"address"`
+		Code    hexutil.Bytes  `json:"code"`
+	}
+	type call struct {
+		Type         string      `json:"type"`
+		Action       action      `json:"action"`
+		Result       *result     `json:"result"`
+		Error        string      `json:"error"`
+		Subtraces    uint64      `json:"subtraces"`
+		TraceAddress []uint64    `json:"traceAddress"`
+		BlockNumber  uint64      `json:"blockNumber"`
+		BlockHash    common.Hash `json:"blockHash"`
+		TxHash       common.Hash `json:"transactionHash"`
+		TxIndex      uint64      `json:"transactionPosition"`
+	}
+
+	var dec call
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	c.Type = dec.Type
+	c.CallType = dec.Action.CallType
+	c.Gas = uint64(dec.Action.Gas)
+	c.Error = dec.Error
+	c.Subtraces = dec.Subtraces
+	c.TraceAddress = dec.TraceAddress
+	c.BlockNumber = dec.BlockNumber
+	c.BlockHash = dec.BlockHash
+	c.TxHash = dec.TxHash
+	c.TxIndex = dec.TxIndex
+
+	switch dec.Type {
+	case "create":
+		c.From = dec.Action.From
+		c.Value = (*big.Int)(dec.Action.Value)
+		c.Input = dec.Action.Init
+		if dec.Result != nil {
+			c.To = dec.Result.Address
+			c.Output = dec.Result.Code
+		}
+	case "suicide":
+		c.From = dec.Action.Address
+		c.To = dec.Action.RefundAddress
+		c.Value = (*big.Int)(dec.Action.Balance)
+	default:
+		c.From = dec.Action.From
+		c.To = dec.Action.To
+		c.Value = (*big.Int)(dec.Action.Value)
+		c.Input = dec.Action.Input
+		if dec.Result != nil {
+			c.Output = dec.Result.Output
+		}
+	}
+	if dec.Result != nil {
+		c.GasUsed = uint64(dec.Result.GasUsed)
+	}
+	return nil
+}
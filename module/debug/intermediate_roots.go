@@ -0,0 +1,16 @@
+package debug
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// IntermediateRoots requests the state roots after each transaction of the
+// block with the given hash.
+func IntermediateRoots(blockHash common.Hash) w3types.RPCCallerFactory[[]common.Hash] {
+	return module.NewFactory[[]common.Hash](
+		"debug_intermediateRoots",
+		[]any{blockHash},
+	)
+}
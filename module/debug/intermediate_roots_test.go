@@ -0,0 +1,23 @@
+package debug_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestIntermediateRoots(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]common.Hash]{
+		{
+			Golden: "intermediateRoots",
+			Call:   debug.IntermediateRoots(w3.H("0x00000000000000000000000000000000000000000000000000000000000000b1")),
+			WantRet: []common.Hash{
+				w3.H("0x00000000000000000000000000000000000000000000000000000000000000a1"),
+				w3.H("0x00000000000000000000000000000000000000000000000000000000000000a2"),
+			},
+		},
+	})
+}
@@ -0,0 +1,51 @@
+package debug
+
+import (
+	"encoding/json"
+
+	"github.com/lmittmann/w3/w3types"
+)
+
+// MuxTrace is the result of the "muxTracer" (see [MuxTracer]). The results of
+// known tracers are decoded into their typed fields, the results of all
+// tracers are available in Raw.
+type MuxTrace struct {
+	Call         *CallTrace                 // Result of the "callTracer"
+	FlatCall     []*FlatCallTrace           // Result of the "flatCallTracer"
+	Prestate     w3types.State              // Result of the "prestateTracer"
+	PrestateDiff *StateDiff                 // Result of the "prestateTracer" in diff mode
+	FourByte     map[string]uint64          // Result of the "4byteTracer"
+	Raw          map[string]json.RawMessage // Results of all tracers by tracer name
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (t *MuxTrace) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &t.Raw); err != nil {
+		return err
+	}
+
+	for name, result := range t.Raw {
+		var err error
+		switch name {
+		case "callTracer":
+			err = json.Unmarshal(result, &t.Call)
+		case "flatCallTracer":
+			err = json.Unmarshal(result, &t.FlatCall)
+		case "prestateTracer":
+			var diff struct {
+				Post json.RawMessage `json:"post"`
+			}
+			if err = json.Unmarshal(result, &diff); err == nil && diff.Post != nil {
+				err = json.Unmarshal(result, &t.PrestateDiff)
+			} else if err == nil {
+				err = json.Unmarshal(result, prestateRetWrapper(&t.Prestate))
+			}
+		case "4byteTracer":
+			err = json.Unmarshal(result, &t.FourByte)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
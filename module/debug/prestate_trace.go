@@ -0,0 +1,134 @@
+package debug
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// PrestateTraceCall requests the prestate trace of the given message, i.e. the
+// state of all accounts and storage slots that are touched by the message
+// before its execution.
+func PrestateTraceCall(msg *w3types.Message, blockNumber *big.Int, overrides w3types.State) w3types.RPCCallerFactory[w3types.State] {
+	return module.NewFactory(
+		"debug_traceCall",
+		[]any{msg, module.BlockNumberArg(blockNumber), &TracerConfig{Tracer: "prestateTracer", Overrides: overrides}},
+		module.WithArgsWrapper[w3types.State](msgArgsWrapper),
+		module.WithRetWrapper(prestateRetWrapper),
+	)
+}
+
+// PrestateTraceTx requests the prestate trace of the transaction with the given
+// hash, i.e. the state of all accounts and storage slots that are touched by the
+// transaction before its execution.
+func PrestateTraceTx(txHash common.Hash, overrides w3types.State) w3types.RPCCallerFactory[w3types.State] {
+	return module.NewFactory(
+		"debug_traceTransaction",
+		[]any{txHash, &TracerConfig{Tracer: "prestateTracer", Overrides: overrides}},
+		module.WithRetWrapper(prestateRetWrapper),
+	)
+}
+
+// PrestateDiffTraceCall requests the prestate trace of the given message in diff
+// mode, i.e. the state of all accounts and storage slots that are modified by
+// the message before and after its execution.
+func PrestateDiffTraceCall(msg *w3types.Message, blockNumber *big.Int, overrides w3types.State) w3types.RPCCallerFactory[*StateDiff] {
+	return module.NewFactory(
+		"debug_traceCall",
+		[]any{msg, module.BlockNumberArg(blockNumber), &TracerConfig{Tracer: "prestateTracer", TracerConfig: &PrestateTracerConfig{DiffMode: true}, Overrides: overrides}},
+		module.WithArgsWrapper[*StateDiff](msgArgsWrapper),
+	)
+}
+
+// PrestateDiffTraceTx requests the prestate trace of the transaction with the
+// given hash in diff mode, i.e. the state of all accounts and storage slots
+// that are modified by the transaction before and after its execution.
+func PrestateDiffTraceTx(txHash common.Hash, overrides w3types.State) w3types.RPCCallerFactory[*StateDiff] {
+	return module.NewFactory[*StateDiff](
+		"debug_traceTransaction",
+		[]any{txHash, &TracerConfig{Tracer: "prestateTracer", TracerConfig: &PrestateTracerConfig{DiffMode: true}, Overrides: overrides}},
+	)
+}
+
+// StateDiff is the state of the accounts that are modified by a message or
+// transaction before (Pre) and after (Post) its execution.
+//
+// Post only contains the modified fields of an account. Accounts in Pre that
+// are not in Post were deleted.
+type StateDiff struct {
+	Pre  w3types.State
+	Post w3types.State
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (d *StateDiff) UnmarshalJSON(data []byte) error {
+	type stateDiff struct {
+		Pre  prestate `json:"pre"`
+		Post prestate `json:"post"`
+	}
+
+	var dec stateDiff
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	d.Pre = w3types.State(dec.Pre)
+	d.Post = w3types.State(dec.Post)
+	return nil
+}
+
+func prestateRetWrapper(ret *w3types.State) any { return (*prestate)(ret) }
+
+// prestate is the state as returned by the prestateTracer.
+type prestate w3types.State
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (s *prestate) UnmarshalJSON(data []byte) error {
+	type account struct {
+		Nonce   prestateNonce               `json:"nonce"`
+		Balance *hexutil.Big                `json:"balance"`
+		Code    hexutil.Bytes               `json:"code"`
+		Storage map[common.Hash]common.Hash `json:"storage"`
+	}
+
+	var dec map[common.Address]*account
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	*s = make(prestate, len(dec))
+	for addr, acc := range dec {
+		if acc == nil {
+			(*s)[addr] = &w3types.Account{}
+			continue
+		}
+		(*s)[addr] = &w3types.Account{
+			Nonce:   uint64(acc.Nonce),
+			Balance: (*big.Int)(acc.Balance),
+			Code:    acc.Code,
+			Storage: acc.Storage,
+		}
+	}
+	return nil
+}
+
+// prestateNonce is a nonce that is either encoded as JSON number, or as hex
+// string.
+type prestateNonce uint64
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (n *prestateNonce) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return (*hexutil.Uint64)(n).UnmarshalJSON(data)
+	}
+	nonce, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return err
+	}
+	*n = prestateNonce(nonce)
+	return nil
+}
@@ -0,0 +1,64 @@
+package debug_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestPrestateTraceCall(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[w3types.State]{
+		{
+			Golden: "prestateTraceCall",
+			Call: debug.PrestateTraceCall(&w3types.Message{
+				From:  w3.A("0x000000000000000000000000000000000000c0Fe"),
+				To:    w3.APtr("0x000000000000000000000000000000000000dEaD"),
+				Value: w3.I("1 ether"),
+			}, nil, w3types.State{
+				w3.A("0x000000000000000000000000000000000000c0Fe"): {Balance: w3.I("1 ether")},
+			}),
+			WantRet: w3types.State{
+				w3.A("0x0000000000000000000000000000000000000000"): {Balance: w3.I("8000 ether")},
+				w3.A("0x000000000000000000000000000000000000c0Fe"): {Balance: w3.I("1 ether")},
+				w3.A("0x000000000000000000000000000000000000dEaD"): {
+					Nonce:   1,
+					Balance: w3.I("2"),
+					Code:    w3.B("0x6000"),
+					Storage: w3types.Storage{
+						common.BigToHash(w3.I("1")): common.BigToHash(w3.I("42")),
+					},
+				},
+			},
+		},
+	}, cmpopts.IgnoreUnexported(w3types.Account{}))
+}
+
+func TestPrestateDiffTraceTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*debug.StateDiff]{
+		{
+			Golden: "prestateDiffTraceTx",
+			Call:   debug.PrestateDiffTraceTx(w3.H("0x6ea1798a2d0d21db18d6e45ca00f230160b05f172f6022aa138a0b605831d740"), nil),
+			WantRet: &debug.StateDiff{
+				Pre: w3types.State{
+					w3.A("0x000000000000000000000000000000000000c0Fe"): {Nonce: 1, Balance: w3.I("1 ether")},
+					w3.A("0x000000000000000000000000000000000000dEaD"): {
+						Balance: w3.I("0"),
+						Storage: w3types.Storage{common.BigToHash(w3.I("1")): {}},
+					},
+				},
+				Post: w3types.State{
+					w3.A("0x000000000000000000000000000000000000c0Fe"): {Nonce: 2, Balance: w3.I("0")},
+					w3.A("0x000000000000000000000000000000000000dEaD"): {
+						Balance: w3.I("1 ether"),
+						Storage: w3types.Storage{common.BigToHash(w3.I("1")): common.BigToHash(w3.I("42"))},
+					},
+				},
+			},
+		},
+	}, cmpopts.IgnoreUnexported(w3types.Account{}))
+}
@@ -0,0 +1,109 @@
+package debug
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// RawBlock requests the RLP encoded block with the given number and decodes
+// it.
+func RawBlock(blockNumber *big.Int) w3types.RPCCallerFactory[*types.Block] {
+	return module.NewFactory(
+		"debug_getRawBlock",
+		[]any{module.BlockNumberArg(blockNumber)},
+		module.WithRetWrapper(func(ret **types.Block) any { return &rawBlock{ret} }),
+	)
+}
+
+// RawHeader requests the RLP encoded header of the block with the given number
+// and decodes it.
+func RawHeader(blockNumber *big.Int) w3types.RPCCallerFactory[*types.Header] {
+	return module.NewFactory(
+		"debug_getRawHeader",
+		[]any{module.BlockNumberArg(blockNumber)},
+		module.WithRetWrapper(func(ret **types.Header) any { return &rawHeader{ret} }),
+	)
+}
+
+// RawReceipts requests the consensus encoded receipts of the block with the
+// given number and decodes them. Only the consensus fields of the receipts are
+// set.
+func RawReceipts(blockNumber *big.Int) w3types.RPCCallerFactory[types.Receipts] {
+	return module.NewFactory(
+		"debug_getRawReceipts",
+		[]any{module.BlockNumberArg(blockNumber)},
+		module.WithRetWrapper(func(ret *types.Receipts) any { return &rawReceipts{ret} }),
+	)
+}
+
+// RawTransaction requests the binary encoded transaction with the given hash
+// and decodes it.
+func RawTransaction(txHash common.Hash) w3types.RPCCallerFactory[*types.Transaction] {
+	return module.NewFactory(
+		"debug_getRawTransaction",
+		[]any{txHash},
+		module.WithRetWrapper(func(ret **types.Transaction) any { return &rawTx{ret} }),
+	)
+}
+
+type rawBlock struct{ ret **types.Block }
+
+func (r *rawBlock) UnmarshalJSON(data []byte) error {
+	var enc hexutil.Bytes
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	*r.ret = new(types.Block)
+	return rlp.DecodeBytes(enc, *r.ret)
+}
+
+type rawHeader struct{ ret **types.Header }
+
+func (r *rawHeader) UnmarshalJSON(data []byte) error {
+	var enc hexutil.Bytes
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	*r.ret = new(types.Header)
+	return rlp.DecodeBytes(enc, *r.ret)
+}
+
+type rawReceipts struct{ ret *types.Receipts }
+
+func (r *rawReceipts) UnmarshalJSON(data []byte) error {
+	var enc []hexutil.Bytes
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	receipts := make(types.Receipts, len(enc))
+	for i, rawReceipt := range enc {
+		receipts[i] = new(types.Receipt)
+		if err := receipts[i].UnmarshalBinary(rawReceipt); err != nil {
+			return err
+		}
+	}
+	*r.ret = receipts
+	return nil
+}
+
+type rawTx struct{ ret **types.Transaction }
+
+func (r *rawTx) UnmarshalJSON(data []byte) error {
+	var enc hexutil.Bytes
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return err
+	}
+
+	*r.ret = new(types.Transaction)
+	return (*r.ret).UnmarshalBinary(enc)
+}
@@ -0,0 +1,93 @@
+package debug_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	rawHeader = &types.Header{
+		ParentHash:  w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+		UncleHash:   types.EmptyUncleHash,
+		Coinbase:    w3.A("0x000000000000000000000000000000000000c0Fe"),
+		Root:        w3.H("0x0000000000000000000000000000000000000000000000000000000000000002"),
+		TxHash:      types.EmptyTxsHash,
+		ReceiptHash: types.EmptyReceiptsHash,
+		Difficulty:  new(big.Int),
+		Number:      big.NewInt(1),
+		GasLimit:    30_000_000,
+		Time:        1700000000,
+		Extra:       []byte{},
+		BaseFee:     big.NewInt(7),
+	}
+	rawTx = types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(10),
+		Gas:       21_000,
+		To:        w3.APtr("0x000000000000000000000000000000000000dEaD"),
+		Value:     big.NewInt(1),
+		Data:      []byte{},
+		V:         big.NewInt(0),
+		R:         big.NewInt(1),
+		S:         big.NewInt(1),
+	})
+)
+
+func TestRawBlock(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*types.Block]{
+		{
+			Golden: "getRawBlock",
+			Call:   debug.RawBlock(big.NewInt(1)),
+			WantRet: types.NewBlockWithHeader(rawHeader).WithBody(types.Body{
+				Transactions: []*types.Transaction{rawTx},
+			}),
+		},
+	})
+}
+
+func TestRawHeader(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*types.Header]{
+		{
+			Golden:  "getRawHeader",
+			Call:    debug.RawHeader(big.NewInt(1)),
+			WantRet: rawHeader,
+		},
+	})
+}
+
+func TestRawReceipts(t *testing.T) {
+	receipt := &types.Receipt{
+		Type:              types.DynamicFeeTxType,
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 21_000,
+		Logs: []*types.Log{
+			{Address: w3.A("0x000000000000000000000000000000000000dEaD"), Data: w3.B("0x01")},
+		},
+	}
+	receipt.Bloom = types.CreateBloom(receipt)
+
+	rpctest.RunTestCases(t, []rpctest.TestCase[types.Receipts]{
+		{
+			Golden:  "getRawReceipts",
+			Call:    debug.RawReceipts(big.NewInt(1)),
+			WantRet: types.Receipts{receipt},
+		},
+	})
+}
+
+func TestRawTransaction(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*types.Transaction]{
+		{
+			Golden:  "getRawTransaction",
+			Call:    debug.RawTransaction(rawTx.Hash()),
+			WantRet: rawTx,
+		},
+	})
+}
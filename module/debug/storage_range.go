@@ -0,0 +1,93 @@
+package debug
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"iter"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// StorageRangeAt requests a page of at most maxResults storage slots of the
+// contract at the given address, starting at the slot with the hash keyStart.
+// The storage is requested at the state after the transaction with the given
+// index in the block with the given hash.
+func StorageRangeAt(blockHash common.Hash, txIndex int, addr common.Address, keyStart common.Hash, maxResults int) w3types.RPCCallerFactory[*StorageRangeResponse] {
+	return module.NewFactory[*StorageRangeResponse](
+		"debug_storageRangeAt",
+		[]any{blockHash, txIndex, addr, keyStart, maxResults},
+	)
+}
+
+// IterStorageRangeAt returns an iterator over all storage slots of the contract
+// at the given address, that are requested in pages of at most pageSize slots
+// using [StorageRangeAt]. The iteration stops after the first error.
+func IterStorageRangeAt(ctx context.Context, client *w3.Client, blockHash common.Hash, txIndex int, addr common.Address, pageSize int) iter.Seq2[*StorageEntry, error] {
+	return func(yield func(*StorageEntry, error) bool) {
+		var keyStart common.Hash
+		for {
+			var page *StorageRangeResponse
+			if err := client.CallCtx(ctx, StorageRangeAt(blockHash, txIndex, addr, keyStart, pageSize).Returns(&page)); err != nil {
+				yield(nil, err)
+				return
+			}
+
+			for _, entry := range page.Storage {
+				if !yield(entry, nil) {
+					return
+				}
+			}
+			if page.NextKey == nil {
+				return
+			}
+			keyStart = *page.NextKey
+		}
+	}
+}
+
+type StorageRangeResponse struct {
+	Storage []*StorageEntry // Storage slots in ascending order of their key hash
+	NextKey *common.Hash    // Key hash of the first slot of the next page, nil if this is the last page
+}
+
+type StorageEntry struct {
+	KeyHash common.Hash  // Keccak256 hash of the slot
+	Key     *common.Hash // Slot, nil if the node does not know the preimage of the key hash
+	Value   common.Hash
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *StorageRangeResponse) UnmarshalJSON(data []byte) error {
+	type storageEntry struct {
+		Key   *common.Hash `json:"key"`
+		Value common.Hash  `json:"value"`
+	}
+	type storageRange struct {
+		Storage map[common.Hash]storageEntry `json:"storage"`
+		NextKey *common.Hash                 `json:"nextKey"`
+	}
+
+	var dec storageRange
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	r.Storage = make([]*StorageEntry, 0, len(dec.Storage))
+	for keyHash, entry := range dec.Storage {
+		r.Storage = append(r.Storage, &StorageEntry{
+			KeyHash: keyHash,
+			Key:     entry.Key,
+			Value:   entry.Value,
+		})
+	}
+	slices.SortFunc(r.Storage, func(a, b *StorageEntry) int {
+		return bytes.Compare(a.KeyHash[:], b.KeyHash[:])
+	})
+	r.NextKey = dec.NextKey
+	return nil
+}
@@ -0,0 +1,60 @@
+package debug_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	storageRangeBlockHash = w3.H("0x00000000000000000000000000000000000000000000000000000000000000b1")
+	storageRangeAddr      = w3.A("0x000000000000000000000000000000000000c0Fe")
+)
+
+func TestStorageRangeAt(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*debug.StorageRangeResponse]{
+		{
+			Golden: "storageRangeAt",
+			Call:   debug.StorageRangeAt(storageRangeBlockHash, 0, storageRangeAddr, common.Hash{}, 2),
+			WantRet: &debug.StorageRangeResponse{
+				Storage: []*debug.StorageEntry{
+					{KeyHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000010"), Value: w3.H("0x0000000000000000000000000000000000000000000000000000000000000011")},
+					{KeyHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000020"), Key: ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000002")), Value: w3.H("0x0000000000000000000000000000000000000000000000000000000000000022")},
+				},
+				NextKey: ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000030")),
+			},
+		},
+	})
+}
+
+func TestIterStorageRangeAt(t *testing.T) {
+	srv := rpctest.NewFileServer(t, "testdata/iterStorageRangeAt.golden")
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	var gotStorage []*debug.StorageEntry
+	for entry, err := range debug.IterStorageRangeAt(context.Background(), client, storageRangeBlockHash, 0, storageRangeAddr, 2) {
+		if err != nil {
+			t.Fatalf("Failed to iterate storage: %v", err)
+		}
+		gotStorage = append(gotStorage, entry)
+	}
+
+	wantStorage := []*debug.StorageEntry{
+		{KeyHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000010"), Value: w3.H("0x0000000000000000000000000000000000000000000000000000000000000011")},
+		{KeyHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000020"), Key: ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000002")), Value: w3.H("0x0000000000000000000000000000000000000000000000000000000000000022")},
+		{KeyHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000030"), Key: ptr(w3.H("0x0000000000000000000000000000000000000000000000000000000000000003")), Value: w3.H("0x0000000000000000000000000000000000000000000000000000000000000033")},
+	}
+	if diff := cmp.Diff(wantStorage, gotStorage); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func ptr[T any](v T) *T { return &v }
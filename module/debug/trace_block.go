@@ -0,0 +1,89 @@
+package debug
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// TraceBlockByNumber requests the traces of all transactions in the block with
+// the given number using the given tracer.
+func TraceBlockByNumber[T any](blockNumber *big.Int, tracer Tracer[T]) w3types.RPCCallerFactory[[]*TxTrace[T]] {
+	return module.NewFactory(
+		"debug_traceBlockByNumber",
+		[]any{module.BlockNumberArg(blockNumber), tracer},
+		module.WithRetWrapper(txTracesRetWrapper(tracer)),
+	)
+}
+
+// TraceBlockByHash requests the traces of all transactions in the block with
+// the given hash using the given tracer.
+func TraceBlockByHash[T any](blockHash common.Hash, tracer Tracer[T]) w3types.RPCCallerFactory[[]*TxTrace[T]] {
+	return module.NewFactory(
+		"debug_traceBlockByHash",
+		[]any{blockHash, tracer},
+		module.WithRetWrapper(txTracesRetWrapper(tracer)),
+	)
+}
+
+// TraceBadBlock requests the traces of all transactions in the bad block with
+// the given hash using the given tracer. Bad blocks are blocks that were
+// rejected by the node.
+func TraceBadBlock[T any](blockHash common.Hash, tracer Tracer[T]) w3types.RPCCallerFactory[[]*TxTrace[T]] {
+	return module.NewFactory(
+		"debug_traceBadBlock",
+		[]any{blockHash, tracer},
+		module.WithRetWrapper(txTracesRetWrapper(tracer)),
+	)
+}
+
+// TxTrace is the trace of a single transaction of a block. If tracing the
+// transaction failed, Error is set and Result is the zero value.
+type TxTrace[T any] struct {
+	TxHash common.Hash
+	Result T
+	Error  string
+}
+
+func txTracesRetWrapper[T any](tracer Tracer[T]) func(ret *[]*TxTrace[T]) any {
+	return func(ret *[]*TxTrace[T]) any {
+		return &txTraces[T]{ret: ret, tracer: tracer}
+	}
+}
+
+// txTraces decodes the traces of transactions using the result decoder of the
+// tracer.
+type txTraces[T any] struct {
+	ret    *[]*TxTrace[T]
+	tracer Tracer[T]
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (t *txTraces[T]) UnmarshalJSON(data []byte) error {
+	type txTrace struct {
+		TxHash common.Hash     `json:"txHash"`
+		Result json.RawMessage `json:"result"`
+		Error  string          `json:"error"`
+	}
+
+	var dec []txTrace
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	traces := make([]*TxTrace[T], len(dec))
+	for i, trace := range dec {
+		traces[i] = &TxTrace[T]{TxHash: trace.TxHash, Error: trace.Error}
+		if len(trace.Result) == 0 || string(trace.Result) == "null" {
+			continue
+		}
+		if err := json.Unmarshal(trace.Result, t.tracer.wrapRet(&traces[i].Result)); err != nil {
+			return err
+		}
+	}
+	*t.ret = traces
+	return nil
+}
@@ -0,0 +1,70 @@
+package debug_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestTraceBlockByNumber(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.TxTrace[*debug.CallTrace]]{
+		{
+			Golden: "traceBlockByNumber_callTracer",
+			Call:   debug.TraceBlockByNumber(big.NewInt(1), debug.CallTracer),
+			WantRet: []*debug.TxTrace[*debug.CallTrace]{
+				{
+					TxHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+					Result: &debug.CallTrace{
+						From:    w3.A("0x000000000000000000000000000000000000c0Fe"),
+						To:      w3.A("0x000000000000000000000000000000000000dEaD"),
+						Type:    "CALL",
+						Gas:     21_000,
+						GasUsed: 21_000,
+						Value:   w3.I("1 ether"),
+					},
+				},
+				{
+					TxHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000002"),
+					Error:  "execution timeout",
+				},
+			},
+		},
+	})
+}
+
+func TestTraceBlockByHash(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.TxTrace[w3types.State]]{
+		{
+			Golden: "traceBlockByHash_prestateTracer",
+			Call:   debug.TraceBlockByHash(w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"), debug.PrestateTracer),
+			WantRet: []*debug.TxTrace[w3types.State]{
+				{
+					TxHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+					Result: w3types.State{
+						w3.A("0x000000000000000000000000000000000000c0Fe"): {Nonce: 1, Balance: w3.I("1 ether")},
+					},
+				},
+			},
+		},
+	}, cmpopts.IgnoreUnexported(w3types.Account{}))
+}
+
+func TestTraceBadBlock(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.TxTrace[map[string]uint64]]{
+		{
+			Golden: "traceBadBlock_4byteTracer",
+			Call:   debug.TraceBadBlock(w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"), debug.FourByteTracer),
+			WantRet: []*debug.TxTrace[map[string]uint64]{
+				{
+					TxHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+					Result: map[string]uint64{"0x70a08231-32": 2, "0xa9059cbb-64": 1},
+				},
+			},
+		},
+	})
+}
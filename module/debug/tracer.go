@@ -0,0 +1,166 @@
+package debug
+
+import (
+	"encoding/json"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// TraceCallWithTracer requests the trace of the given message using the given
+// tracer.
+func TraceCallWithTracer[T any](msg *w3types.Message, blockNumber *big.Int, tracer Tracer[T]) w3types.RPCCallerFactory[T] {
+	return module.NewFactory(
+		"debug_traceCall",
+		[]any{msg, module.BlockNumberArg(blockNumber), tracer},
+		module.WithArgsWrapper[T](msgArgsWrapper),
+		module.WithRetWrapper(tracer.wrapRet),
+	)
+}
+
+// TraceTxWithTracer requests the trace of the transaction with the given hash
+// using the given tracer.
+func TraceTxWithTracer[T any](txHash common.Hash, tracer Tracer[T]) w3types.RPCCallerFactory[T] {
+	return module.NewFactory(
+		"debug_traceTransaction",
+		[]any{txHash, tracer},
+		module.WithRetWrapper(tracer.wrapRet),
+	)
+}
+
+// Tracer is the configuration of a tracer whose results are decoded into T.
+type Tracer[T any] struct {
+	config     any              // trace config
+	retWrapper func(ret *T) any // optional wrapper to decode results
+}
+
+// NewTracer returns a tracer with the given config whose results are decoded
+// into T. Use T = [json.RawMessage] for the results of custom JavaScript
+// tracers.
+//
+// Example:
+//
+//	tracer := debug.NewTracer[*debug.CallTrace](&debug.TracerConfig{
+//		Tracer:       "callTracer",
+//		TracerConfig: &debug.CallTracerConfig{WithLog: true},
+//	})
+func NewTracer[T any](config *TracerConfig) Tracer[T] {
+	if config == nil {
+		config = &TracerConfig{}
+	}
+	t := Tracer[T]{config: config}
+	if _, ok := any((*T)(nil)).(*w3types.State); ok {
+		t.retWrapper = func(ret *T) any { return prestateRetWrapper(any(ret).(*w3types.State)) }
+	}
+	return t
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (t Tracer[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.config)
+}
+
+func (t Tracer[T]) wrapRet(ret *T) any {
+	if t.retWrapper != nil {
+		return t.retWrapper(ret)
+	}
+	return ret
+}
+
+var (
+	// CallTracer traces the call frames of transactions (see [CallTrace]).
+	CallTracer = NewTracer[*CallTrace](&TracerConfig{Tracer: "callTracer"})
+
+	// FlatCallTracer traces the call frames of transactions as flat list
+	// (see [FlatCallTrace]).
+	FlatCallTracer = NewTracer[[]*FlatCallTrace](&TracerConfig{Tracer: "flatCallTracer"})
+
+	// PrestateTracer traces the state of all accounts and storage slots that
+	// are touched by transactions before their execution.
+	PrestateTracer = NewTracer[w3types.State](&TracerConfig{Tracer: "prestateTracer"})
+
+	// PrestateDiffTracer traces the state of all accounts and storage slots
+	// that are modified by transactions before and after their execution.
+	PrestateDiffTracer = NewTracer[*StateDiff](&TracerConfig{
+		Tracer:       "prestateTracer",
+		TracerConfig: &PrestateTracerConfig{DiffMode: true},
+	})
+
+	// FourByteTracer traces the function selectors and calldata sizes of all
+	// calls of transactions. Results map "<selector>-<calldata size>" to the
+	// number of occurrences.
+	FourByteTracer = NewTracer[map[string]uint64](&TracerConfig{Tracer: "4byteTracer"})
+)
+
+// StructLogTracer returns a tracer that traces the executed opcodes of
+// transactions with the given config (see [Trace]).
+func StructLogTracer(config *TraceConfig) Tracer[*Trace] {
+	if config == nil {
+		config = &TraceConfig{}
+	}
+	return Tracer[*Trace]{config: config}
+}
+
+// MuxTracer returns a tracer that runs the given tracers at once. The tracers
+// map the name of a tracer to its tracer specific config, which may be nil.
+func MuxTracer(tracers map[string]any) Tracer[*MuxTrace] {
+	config := make(map[string]any, len(tracers))
+	for name, tracerConfig := range tracers {
+		if tracerConfig == nil {
+			tracerConfig = struct{}{}
+		}
+		config[name] = tracerConfig
+	}
+	return NewTracer[*MuxTrace](&TracerConfig{Tracer: "muxTracer", TracerConfig: config})
+}
+
+// TracerConfig is the configuration of a named or custom JavaScript tracer.
+type TracerConfig struct {
+	Tracer         string                  // Name of the tracer, or code of a custom JavaScript tracer
+	TracerConfig   any                     // Tracer specific config, e.g. [CallTracerConfig]
+	Timeout        time.Duration           // Timeout of the tracer (node default if zero)
+	Reexec         uint64                  // Number of blocks to reexecute to regenerate missing state (node default if zero)
+	Overrides      w3types.State           // Override account state (only for calls)
+	BlockOverrides *w3types.BlockOverrides // Override block state (only for calls)
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (c *TracerConfig) MarshalJSON() ([]byte, error) {
+	type config struct {
+		Tracer         string                  `json:"tracer,omitempty"`
+		TracerConfig   any                     `json:"tracerConfig,omitempty"`
+		Timeout        string                  `json:"timeout,omitempty"`
+		Reexec         uint64                  `json:"reexec,omitempty"`
+		Overrides      w3types.State           `json:"stateOverrides,omitempty"`
+		BlockOverrides *w3types.BlockOverrides `json:"blockOverrides,omitempty"`
+	}
+
+	enc := config{
+		Tracer:         c.Tracer,
+		TracerConfig:   c.TracerConfig,
+		Reexec:         c.Reexec,
+		Overrides:      c.Overrides,
+		BlockOverrides: c.BlockOverrides,
+	}
+	if c.Timeout > 0 {
+		enc.Timeout = c.Timeout.String()
+	}
+	return json.Marshal(enc)
+}
+
+// CallTracerConfig is the tracer specific config of the "callTracer" and
+// "flatCallTracer".
+type CallTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall,omitempty"` // Only trace the top-level call
+	WithLog     bool `json:"withLog,omitempty"`     // Include the logs of calls
+}
+
+// PrestateTracerConfig is the tracer specific config of the "prestateTracer".
+type PrestateTracerConfig struct {
+	DiffMode       bool `json:"diffMode,omitempty"`       // Trace the state before and after execution
+	DisableCode    bool `json:"disableCode,omitempty"`    // Do not include code
+	DisableStorage bool `json:"disableStorage,omitempty"` // Do not include storage
+}
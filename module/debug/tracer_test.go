@@ -0,0 +1,122 @@
+package debug_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestTraceCallWithTracer(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*debug.CallTrace]{
+		{
+			Golden: "traceCall_callTracer_withLog",
+			Call: debug.TraceCallWithTracer(&w3types.Message{
+				From: w3.A("0x000000000000000000000000000000000000c0Fe"),
+				To:   w3.APtr("0x000000000000000000000000000000000000dEaD"),
+			}, nil, debug.NewTracer[*debug.CallTrace](&debug.TracerConfig{
+				Tracer:       "callTracer",
+				TracerConfig: &debug.CallTracerConfig{OnlyTopCall: true, WithLog: true},
+				Timeout:      10 * time.Second,
+			})),
+			WantRet: &debug.CallTrace{
+				From:    w3.A("0x000000000000000000000000000000000000c0Fe"),
+				To:      w3.A("0x000000000000000000000000000000000000dEaD"),
+				Type:    "CALL",
+				Gas:     49979000,
+				GasUsed: 21_000,
+				Value:   w3.Big0,
+				Logs: []*debug.CallLog{{
+					Address: w3.A("0x000000000000000000000000000000000000dEaD"),
+					Topics:  []common.Hash{w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")},
+					Data:    w3.B("0xc0fe"),
+				}},
+			},
+		},
+	})
+}
+
+func TestTraceTxWithTracer(t *testing.T) {
+	txHash := w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")
+
+	t.Run("flatCallTracer", func(t *testing.T) {
+		rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.FlatCallTrace]{
+			{
+				Golden: "traceTx_flatCallTracer",
+				Call:   debug.TraceTxWithTracer(txHash, debug.FlatCallTracer),
+				WantRet: []*debug.FlatCallTrace{
+					{
+						Type:         "call",
+						CallType:     "call",
+						From:         w3.A("0x000000000000000000000000000000000000c0Fe"),
+						To:           w3.A("0x000000000000000000000000000000000000dEaD"),
+						Gas:          21_000,
+						GasUsed:      21_000,
+						Value:        w3.I("1"),
+						Subtraces:    1,
+						TraceAddress: []uint64{},
+						BlockNumber:  1,
+						BlockHash:    w3.H("0x0000000000000000000000000000000000000000000000000000000000000002"),
+						TxHash:       txHash,
+					},
+					{
+						Type:         "create",
+						From:         w3.A("0x000000000000000000000000000000000000dEaD"),
+						To:           w3.A("0x000000000000000000000000000000000000bEEF"),
+						Gas:          0x1000,
+						GasUsed:      0x100,
+						Value:        w3.Big0,
+						Input:        w3.B("0x6000"),
+						Output:       w3.B("0x00"),
+						TraceAddress: []uint64{0},
+						BlockNumber:  1,
+						BlockHash:    w3.H("0x0000000000000000000000000000000000000000000000000000000000000002"),
+						TxHash:       txHash,
+					},
+				},
+			},
+		})
+	})
+
+	t.Run("muxTracer", func(t *testing.T) {
+		rpctest.RunTestCases(t, []rpctest.TestCase[*debug.MuxTrace]{
+			{
+				Golden: "traceTx_muxTracer",
+				Call: debug.TraceTxWithTracer(txHash, debug.MuxTracer(map[string]any{
+					"4byteTracer":    nil,
+					"prestateTracer": &debug.PrestateTracerConfig{DiffMode: true},
+				})),
+				WantRet: &debug.MuxTrace{
+					FourByte: map[string]uint64{"0x70a08231-32": 1},
+					PrestateDiff: &debug.StateDiff{
+						Pre:  w3types.State{w3.A("0x000000000000000000000000000000000000c0Fe"): {Balance: w3.I("1")}},
+						Post: w3types.State{w3.A("0x000000000000000000000000000000000000c0Fe"): {Balance: w3.Big0}},
+					},
+					Raw: map[string]json.RawMessage{
+						"4byteTracer":    json.RawMessage(`{"0x70a08231-32":1}`),
+						"prestateTracer": json.RawMessage(`{"pre":{"0x000000000000000000000000000000000000c0fe":{"balance":"0x1"}},"post":{"0x000000000000000000000000000000000000c0fe":{"balance":"0x0"}}}`),
+					},
+				},
+			},
+		}, cmpopts.IgnoreUnexported(w3types.Account{}))
+	})
+
+	t.Run("jsTracer", func(t *testing.T) {
+		rpctest.RunTestCases(t, []rpctest.TestCase[json.RawMessage]{
+			{
+				Golden: "traceTx_jsTracer",
+				Call: debug.TraceTxWithTracer(txHash, debug.NewTracer[json.RawMessage](&debug.TracerConfig{
+					Tracer: `{data: [], fault: function(log) {}, step: function(log) { if(log.op.toString() == "CALL") this.data.push(log.stack.peek(0)); }, result: function() { return this.data; }}`,
+					Reexec: 1000,
+				})),
+				WantRet: json.RawMessage(`[1,2]`),
+			},
+		})
+	})
+}
@@ -0,0 +1,8 @@
+/*
+Package engine implements RPC API bindings for methods in the "engine"
+namespace of the authenticated Engine API of execution clients.
+
+Clients that connect to the Engine API must authenticate every request using
+the JWT secret of the execution client, see [w3.WithJWTSecret].
+*/
+package engine
@@ -0,0 +1,26 @@
+package engine
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// ExchangeCapabilities sends the given Engine API methods supported by the
+// consensus client and requests the methods supported by the execution client.
+func ExchangeCapabilities(methods []string) w3types.RPCCallerFactory[[]string] {
+	return module.NewFactory[[]string](
+		"engine_exchangeCapabilities",
+		[]any{methods},
+	)
+}
+
+// GetBlobsV1 requests the blobs and proofs with the given versioned hashes from
+// the transaction pool of the execution client. The returned slice has the same
+// length as hashes and contains nil for every unknown blob.
+func GetBlobsV1(hashes []common.Hash) w3types.RPCCallerFactory[[]*BlobAndProof] {
+	return module.NewFactory[[]*BlobAndProof](
+		"engine_getBlobsV1",
+		[]any{nilToEmptyHashes(hashes)},
+	)
+}
@@ -0,0 +1,33 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/engine"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestExchangeCapabilities(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]string]{
+		{
+			Golden:  "exchange_capabilities",
+			Call:    engine.ExchangeCapabilities([]string{"engine_newPayloadV4", "engine_getPayloadV4"}),
+			WantRet: []string{"engine_newPayloadV4", "engine_getPayloadV4", "engine_getBlobsV1"},
+		},
+	})
+}
+
+func TestGetBlobsV1(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*engine.BlobAndProof]{
+		{
+			Golden: "get_blobs_v1",
+			Call:   engine.GetBlobsV1([]common.Hash{versionedHash, beaconRoot}),
+			WantRet: []*engine.BlobAndProof{
+				{Blob: w3.B("0xc0fe"), Proof: w3.B("0x5191")},
+				nil,
+			},
+		},
+	})
+}
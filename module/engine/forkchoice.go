@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// ForkchoiceUpdatedV1 updates the fork choice of the execution client to the
+// given state. If attrs is not nil, the execution client starts building a
+// Paris payload on top of the new head.
+func ForkchoiceUpdatedV1(state *ForkchoiceState, attrs *PayloadAttributes) w3types.RPCCallerFactory[*ForkchoiceResponse] {
+	return module.NewFactory[*ForkchoiceResponse](
+		"engine_forkchoiceUpdatedV1",
+		[]any{state, attrs},
+	)
+}
+
+// ForkchoiceUpdatedV2 is like [ForkchoiceUpdatedV1], but starts building a
+// Paris or Shanghai payload.
+func ForkchoiceUpdatedV2(state *ForkchoiceState, attrs *PayloadAttributes) w3types.RPCCallerFactory[*ForkchoiceResponse] {
+	return module.NewFactory[*ForkchoiceResponse](
+		"engine_forkchoiceUpdatedV2",
+		[]any{state, attrs},
+	)
+}
+
+// ForkchoiceUpdatedV3 is like [ForkchoiceUpdatedV1], but starts building a
+// Cancun or Prague payload.
+func ForkchoiceUpdatedV3(state *ForkchoiceState, attrs *PayloadAttributes) w3types.RPCCallerFactory[*ForkchoiceResponse] {
+	return module.NewFactory[*ForkchoiceResponse](
+		"engine_forkchoiceUpdatedV3",
+		[]any{state, attrs},
+	)
+}
@@ -0,0 +1,54 @@
+package engine_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/engine"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var forkchoiceState = &engine.ForkchoiceState{
+	HeadBlockHash:      w3.H("0x0000000000000000000000000000000000000000000000000000000000000005"),
+	SafeBlockHash:      w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+	FinalizedBlockHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+}
+
+func TestForkchoiceUpdatedV1(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.ForkchoiceResponse]{
+		{
+			Golden: "forkchoice_updated_v1",
+			Call:   engine.ForkchoiceUpdatedV1(forkchoiceState, nil),
+			WantRet: &engine.ForkchoiceResponse{
+				PayloadStatus: engine.PayloadStatus{
+					Status:          engine.StatusValid,
+					LatestValidHash: &forkchoiceState.HeadBlockHash,
+				},
+			},
+		},
+	})
+}
+
+func TestForkchoiceUpdatedV3(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.ForkchoiceResponse]{
+		{
+			Golden: "forkchoice_updated_v3",
+			Call: engine.ForkchoiceUpdatedV3(forkchoiceState, &engine.PayloadAttributes{
+				Timestamp:             1700000012,
+				Random:                w3.H("0x0000000000000000000000000000000000000000000000000000000000000004"),
+				SuggestedFeeRecipient: w3.A("0x000000000000000000000000000000000000c0Fe"),
+				Withdrawals:           []*types.Withdrawal{},
+				BeaconRoot:            &beaconRoot,
+			}),
+			WantRet: &engine.ForkchoiceResponse{
+				PayloadStatus: engine.PayloadStatus{
+					Status:          engine.StatusValid,
+					LatestValidHash: &forkchoiceState.HeadBlockHash,
+				},
+				PayloadID: &payloadID,
+			},
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// NewPayloadV1 sends the given Paris execution payload to the execution client
+// for validation.
+func NewPayloadV1(payload *ExecutableData) w3types.RPCCallerFactory[*PayloadStatus] {
+	return module.NewFactory[*PayloadStatus](
+		"engine_newPayloadV1",
+		[]any{payload},
+	)
+}
+
+// NewPayloadV2 sends the given Paris or Shanghai execution payload to the
+// execution client for validation.
+func NewPayloadV2(payload *ExecutableData) w3types.RPCCallerFactory[*PayloadStatus] {
+	return module.NewFactory[*PayloadStatus](
+		"engine_newPayloadV2",
+		[]any{payload},
+	)
+}
+
+// NewPayloadV3 sends the given Cancun execution payload to the execution client
+// for validation, together with the versioned hashes of its blobs and the
+// parent beacon block root.
+func NewPayloadV3(payload *ExecutableData, versionedHashes []common.Hash, beaconRoot common.Hash) w3types.RPCCallerFactory[*PayloadStatus] {
+	return module.NewFactory[*PayloadStatus](
+		"engine_newPayloadV3",
+		[]any{payload, nilToEmptyHashes(versionedHashes), beaconRoot},
+	)
+}
+
+// NewPayloadV4 sends the given Prague execution payload to the execution client
+// for validation, together with the versioned hashes of its blobs, the parent
+// beacon block root and the execution requests.
+func NewPayloadV4(payload *ExecutableData, versionedHashes []common.Hash, beaconRoot common.Hash, requests [][]byte) w3types.RPCCallerFactory[*PayloadStatus] {
+	encRequests := make([]hexutil.Bytes, len(requests))
+	for i, req := range requests {
+		encRequests[i] = req
+	}
+
+	return module.NewFactory[*PayloadStatus](
+		"engine_newPayloadV4",
+		[]any{payload, nilToEmptyHashes(versionedHashes), beaconRoot, encRequests},
+	)
+}
+
+// GetPayloadV1 requests the Paris execution payload of the build process with
+// the given ID.
+func GetPayloadV1(id PayloadID) w3types.RPCCallerFactory[*ExecutableData] {
+	return module.NewFactory[*ExecutableData](
+		"engine_getPayloadV1",
+		[]any{id},
+	)
+}
+
+// GetPayloadV2 requests the Paris or Shanghai execution payload of the build
+// process with the given ID.
+func GetPayloadV2(id PayloadID) w3types.RPCCallerFactory[*ExecutionPayloadEnvelope] {
+	return module.NewFactory[*ExecutionPayloadEnvelope](
+		"engine_getPayloadV2",
+		[]any{id},
+	)
+}
+
+// GetPayloadV3 requests the Cancun execution payload and blobs bundle of the
+// build process with the given ID.
+func GetPayloadV3(id PayloadID) w3types.RPCCallerFactory[*ExecutionPayloadEnvelope] {
+	return module.NewFactory[*ExecutionPayloadEnvelope](
+		"engine_getPayloadV3",
+		[]any{id},
+	)
+}
+
+// GetPayloadV4 requests the Prague execution payload, blobs bundle and
+// execution requests of the build process with the given ID.
+func GetPayloadV4(id PayloadID) w3types.RPCCallerFactory[*ExecutionPayloadEnvelope] {
+	return module.NewFactory[*ExecutionPayloadEnvelope](
+		"engine_getPayloadV4",
+		[]any{id},
+	)
+}
+
+// nilToEmptyHashes returns an empty slice if hashes is nil, as the Engine API
+// requires the versioned hashes to be an array.
+func nilToEmptyHashes(hashes []common.Hash) []common.Hash {
+	if hashes == nil {
+		return []common.Hash{}
+	}
+	return hashes
+}
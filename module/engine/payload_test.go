@@ -0,0 +1,125 @@
+package engine_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/engine"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	blobGasUsed   uint64 = 0x20000
+	excessBlobGas uint64 = 0
+
+	payload = &engine.ExecutableData{
+		ParentHash:    w3.H("0x0000000000000000000000000000000000000000000000000000000000000001"),
+		FeeRecipient:  w3.A("0x000000000000000000000000000000000000c0Fe"),
+		StateRoot:     w3.H("0x0000000000000000000000000000000000000000000000000000000000000002"),
+		ReceiptsRoot:  w3.H("0x0000000000000000000000000000000000000000000000000000000000000003"),
+		LogsBloom:     make([]byte, 256),
+		Random:        w3.H("0x0000000000000000000000000000000000000000000000000000000000000004"),
+		Number:        1,
+		GasLimit:      30_000_000,
+		GasUsed:       21_000,
+		Timestamp:     1700000000,
+		ExtraData:     []byte{},
+		BaseFeePerGas: big.NewInt(7),
+		BlockHash:     w3.H("0x0000000000000000000000000000000000000000000000000000000000000005"),
+		Transactions:  [][]byte{w3.B("0x02c0ffee")},
+	}
+	payloadV3 = &engine.ExecutableData{
+		ParentHash:    payload.ParentHash,
+		FeeRecipient:  payload.FeeRecipient,
+		StateRoot:     payload.StateRoot,
+		ReceiptsRoot:  payload.ReceiptsRoot,
+		LogsBloom:     payload.LogsBloom,
+		Random:        payload.Random,
+		Number:        payload.Number,
+		GasLimit:      payload.GasLimit,
+		GasUsed:       payload.GasUsed,
+		Timestamp:     payload.Timestamp,
+		ExtraData:     payload.ExtraData,
+		BaseFeePerGas: payload.BaseFeePerGas,
+		BlockHash:     payload.BlockHash,
+		Transactions:  payload.Transactions,
+		Withdrawals:   []*types.Withdrawal{},
+		BlobGasUsed:   &blobGasUsed,
+		ExcessBlobGas: &excessBlobGas,
+	}
+
+	versionedHash = w3.H("0x0100000000000000000000000000000000000000000000000000000000000006")
+	beaconRoot    = w3.H("0x0000000000000000000000000000000000000000000000000000000000000007")
+	payloadID     = engine.PayloadID{0x03, 0, 0, 0, 0, 0, 0, 0x01}
+)
+
+func TestNewPayloadV1(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.PayloadStatus]{
+		{
+			Golden: "new_payload_v1",
+			Call:   engine.NewPayloadV1(payload),
+			WantRet: &engine.PayloadStatus{
+				Status:          engine.StatusValid,
+				LatestValidHash: &payload.BlockHash,
+			},
+		},
+	})
+}
+
+func TestNewPayloadV3(t *testing.T) {
+	validationErr := "invalid blob hashes"
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.PayloadStatus]{
+		{
+			Golden: "new_payload_v3",
+			Call:   engine.NewPayloadV3(payloadV3, []common.Hash{versionedHash}, beaconRoot),
+			WantRet: &engine.PayloadStatus{
+				Status:          engine.StatusInvalid,
+				LatestValidHash: &payload.ParentHash,
+				ValidationError: &validationErr,
+			},
+		},
+	})
+}
+
+func TestNewPayloadV4(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.PayloadStatus]{
+		{
+			Golden:  "new_payload_v4",
+			Call:    engine.NewPayloadV4(payloadV3, nil, beaconRoot, [][]byte{w3.B("0x00c0fe")}),
+			WantRet: &engine.PayloadStatus{Status: engine.StatusSyncing},
+		},
+	})
+}
+
+func TestGetPayloadV1(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.ExecutableData]{
+		{
+			Golden:  "get_payload_v1",
+			Call:    engine.GetPayloadV1(payloadID),
+			WantRet: payload,
+		},
+	})
+}
+
+func TestGetPayloadV4(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*engine.ExecutionPayloadEnvelope]{
+		{
+			Golden: "get_payload_v4",
+			Call:   engine.GetPayloadV4(payloadID),
+			WantRet: &engine.ExecutionPayloadEnvelope{
+				ExecutionPayload: payloadV3,
+				BlockValue:       big.NewInt(1_000_000),
+				BlobsBundle: &engine.BlobsBundle{
+					Commitments: []hexutil.Bytes{},
+					Proofs:      []hexutil.Bytes{},
+					Blobs:       []hexutil.Bytes{},
+				},
+				Requests: [][]byte{},
+			},
+		},
+	})
+}
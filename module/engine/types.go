@@ -0,0 +1,44 @@
+package engine
+
+import "github.com/ethereum/go-ethereum/beacon/engine"
+
+type (
+	// ExecutableData is the execution payload of a block.
+	ExecutableData = engine.ExecutableData
+
+	// ExecutionPayloadEnvelope is the execution payload of a block with its
+	// value, blobs bundle and execution requests.
+	ExecutionPayloadEnvelope = engine.ExecutionPayloadEnvelope
+
+	// BlobsBundle contains the commitments, proofs and blobs of the blob
+	// transactions of an execution payload.
+	BlobsBundle = engine.BlobsBundle
+
+	// PayloadStatus is the status of an execution payload.
+	PayloadStatus = engine.PayloadStatusV1
+
+	// PayloadAttributes are the attributes of a payload that is built by the
+	// execution client.
+	PayloadAttributes = engine.PayloadAttributes
+
+	// PayloadID identifies the build process of a payload.
+	PayloadID = engine.PayloadID
+
+	// ForkchoiceState is the head, safe and finalized block of the fork choice.
+	ForkchoiceState = engine.ForkchoiceStateV1
+
+	// ForkchoiceResponse is the response of a fork choice update.
+	ForkchoiceResponse = engine.ForkChoiceResponse
+
+	// BlobAndProof is a blob and its KZG proof.
+	BlobAndProof = engine.BlobAndProofV1
+)
+
+// Statuses of a [PayloadStatus].
+const (
+	StatusValid            = "VALID"
+	StatusInvalid          = "INVALID"
+	StatusSyncing          = "SYNCING"
+	StatusAccepted         = "ACCEPTED"
+	StatusInvalidBlockHash = "INVALID_BLOCK_HASH"
+)
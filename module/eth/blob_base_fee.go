@@ -0,0 +1,17 @@
+package eth
+
+import (
+	"math/big"
+
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// BlobBaseFee requests the current blob base fee in wei.
+func BlobBaseFee() w3types.RPCCallerFactory[*big.Int] {
+	return module.NewFactory(
+		"eth_blobBaseFee",
+		nil,
+		module.WithRetWrapper(module.HexBigRetWrapper),
+	)
+}
@@ -0,0 +1,20 @@
+package eth_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestBlobBaseFee(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*big.Int]{
+		{
+			Golden:  "blob_base_fee",
+			Call:    eth.BlobBaseFee(),
+			WantRet: w3.I("0x3b9aca00"),
+		},
+	})
+}
@@ -1,6 +1,7 @@
 package eth
 
 import (
+	"context"
 	"encoding/json"
 	"math/big"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/internal/ccip"
 	"github.com/lmittmann/w3/internal/module"
 	"github.com/lmittmann/w3/w3types"
 )
@@ -104,8 +106,11 @@ type CallFuncFactory struct {
 	msg       *w3types.Message
 	atBlock   *big.Int
 	overrides w3types.State
+	ccipRead  bool
+	fetcher   CCIPFetcher
 
 	// returns
+	call    *ccip.Call
 	result  []byte
 	returns []any
 }
@@ -135,6 +140,15 @@ func (f *CallFuncFactory) Overrides(overrides w3types.State) *CallFuncFactory {
 	return f
 }
 
+// CCIPRead enables following EIP-3668 offchain lookups of the called contract
+// using the given fetcher, like [CallCCIPRead]. If fetcher is nil, the gateways
+// are requested via HTTP using [http.DefaultClient].
+func (f *CallFuncFactory) CCIPRead(fetcher CCIPFetcher) *CallFuncFactory {
+	f.ccipRead = true
+	f.fetcher = fetcher
+	return f
+}
+
 func (f *CallFuncFactory) CreateRequest() (rpc.BatchElem, error) {
 	input, err := f.msg.Func.EncodeArgs(f.msg.Args...)
 	if err != nil {
@@ -142,6 +156,11 @@ func (f *CallFuncFactory) CreateRequest() (rpc.BatchElem, error) {
 	}
 	f.msg.Input = input
 
+	if f.ccipRead {
+		f.call = ccip.NewCall(f.msg, f.atBlock, f.overrides, f.fetcher, MaxOffchainLookups)
+		return f.call.CreateRequest()
+	}
+
 	args := []any{
 		f.msg,
 		module.BlockNumberArg(f.atBlock),
@@ -158,7 +177,12 @@ func (f *CallFuncFactory) CreateRequest() (rpc.BatchElem, error) {
 }
 
 func (f *CallFuncFactory) HandleResponse(elem rpc.BatchElem) error {
-	if err := elem.Error; err != nil {
+	if f.call != nil {
+		if err := f.call.HandleResponse(elem); err != nil || f.call.Pending() {
+			return err
+		}
+		f.result = f.call.Output()
+	} else if err := elem.Error; err != nil {
 		return err
 	}
 
@@ -167,3 +191,17 @@ func (f *CallFuncFactory) HandleResponse(elem rpc.BatchElem) error {
 	}
 	return nil
 }
+
+// FollowUp implements the [w3types.RPCFollowUpCaller] interface. It follows the
+// pending offchain lookup of the call, if CCIP-Read is enabled.
+func (f *CallFuncFactory) FollowUp(ctx context.Context, call func(context.Context, ...w3types.RPCCaller) error) error {
+	if f.call == nil || !f.call.Pending() {
+		return nil
+	}
+	if err := f.call.FollowUp(ctx, call); err != nil {
+		return err
+	}
+
+	f.result = f.call.Output()
+	return f.msg.Func.DecodeReturns(f.result, f.returns...)
+}
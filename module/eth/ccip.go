@@ -0,0 +1,89 @@
+package eth
+
+import (
+	"context"
+	"math/big"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/internal/ccip"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// MaxOffchainLookups is the maximum number of consecutive EIP-3668 offchain
+// lookups that are followed by a call with CCIP-Read enabled.
+const MaxOffchainLookups = ccip.MaxLookups
+
+// CCIPFetcher fetches the response of an EIP-3668 offchain lookup with the given
+// sender and call data from the given gateway URLs.
+type CCIPFetcher interface {
+	Fetch(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error)
+}
+
+// NewCCIPHTTPFetcher returns a [CCIPFetcher] that requests the gateways via HTTP
+// using the given client, as specified by EIP-3668. If client is nil,
+// [http.DefaultClient] is used.
+func NewCCIPHTTPFetcher(client *http.Client) CCIPFetcher {
+	return &ccip.HTTPFetcher{Client: client}
+}
+
+// CallCCIPRead is like [Call], but follows EIP-3668 offchain lookups of the
+// called contract using the given fetcher: if the call reverts with an
+// OffchainLookup error, the response of the gateway is fetched and passed to
+// the callback function of the contract, whose output is returned. If fetcher
+// is nil, the gateways are requested via HTTP using [http.DefaultClient].
+func CallCCIPRead(msg *w3types.Message, blockNumber *big.Int, overrides w3types.State, fetcher CCIPFetcher) w3types.RPCCallerFactory[[]byte] {
+	return &callCCIPReadFactory{
+		msg:         msg,
+		blockNumber: blockNumber,
+		overrides:   overrides,
+		fetcher:     fetcher,
+	}
+}
+
+type callCCIPReadFactory struct {
+	// args
+	msg         *w3types.Message
+	blockNumber *big.Int
+	overrides   w3types.State
+	fetcher     CCIPFetcher
+
+	// returns
+	call *ccip.Call
+	ret  *[]byte
+}
+
+func (f callCCIPReadFactory) Returns(ret *[]byte) w3types.RPCCaller {
+	f.ret = ret
+	return &f
+}
+
+func (f *callCCIPReadFactory) CreateRequest() (rpc.BatchElem, error) {
+	args, err := msgArgsWrapper([]any{f.msg})
+	if err != nil {
+		return rpc.BatchElem{}, err
+	}
+
+	f.call = ccip.NewCall(args[0].(*w3types.Message), f.blockNumber, f.overrides, f.fetcher, MaxOffchainLookups)
+	return f.call.CreateRequest()
+}
+
+func (f *callCCIPReadFactory) HandleResponse(elem rpc.BatchElem) error {
+	if err := f.call.HandleResponse(elem); err != nil || f.call.Pending() {
+		return err
+	}
+	*f.ret = f.call.Output()
+	return nil
+}
+
+func (f *callCCIPReadFactory) FollowUp(ctx context.Context, call func(context.Context, ...w3types.RPCCaller) error) error {
+	if !f.call.Pending() {
+		return nil
+	}
+	if err := f.call.FollowUp(ctx, call); err != nil {
+		return err
+	}
+	*f.ret = f.call.Output()
+	return nil
+}
@@ -0,0 +1,165 @@
+package eth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	addrOffchain = w3.A("0x000000000000000000000000000000000000c0Fe")
+
+	funcOffchainLookup = w3.MustNewFunc("OffchainLookup(address sender, string[] urls, bytes callData, bytes4 callbackFunction, bytes extraData)", "")
+	funcCallback       = w3.MustNewFunc("callback(bytes response, bytes extraData)", "uint256")
+	funcGet            = w3.MustNewFunc("get()", "uint256")
+)
+
+// newOffchainServer returns an RPC server of a contract that reverts with an
+// OffchainLookup error with the given gateway URLs on every call, except calls
+// of its callback function with a response of 0xbeef, if loop is false.
+func newOffchainServer(t *testing.T, urls []string, loop bool) *httptest.Server {
+	t.Helper()
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("invalid request: %v", err)
+			return
+		}
+		if req.Method != "eth_call" {
+			t.Errorf("unexpected method %q", req.Method)
+			return
+		}
+		var msg struct {
+			To   common.Address `json:"to"`
+			Data hexutil.Bytes  `json:"data"`
+		}
+		if err := json.Unmarshal(req.Params[0], &msg); err != nil {
+			t.Errorf("invalid message: %v", err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		var response, extraData []byte
+		if err := funcCallback.DecodeArgs(msg.Data, &response, &extraData); err == nil && !loop {
+			if string(response) != "\xbe\xef" || string(extraData) != "\xde\xad" {
+				t.Errorf("unexpected callback args: %x, %x", response, extraData)
+			}
+			fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"%s"}`, req.ID, common.BigToHash(big.NewInt(42)))
+			return
+		}
+
+		revert, err := funcOffchainLookup.EncodeArgs(msg.To, urls, w3.B("0xc0fe"), funcCallback.Selector, w3.B("0xdead"))
+		if err != nil {
+			t.Errorf("failed to encode revert: %v", err)
+			return
+		}
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":3,"message":"execution reverted","data":"%s"}}`, req.ID, hexutil.Encode(revert))
+	}))
+}
+
+func TestCallCCIPRead(t *testing.T) {
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want := "/0x000000000000000000000000000000000000c0fe/0xc0fe.json"; r.URL.Path != want {
+			t.Errorf("want path %q, got %q", want, r.URL.Path)
+		}
+		io.WriteString(w, `{"data":"0xbeef"}`)
+	}))
+	defer gateway.Close()
+
+	srv := newOffchainServer(t, []string{gateway.URL + "/{sender}/{data}.json"}, false)
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL)
+	defer client.Close()
+
+	var output []byte
+	if err := client.Call(
+		eth.CallCCIPRead(&w3types.Message{To: &addrOffchain, Func: funcGet}, nil, nil, nil).Returns(&output),
+	); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	if want := common.BigToHash(big.NewInt(42)).Bytes(); string(want) != string(output) {
+		t.Fatalf("want %x, got %x", want, output)
+	}
+}
+
+func TestCallFuncCCIPRead(t *testing.T) {
+	srv := newOffchainServer(t, []string{"https://gateway.example/{sender}/{data}.json"}, false)
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL)
+	defer client.Close()
+
+	fetcher := fetcherFunc(func(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error) {
+		return w3.B("0xbeef"), nil
+	})
+
+	t.Run("ccip_read", func(t *testing.T) {
+		var got big.Int
+		if err := client.Call(
+			eth.CallFunc(addrOffchain, funcGet).CCIPRead(fetcher).Returns(&got),
+		); err != nil {
+			t.Fatalf("Request failed: %v", err)
+		}
+		if want := big.NewInt(42); want.Cmp(&got) != 0 {
+			t.Fatalf("want %v, got %v", want, &got)
+		}
+	})
+
+	t.Run("no_ccip_read", func(t *testing.T) {
+		var got big.Int
+		err := client.Call(eth.CallFunc(addrOffchain, funcGet).Returns(&got))
+		if err == nil || err.Error() != "w3: call failed: execution reverted" {
+			t.Fatalf("want execution reverted error, got %v", err)
+		}
+	})
+}
+
+func TestCallCCIPRead_TooManyLookups(t *testing.T) {
+	srv := newOffchainServer(t, []string{"https://gateway.example/{sender}/{data}.json"}, true)
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL)
+	defer client.Close()
+
+	var lookups int
+	fetcher := fetcherFunc(func(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error) {
+		lookups++
+		return w3.B("0xbeef"), nil
+	})
+
+	var output []byte
+	err := client.Call(
+		eth.CallCCIPRead(&w3types.Message{To: &addrOffchain, Func: funcGet}, nil, nil, fetcher).Returns(&output),
+	)
+	var callErrs w3.CallErrors
+	if !errors.As(err, &callErrs) || callErrs[0] == nil || callErrs[0].Error() != "w3: too many offchain lookups" {
+		t.Fatalf("want too many offchain lookups error, got %v", err)
+	}
+	if lookups != eth.MaxOffchainLookups {
+		t.Fatalf("want %d lookups, got %d", eth.MaxOffchainLookups, lookups)
+	}
+}
+
+type fetcherFunc func(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error)
+
+func (f fetcherFunc) Fetch(ctx context.Context, urls []string, sender common.Address, callData []byte) ([]byte, error) {
+	return f(ctx, urls, sender, callData)
+}
@@ -0,0 +1,71 @@
+package eth
+
+import (
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// NewFilter installs a filter for logs that match the given
+// ethereum.FilterQuery q and returns its filter ID.
+func NewFilter(q ethereum.FilterQuery) w3types.RPCCallerFactory[rpc.ID] {
+	return module.NewFactory(
+		"eth_newFilter",
+		[]any{q},
+		module.WithArgsWrapper[rpc.ID](func(args []any) ([]any, error) {
+			arg, err := toFilterArg(args[0].(ethereum.FilterQuery))
+			if err != nil {
+				return nil, err
+			}
+			return []any{arg}, nil
+		}),
+	)
+}
+
+// NewBlockFilter installs a filter for new block hashes and returns its filter
+// ID.
+func NewBlockFilter() w3types.RPCCallerFactory[rpc.ID] {
+	return module.NewFactory[rpc.ID](
+		"eth_newBlockFilter",
+		nil,
+	)
+}
+
+// NewPendingTransactionFilter installs a filter for the hashes of new pending
+// transactions and returns its filter ID.
+func NewPendingTransactionFilter() w3types.RPCCallerFactory[rpc.ID] {
+	return module.NewFactory[rpc.ID](
+		"eth_newPendingTransactionFilter",
+		nil,
+	)
+}
+
+// FilterChanges requests the changes of the filter with the given id since the
+// last poll. T must be [common.Hash] for block and pending transaction filters,
+// or [types.Log] for log filters.
+func FilterChanges[T common.Hash | types.Log](id rpc.ID) w3types.RPCCallerFactory[[]T] {
+	return module.NewFactory[[]T](
+		"eth_getFilterChanges",
+		[]any{id},
+	)
+}
+
+// FilterLogs requests all logs matching the log filter with the given id.
+func FilterLogs(id rpc.ID) w3types.RPCCallerFactory[[]types.Log] {
+	return module.NewFactory[[]types.Log](
+		"eth_getFilterLogs",
+		[]any{id},
+	)
+}
+
+// UninstallFilter uninstalls the filter with the given id and returns a bool
+// indicating success.
+func UninstallFilter(id rpc.ID) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"eth_uninstallFilter",
+		[]any{id},
+	)
+}
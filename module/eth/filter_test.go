@@ -0,0 +1,104 @@
+package eth_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var filterLog = types.Log{
+	Address: w3.A("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f"),
+	Topics: []common.Hash{
+		w3.H("0x0d3648bd0f6ba80134a33ba9275ac585d9d315f0ad8355cddefde31afa28d0e9"),
+		w3.H("0x000000000000000000000000a0b86991c6218b36c1d19d4a2e9eb0ce3606eb48"),
+		w3.H("0x000000000000000000000000c02aaa39b223fe8d0a0e5c4f27ead9083c756cc2"),
+	},
+	Data:        w3.B("0x000000000000000000000000b4e16d0168e52d35cacd2c6185b44281ec28c9dc0000000000000000000000000000000000000000000000000000000000000001"),
+	BlockNumber: 10008355,
+	TxHash:      w3.H("0xd07cbde817318492092cc7a27b3064a69bd893c01cb593d6029683ffd290ab3a"),
+	TxIndex:     38,
+	BlockHash:   w3.H("0x359d1dc4f14f9a07cba3ae8416958978ce98f78ad7b8d505925dad9722081f04"),
+	Index:       34,
+}
+
+func TestNewFilter(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[rpc.ID]{
+		{
+			Golden: "new_filter",
+			Call: eth.NewFilter(ethereum.FilterQuery{
+				FromBlock: w3.I("10000000"),
+				Addresses: []common.Address{w3.A("0x5C69bEe701ef814a2B6a3EDD4B1652CB9cc5aA6f")},
+				Topics:    [][]common.Hash{{w3.H("0x0d3648bd0f6ba80134a33ba9275ac585d9d315f0ad8355cddefde31afa28d0e9")}},
+			}),
+			WantRet: "0x6a2b4e1d39f8b9a1c2cc2e5a3f1b8c04",
+		},
+		{
+			Golden:  "new_block_filter",
+			Call:    eth.NewBlockFilter(),
+			WantRet: "0x3c8a8d0e0b9d4c6e9d2c7f6a1b5e4d3c",
+		},
+		{
+			Golden:  "new_pending_transaction_filter",
+			Call:    eth.NewPendingTransactionFilter(),
+			WantRet: "0x9f1e2d3c4b5a69788796a5b4c3d2e1f0",
+		},
+	})
+}
+
+func TestFilterChanges(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]common.Hash]{
+		{
+			Golden: "get_filter_changes__block",
+			Call:   eth.FilterChanges[common.Hash]("0x3c8a8d0e0b9d4c6e9d2c7f6a1b5e4d3c"),
+			WantRet: []common.Hash{
+				w3.H("0x88e96d4537bea4d9c05d12549907b32561d3bf31f45aae734cdc119f13406cb6"),
+				w3.H("0xb495a1d7e6663152ae92708da4843337b958146015a2802f4193a410044698c9"),
+			},
+		},
+		{
+			Golden:  "get_filter_changes__empty",
+			Call:    eth.FilterChanges[common.Hash]("0x3c8a8d0e0b9d4c6e9d2c7f6a1b5e4d3c"),
+			WantRet: []common.Hash{},
+		},
+		{
+			Golden:  "get_filter_changes__not_found",
+			Call:    eth.FilterChanges[common.Hash]("0x3c8a8d0e0b9d4c6e9d2c7f6a1b5e4d3c"),
+			WantErr: errors.New("w3: call failed: filter not found"),
+		},
+	})
+
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]types.Log]{
+		{
+			Golden:  "get_filter_changes__logs",
+			Call:    eth.FilterChanges[types.Log]("0x6a2b4e1d39f8b9a1c2cc2e5a3f1b8c04"),
+			WantRet: []types.Log{filterLog},
+		},
+	})
+}
+
+func TestFilterLogs(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]types.Log]{
+		{
+			Golden:  "get_filter_logs",
+			Call:    eth.FilterLogs("0x6a2b4e1d39f8b9a1c2cc2e5a3f1b8c04"),
+			WantRet: []types.Log{filterLog},
+		},
+	})
+}
+
+func TestUninstallFilter(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "uninstall_filter",
+			Call:    eth.UninstallFilter("0x6a2b4e1d39f8b9a1c2cc2e5a3f1b8c04"),
+			WantRet: true,
+		},
+	})
+}
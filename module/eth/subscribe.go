@@ -1,33 +1,164 @@
 package eth
 
 import (
+	"context"
+
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/lmittmann/w3/w3types"
 )
 
 // NewHeads subscribes to notifications of updates to the blockchain head.
+//
+// If the RPC endpoint does not support subscriptions, the subscription is
+// emulated by polling a block filter.
 func NewHeads(ch chan<- *types.Header) w3types.RPCSubscriber {
-	return &ethSubscription[*types.Header]{ch, []any{"newHeads"}, nil}
+	return &ethSubscription[*types.Header]{
+		ch:        ch,
+		params:    []any{"newHeads"},
+		newFilter: NewBlockFilter(),
+		poll:      pollHeaders,
+	}
 }
 
 // PendingTransactions subscribes to notifications about new pending transactions in the transaction pool.
+//
+// If the RPC endpoint does not support subscriptions, the subscription is
+// emulated by polling a pending transaction filter.
 func PendingTransactions(ch chan<- *types.Transaction) w3types.RPCSubscriber {
-	return &ethSubscription[*types.Transaction]{ch, []any{"newPendingTransactions", true}, nil}
+	return &ethSubscription[*types.Transaction]{
+		ch:        ch,
+		params:    []any{"newPendingTransactions", true},
+		newFilter: NewPendingTransactionFilter(),
+		poll:      pollTxs,
+	}
 }
 
 // NewLogs subscribes to notifications about logs that match the given filter query.
+//
+// If the RPC endpoint does not support subscriptions, the subscription is
+// emulated by polling a log filter.
 func NewLogs(ch chan<- *types.Log, q ethereum.FilterQuery) w3types.RPCSubscriber {
 	arg, err := toFilterArg(q)
-	return &ethSubscription[*types.Log]{ch, []any{"logs", arg}, err}
+	return &ethSubscription[*types.Log]{
+		ch:        ch,
+		params:    []any{"logs", arg},
+		err:       err,
+		newFilter: NewFilter(q),
+		poll:      pollLogs,
+	}
 }
 
 type ethSubscription[T any] struct {
 	ch     chan<- T
 	params []any
 	err    error
+
+	newFilter w3types.RPCCallerFactory[rpc.ID]
+	poll      func(ctx context.Context, id rpc.ID, call callFunc) ([]T, error)
 }
 
+type callFunc = func(context.Context, ...w3types.RPCCaller) error
+
 func (s *ethSubscription[T]) CreateRequest() (string, any, []any, error) {
 	return "eth", s.ch, s.params, s.err
 }
+
+// NewFilter implements the [w3types.RPCPollSubscriber] interface.
+func (s *ethSubscription[T]) NewFilter(id *rpc.ID) w3types.RPCCaller {
+	return s.newFilter.Returns(id)
+}
+
+// Poll implements the [w3types.RPCPollSubscriber] interface.
+func (s *ethSubscription[T]) Poll(ctx context.Context, id rpc.ID, call callFunc) error {
+	vals, err := s.poll(ctx, id, call)
+	if err != nil {
+		return err
+	}
+
+	for _, val := range vals {
+		select {
+		case s.ch <- val:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// UninstallFilter implements the [w3types.RPCPollSubscriber] interface.
+func (s *ethSubscription[T]) UninstallFilter(id rpc.ID) w3types.RPCCaller {
+	return UninstallFilter(id).Returns(new(bool))
+}
+
+func pollHeaders(ctx context.Context, id rpc.ID, call callFunc) ([]*types.Header, error) {
+	var hashes []common.Hash
+	if err := call(ctx, FilterChanges[common.Hash](id).Returns(&hashes)); err != nil {
+		return nil, err
+	}
+	if len(hashes) <= 0 {
+		return nil, nil
+	}
+
+	headers := make([]*types.Header, len(hashes))
+	calls := make([]w3types.RPCCaller, len(hashes))
+	for i, hash := range hashes {
+		calls[i] = HeaderByHash(hash).Returns(&headers[i])
+	}
+	if err := call(ctx, calls...); err != nil {
+		return nil, err
+	}
+	return headers, nil
+}
+
+func pollTxs(ctx context.Context, id rpc.ID, call callFunc) ([]*types.Transaction, error) {
+	var hashes []common.Hash
+	if err := call(ctx, FilterChanges[common.Hash](id).Returns(&hashes)); err != nil {
+		return nil, err
+	}
+	if len(hashes) <= 0 {
+		return nil, nil
+	}
+
+	txs := make([]*types.Transaction, len(hashes))
+	calls := make([]w3types.RPCCaller, len(hashes))
+	for i, hash := range hashes {
+		// transactions may have been dropped from the transaction pool since
+		// the last poll
+		calls[i] = ignoreErr{Tx(hash).Returns(&txs[i])}
+	}
+	if err := call(ctx, calls...); err != nil {
+		return nil, err
+	}
+
+	pendingTxs := txs[:0]
+	for _, tx := range txs {
+		if tx != nil {
+			pendingTxs = append(pendingTxs, tx)
+		}
+	}
+	return pendingTxs, nil
+}
+
+func pollLogs(ctx context.Context, id rpc.ID, call callFunc) ([]*types.Log, error) {
+	var logs []types.Log
+	if err := call(ctx, FilterChanges[types.Log](id).Returns(&logs)); err != nil {
+		return nil, err
+	}
+
+	ptrLogs := make([]*types.Log, len(logs))
+	for i := range logs {
+		ptrLogs[i] = &logs[i]
+	}
+	return ptrLogs, nil
+}
+
+// ignoreErr wraps a [w3types.RPCCaller] and ignores any error of its response.
+type ignoreErr struct{ w3types.RPCCaller }
+
+func (c ignoreErr) HandleResponse(elem rpc.BatchElem) error {
+	c.RPCCaller.HandleResponse(elem)
+	return nil
+}
@@ -0,0 +1,240 @@
+package mev
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// SendBundle sends the given bundle to the relay and returns its hash.
+func SendBundle(bundle *Bundle) w3types.RPCCallerFactory[common.Hash] {
+	return module.NewFactory(
+		"eth_sendBundle",
+		[]any{bundle},
+		module.WithRetWrapper(bundleHashRetWrapper),
+	)
+}
+
+// CallBundle simulates the given bundle on top of the state of the given
+// state block.
+func CallBundle(bundle *CallBundleRequest) w3types.RPCCallerFactory[*CallBundleResponse] {
+	return module.NewFactory[*CallBundleResponse](
+		"eth_callBundle",
+		[]any{bundle},
+	)
+}
+
+// CancelBundle cancels all bundles that were sent with the given replacement
+// UUID.
+func CancelBundle(replacementUUID string) w3types.RPCCallerFactory[any] {
+	return module.NewFactory(
+		"eth_cancelBundle",
+		[]any{&cancelBundleArgs{ReplacementUUID: replacementUUID}},
+		module.WithAllowNull[any](),
+	)
+}
+
+// Bundle is a bundle of transactions that are included atomically and in order
+// in the given block.
+type Bundle struct {
+	Txs               []*types.Transaction // Signed transactions of the bundle
+	BlockNumber       *big.Int             // Number of the block the bundle is valid for
+	MinTimestamp      uint64               // Optional minimum block timestamp for the bundle to be valid
+	MaxTimestamp      uint64               // Optional maximum block timestamp for the bundle to be valid
+	RevertingTxHashes []common.Hash        // Optional hashes of transactions that are allowed to revert
+	ReplacementUUID   string               // Optional UUID that can be used to cancel or replace the bundle
+	Builders          []string             // Optional names of the builders the bundle is shared with
+}
+
+type bundleJSON struct {
+	Txs               []hexutil.Bytes `json:"txs"`
+	BlockNumber       *hexutil.Big    `json:"blockNumber"`
+	MinTimestamp      uint64          `json:"minTimestamp,omitempty"`
+	MaxTimestamp      uint64          `json:"maxTimestamp,omitempty"`
+	RevertingTxHashes []common.Hash   `json:"revertingTxHashes,omitempty"`
+	ReplacementUUID   string          `json:"replacementUuid,omitempty"`
+	Builders          []string        `json:"builders,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (b *Bundle) MarshalJSON() ([]byte, error) {
+	txs, err := encodeTxs(b.Txs)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&bundleJSON{
+		Txs:               txs,
+		BlockNumber:       (*hexutil.Big)(b.BlockNumber),
+		MinTimestamp:      b.MinTimestamp,
+		MaxTimestamp:      b.MaxTimestamp,
+		RevertingTxHashes: b.RevertingTxHashes,
+		ReplacementUUID:   b.ReplacementUUID,
+		Builders:          b.Builders,
+	})
+}
+
+// CallBundleRequest is a bundle of transactions that is simulated on top of
+// the state of the given state block.
+type CallBundleRequest struct {
+	Txs              []*types.Transaction // Signed transactions of the bundle
+	BlockNumber      *big.Int             // Number of the block the bundle is simulated in
+	StateBlockNumber *big.Int             // Number of the block whose state the bundle is simulated on, default: latest
+	Timestamp        uint64               // Optional timestamp of the simulated block
+}
+
+type callBundleRequestJSON struct {
+	Txs              []hexutil.Bytes `json:"txs"`
+	BlockNumber      *hexutil.Big    `json:"blockNumber"`
+	StateBlockNumber string          `json:"stateBlockNumber"`
+	Timestamp        uint64          `json:"timestamp,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (c *CallBundleRequest) MarshalJSON() ([]byte, error) {
+	txs, err := encodeTxs(c.Txs)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(&callBundleRequestJSON{
+		Txs:              txs,
+		BlockNumber:      (*hexutil.Big)(c.BlockNumber),
+		StateBlockNumber: module.BlockNumberArg(c.StateBlockNumber),
+		Timestamp:        c.Timestamp,
+	})
+}
+
+// CallBundleResponse is the result of the simulation of a bundle.
+type CallBundleResponse struct {
+	BundleHash        common.Hash
+	BundleGasPrice    *big.Int
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	GasFees           *big.Int
+	StateBlockNumber  uint64
+	TotalGasUsed      uint64
+	Results           []*CallBundleResult // Results of the transactions of the bundle
+}
+
+type callBundleResponseJSON struct {
+	BundleHash        common.Hash         `json:"bundleHash"`
+	BundleGasPrice    *decimalBig         `json:"bundleGasPrice"`
+	CoinbaseDiff      *decimalBig         `json:"coinbaseDiff"`
+	EthSentToCoinbase *decimalBig         `json:"ethSentToCoinbase"`
+	GasFees           *decimalBig         `json:"gasFees"`
+	StateBlockNumber  uint64              `json:"stateBlockNumber"`
+	TotalGasUsed      uint64              `json:"totalGasUsed"`
+	Results           []*CallBundleResult `json:"results"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (c *CallBundleResponse) UnmarshalJSON(data []byte) error {
+	var dec callBundleResponseJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	c.BundleHash = dec.BundleHash
+	c.BundleGasPrice = (*big.Int)(dec.BundleGasPrice)
+	c.CoinbaseDiff = (*big.Int)(dec.CoinbaseDiff)
+	c.EthSentToCoinbase = (*big.Int)(dec.EthSentToCoinbase)
+	c.GasFees = (*big.Int)(dec.GasFees)
+	c.StateBlockNumber = dec.StateBlockNumber
+	c.TotalGasUsed = dec.TotalGasUsed
+	c.Results = dec.Results
+	return nil
+}
+
+// CallBundleResult is the result of the simulation of a single transaction of
+// a bundle.
+type CallBundleResult struct {
+	TxHash            common.Hash
+	From              common.Address
+	To                *common.Address
+	GasUsed           uint64
+	GasPrice          *big.Int
+	GasFees           *big.Int
+	CoinbaseDiff      *big.Int
+	EthSentToCoinbase *big.Int
+	Value             []byte // Return data of the transaction
+	Error             string // Error of the transaction, if it failed
+	Revert            string // Revert data of the transaction, if it reverted
+}
+
+type callBundleResultJSON struct {
+	TxHash            common.Hash     `json:"txHash"`
+	From              common.Address  `json:"fromAddress"`
+	To                *common.Address `json:"toAddress"`
+	GasUsed           uint64          `json:"gasUsed"`
+	GasPrice          *decimalBig     `json:"gasPrice"`
+	GasFees           *decimalBig     `json:"gasFees"`
+	CoinbaseDiff      *decimalBig     `json:"coinbaseDiff"`
+	EthSentToCoinbase *decimalBig     `json:"ethSentToCoinbase"`
+	Value             hexutil.Bytes   `json:"value"`
+	Error             string          `json:"error"`
+	Revert            string          `json:"revert"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (c *CallBundleResult) UnmarshalJSON(data []byte) error {
+	var dec callBundleResultJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	c.TxHash = dec.TxHash
+	c.From = dec.From
+	c.To = dec.To
+	c.GasUsed = dec.GasUsed
+	c.GasPrice = (*big.Int)(dec.GasPrice)
+	c.GasFees = (*big.Int)(dec.GasFees)
+	c.CoinbaseDiff = (*big.Int)(dec.CoinbaseDiff)
+	c.EthSentToCoinbase = (*big.Int)(dec.EthSentToCoinbase)
+	c.Value = dec.Value
+	c.Error = dec.Error
+	c.Revert = dec.Revert
+	return nil
+}
+
+type cancelBundleArgs struct {
+	ReplacementUUID string `json:"replacementUuid"`
+}
+
+type bundleHashResponse struct {
+	BundleHash *common.Hash `json:"bundleHash"`
+}
+
+func bundleHashRetWrapper(ret *common.Hash) any {
+	return &bundleHashResponse{BundleHash: ret}
+}
+
+// decimalBig is a [big.Int] that is JSON encoded as decimal string.
+type decimalBig big.Int
+
+func (d *decimalBig) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if _, ok := (*big.Int)(d).SetString(s, 10); !ok {
+		return fmt.Errorf("mev: invalid decimal %q", s)
+	}
+	return nil
+}
+
+func encodeTxs(txs []*types.Transaction) ([]hexutil.Bytes, error) {
+	rawTxs := make([]hexutil.Bytes, len(txs))
+	for i, tx := range txs {
+		rawTx, err := tx.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		rawTxs[i] = rawTx
+	}
+	return rawTxs, nil
+}
@@ -0,0 +1,70 @@
+package mev_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/mev"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestSendBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden: "send_bundle",
+			Call: mev.SendBundle(&mev.Bundle{
+				Txs:               []*types.Transaction{tx},
+				BlockNumber:       big.NewInt(18_000_000),
+				RevertingTxHashes: []common.Hash{tx.Hash()},
+				ReplacementUUID:   "a8b3e5c4-1b2d-4c3e-8f9a-0b1c2d3e4f5a",
+			}),
+			WantRet: bundleHash,
+		},
+	})
+}
+
+func TestCallBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*mev.CallBundleResponse]{
+		{
+			Golden: "call_bundle",
+			Call: mev.CallBundle(&mev.CallBundleRequest{
+				Txs:         []*types.Transaction{tx},
+				BlockNumber: big.NewInt(18_000_000),
+			}),
+			WantRet: &mev.CallBundleResponse{
+				BundleHash:        bundleHash,
+				BundleGasPrice:    w3.I("1 gwei"),
+				CoinbaseDiff:      w3.I("21000 gwei"),
+				EthSentToCoinbase: big.NewInt(0),
+				GasFees:           w3.I("21000 gwei"),
+				StateBlockNumber:  17_999_999,
+				TotalGasUsed:      21_000,
+				Results: []*mev.CallBundleResult{
+					{
+						TxHash:            tx.Hash(),
+						From:              signer.Address(),
+						To:                w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+						GasUsed:           21_000,
+						GasPrice:          w3.I("1 gwei"),
+						GasFees:           w3.I("21000 gwei"),
+						CoinbaseDiff:      w3.I("21000 gwei"),
+						EthSentToCoinbase: big.NewInt(0),
+						Value:             []byte{},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestCancelBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[any]{
+		{
+			Golden: "cancel_bundle",
+			Call:   mev.CancelBundle("a8b3e5c4-1b2d-4c3e-8f9a-0b1c2d3e4f5a"),
+		},
+	})
+}
@@ -0,0 +1,5 @@
+/*
+Package mev implements RPC API bindings for the bundle and private transaction
+methods of MEV relays and block builders, e.g. Flashbots.
+*/
+package mev
@@ -0,0 +1,31 @@
+package mev_test
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+)
+
+var (
+	key    = must(crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"))
+	signer = w3.NewKeySigner(key)
+
+	tx = types.MustSignNewTx(key, types.LatestSignerForChainID(big.NewInt(1)), &types.DynamicFeeTx{
+		ChainID:   big.NewInt(1),
+		GasTipCap: w3.I("1 gwei"),
+		GasFeeCap: w3.I("100 gwei"),
+		Gas:       21_000,
+		To:        w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+		Value:     w3.I("1 ether"),
+	})
+	bundleHash = w3.H("0x164d7d41f24b7f333af3b4a70b690cf93f636227165ea2b699fbb7eed09c46c7")
+)
+
+func must[T any](v T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
@@ -0,0 +1,57 @@
+package mev
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// SendPrivateTx sends the given signed transaction to the relay, which
+// includes it in one of the next blocks up to the given max block number,
+// without broadcasting it to the public mempool. If maxBlockNumber is nil, the
+// relay default is used.
+func SendPrivateTx(tx *types.Transaction, maxBlockNumber *big.Int) w3types.RPCCallerFactory[common.Hash] {
+	return module.NewFactory[common.Hash](
+		"eth_sendPrivateTransaction",
+		[]any{&privateTx{Tx: tx, MaxBlockNumber: maxBlockNumber}},
+	)
+}
+
+// CancelPrivateTx stops the relay from submitting the private transaction with
+// the given hash and returns a bool indicating success.
+func CancelPrivateTx(txHash common.Hash) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"eth_cancelPrivateTransaction",
+		[]any{&cancelPrivateTxArgs{TxHash: txHash}},
+	)
+}
+
+type privateTx struct {
+	Tx             *types.Transaction
+	MaxBlockNumber *big.Int
+}
+
+func (p *privateTx) MarshalJSON() ([]byte, error) {
+	rawTx, err := p.Tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	type privateTxJSON struct {
+		Tx             hexutil.Bytes `json:"tx"`
+		MaxBlockNumber *hexutil.Big  `json:"maxBlockNumber,omitempty"`
+	}
+	return json.Marshal(&privateTxJSON{
+		Tx:             rawTx,
+		MaxBlockNumber: (*hexutil.Big)(p.MaxBlockNumber),
+	})
+}
+
+type cancelPrivateTxArgs struct {
+	TxHash common.Hash `json:"txHash"`
+}
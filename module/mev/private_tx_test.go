@@ -0,0 +1,30 @@
+package mev_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/module/mev"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestSendPrivateTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden:  "send_private_tx",
+			Call:    mev.SendPrivateTx(tx, big.NewInt(18_000_004)),
+			WantRet: tx.Hash(),
+		},
+	})
+}
+
+func TestCancelPrivateTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "cancel_private_tx",
+			Call:    mev.CancelPrivateTx(tx.Hash()),
+			WantRet: true,
+		},
+	})
+}
@@ -0,0 +1,240 @@
+package mev
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// SendShareBundle sends the given MEV-Share bundle to the relay and returns its
+// hash.
+func SendShareBundle(bundle *ShareBundle) w3types.RPCCallerFactory[common.Hash] {
+	return module.NewFactory(
+		"mev_sendBundle",
+		[]any{bundle},
+		module.WithRetWrapper(bundleHashRetWrapper),
+	)
+}
+
+// SimShareBundle simulates the given MEV-Share bundle. The optional overrides
+// modify the environment of the simulated block.
+func SimShareBundle(bundle *ShareBundle, overrides *SimOverrides) w3types.RPCCallerFactory[*SimShareBundleResponse] {
+	args := []any{bundle}
+	if overrides != nil {
+		args = append(args, overrides)
+	}
+	return module.NewFactory[*SimShareBundleResponse](
+		"mev_simBundle",
+		args,
+	)
+}
+
+// ShareBundle is a MEV-Share bundle.
+type ShareBundle struct {
+	Version   string      // Version of the bundle, default: "v0.1"
+	Inclusion Inclusion   // Blocks the bundle is valid for
+	Body      []*BundleTx // Transactions of the bundle
+	Validity  *Validity   // Optional refund requirements of the bundle
+	Privacy   *Privacy    // Optional privacy preferences of the bundle
+}
+
+// Inclusion is the range of blocks a [ShareBundle] is valid for.
+type Inclusion struct {
+	Block    *big.Int // First block the bundle is valid for
+	MaxBlock *big.Int // Optional last block the bundle is valid for
+}
+
+// BundleTx is an element of the body of a [ShareBundle]. Exactly one of Hash,
+// Tx or Bundle must be set.
+type BundleTx struct {
+	Hash      *common.Hash       // Hash of a transaction that was shared by the relay
+	Tx        *types.Transaction // Signed transaction
+	CanRevert bool               // Whether Tx is allowed to revert
+	Bundle    *ShareBundle       // Nested bundle
+}
+
+// Validity defines the refunds of a [ShareBundle].
+type Validity struct {
+	Refund       []*Refund       `json:"refund,omitempty"`
+	RefundConfig []*RefundConfig `json:"refundConfig,omitempty"`
+}
+
+// Refund defines the percentage of the MEV of the body element with the given
+// index that is refunded.
+type Refund struct {
+	BodyIdx int `json:"bodyIdx"`
+	Percent int `json:"percent"`
+}
+
+// RefundConfig defines the percentage of a refund that is sent to the given
+// address.
+type RefundConfig struct {
+	Address common.Address `json:"address"`
+	Percent int            `json:"percent"`
+}
+
+// Privacy defines the data of a [ShareBundle] that is shared with searchers
+// and the builders the bundle is sent to.
+type Privacy struct {
+	Hints    []string `json:"hints,omitempty"`
+	Builders []string `json:"builders,omitempty"`
+}
+
+type shareBundleJSON struct {
+	Version   string          `json:"version"`
+	Inclusion inclusionJSON   `json:"inclusion"`
+	Body      []*bundleTxJSON `json:"body"`
+	Validity  *Validity       `json:"validity,omitempty"`
+	Privacy   *Privacy        `json:"privacy,omitempty"`
+}
+
+type inclusionJSON struct {
+	Block    *hexutil.Big `json:"block"`
+	MaxBlock *hexutil.Big `json:"maxBlock,omitempty"`
+}
+
+type bundleTxJSON struct {
+	Hash      *common.Hash  `json:"hash,omitempty"`
+	Tx        hexutil.Bytes `json:"tx,omitempty"`
+	CanRevert *bool         `json:"canRevert,omitempty"`
+	Bundle    *ShareBundle  `json:"bundle,omitempty"`
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (b *ShareBundle) MarshalJSON() ([]byte, error) {
+	enc := &shareBundleJSON{
+		Version: b.Version,
+		Inclusion: inclusionJSON{
+			Block:    (*hexutil.Big)(b.Inclusion.Block),
+			MaxBlock: (*hexutil.Big)(b.Inclusion.MaxBlock),
+		},
+		Body:     make([]*bundleTxJSON, len(b.Body)),
+		Validity: b.Validity,
+		Privacy:  b.Privacy,
+	}
+	if enc.Version == "" {
+		enc.Version = "v0.1"
+	}
+	for i, tx := range b.Body {
+		enc.Body[i] = &bundleTxJSON{Hash: tx.Hash, Bundle: tx.Bundle}
+		if tx.Tx != nil {
+			rawTx, err := tx.Tx.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			enc.Body[i].Tx = rawTx
+			enc.Body[i].CanRevert = &tx.CanRevert
+		}
+	}
+	return json.Marshal(enc)
+}
+
+// SimOverrides modify the environment of the block a [ShareBundle] is
+// simulated in.
+type SimOverrides struct {
+	ParentBlock *big.Int        // Number of the block whose state the bundle is simulated on
+	BlockNumber *big.Int        // Number of the simulated block
+	Coinbase    *common.Address // Coinbase of the simulated block
+	Timestamp   uint64          // Timestamp of the simulated block
+	GasLimit    uint64          // Gas limit of the simulated block
+	BaseFee     *big.Int        // Base fee of the simulated block
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (o *SimOverrides) MarshalJSON() ([]byte, error) {
+	type simOverridesJSON struct {
+		ParentBlock *hexutil.Big    `json:"parentBlock,omitempty"`
+		BlockNumber *hexutil.Big    `json:"blockNumber,omitempty"`
+		Coinbase    *common.Address `json:"coinbase,omitempty"`
+		Timestamp   hexutil.Uint64  `json:"timestamp,omitempty"`
+		GasLimit    hexutil.Uint64  `json:"gasLimit,omitempty"`
+		BaseFee     *hexutil.Big    `json:"baseFee,omitempty"`
+	}
+	return json.Marshal(&simOverridesJSON{
+		ParentBlock: (*hexutil.Big)(o.ParentBlock),
+		BlockNumber: (*hexutil.Big)(o.BlockNumber),
+		Coinbase:    o.Coinbase,
+		Timestamp:   hexutil.Uint64(o.Timestamp),
+		GasLimit:    hexutil.Uint64(o.GasLimit),
+		BaseFee:     (*hexutil.Big)(o.BaseFee),
+	})
+}
+
+// SimShareBundleResponse is the result of the simulation of a [ShareBundle].
+type SimShareBundleResponse struct {
+	Success         bool
+	Error           string
+	StateBlock      uint64
+	MevGasPrice     *big.Int
+	Profit          *big.Int
+	RefundableValue *big.Int
+	GasUsed         uint64
+	Logs            []*SimLogs // Logs of the elements of the body of the bundle
+}
+
+type simShareBundleResponseJSON struct {
+	Success         bool           `json:"success"`
+	Error           string         `json:"error"`
+	StateBlock      hexutil.Uint64 `json:"stateBlock"`
+	MevGasPrice     *hexutil.Big   `json:"mevGasPrice"`
+	Profit          *hexutil.Big   `json:"profit"`
+	RefundableValue *hexutil.Big   `json:"refundableValue"`
+	GasUsed         hexutil.Uint64 `json:"gasUsed"`
+	Logs            []*SimLogs     `json:"logs"`
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (s *SimShareBundleResponse) UnmarshalJSON(data []byte) error {
+	var dec simShareBundleResponseJSON
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	s.Success = dec.Success
+	s.Error = dec.Error
+	s.StateBlock = uint64(dec.StateBlock)
+	s.MevGasPrice = (*big.Int)(dec.MevGasPrice)
+	s.Profit = (*big.Int)(dec.Profit)
+	s.RefundableValue = (*big.Int)(dec.RefundableValue)
+	s.GasUsed = uint64(dec.GasUsed)
+	s.Logs = dec.Logs
+	return nil
+}
+
+// SimLogs are the logs of a single element of the body of a simulated
+// [ShareBundle]. TxLogs is set for transactions, BundleLogs for nested bundles.
+type SimLogs struct {
+	TxLogs     []*Log     `json:"txLogs,omitempty"`
+	BundleLogs []*SimLogs `json:"bundleLogs,omitempty"`
+}
+
+// Log is a log that was emitted during the simulation of a [ShareBundle].
+type Log struct {
+	Address common.Address
+	Topics  []common.Hash
+	Data    []byte
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (l *Log) UnmarshalJSON(data []byte) error {
+	type log struct {
+		Address common.Address `json:"address"`
+		Topics  []common.Hash  `json:"topics"`
+		Data    hexutil.Bytes  `json:"data"`
+	}
+
+	var dec log
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	l.Address = dec.Address
+	l.Topics = dec.Topics
+	l.Data = dec.Data
+	return nil
+}
@@ -0,0 +1,65 @@
+package mev_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/mev"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestSendShareBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden: "send_share_bundle",
+			Call: mev.SendShareBundle(&mev.ShareBundle{
+				Inclusion: mev.Inclusion{
+					Block:    big.NewInt(18_000_000),
+					MaxBlock: big.NewInt(18_000_002),
+				},
+				Body: []*mev.BundleTx{
+					{Hash: ptr(w3.H("0x2e038916d1ef2b2ba4cb0a4dde4b1dfea7ca3fb2a1d1e2a3b4c5d6e7f8091a2b"))},
+					{Tx: tx},
+				},
+				Validity: &mev.Validity{
+					Refund: []*mev.Refund{{BodyIdx: 0, Percent: 90}},
+				},
+			}),
+			WantRet: bundleHash,
+		},
+	})
+}
+
+func TestSimShareBundle(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*mev.SimShareBundleResponse]{
+		{
+			Golden: "sim_share_bundle",
+			Call: mev.SimShareBundle(
+				&mev.ShareBundle{
+					Inclusion: mev.Inclusion{Block: big.NewInt(18_000_000)},
+					Body:      []*mev.BundleTx{{Tx: tx}},
+				},
+				&mev.SimOverrides{ParentBlock: big.NewInt(17_999_999)},
+			),
+			WantRet: &mev.SimShareBundleResponse{
+				Success:         true,
+				StateBlock:      17_999_999,
+				MevGasPrice:     w3.I("1 gwei"),
+				Profit:          w3.I("21000 gwei"),
+				RefundableValue: w3.I("21000 gwei"),
+				GasUsed:         21_000,
+				Logs: []*mev.SimLogs{
+					{TxLogs: []*mev.Log{{
+						Address: w3.A("0x000000000000000000000000000000000000c0Fe"),
+						Topics:  []common.Hash{w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+						Data:    []byte{0x2a},
+					}}},
+				},
+			},
+		},
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
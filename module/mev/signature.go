@@ -0,0 +1,42 @@
+package mev
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+)
+
+// SignatureHeader is the HTTP header that authenticates requests to Flashbots
+// compatible relays.
+const SignatureHeader = "X-Flashbots-Signature"
+
+// WithSigner sets the [SignatureHeader] of every request of the client. The
+// header value is the address of the signer and its EIP-191 signature of the
+// hex encoded keccak256 hash of the request body:
+//
+//	<address>:<signature>
+//
+// Relays identify the searcher by the signer, the signer does not need to hold
+// any funds.
+func WithSigner(signer w3.Signer) w3.Option {
+	return w3.WithHeaderFunc(func(h http.Header, body []byte) error {
+		sig, err := Sign(signer, body)
+		if err != nil {
+			return err
+		}
+		h.Set(SignatureHeader, sig)
+		return nil
+	})
+}
+
+// Sign returns the [SignatureHeader] value of the given request body.
+func Sign(signer w3.Signer, body []byte) (string, error) {
+	hash := crypto.Keccak256Hash(body)
+	sig, err := w3.SignMessage(signer, []byte(hash.Hex()))
+	if err != nil {
+		return "", err
+	}
+	return signer.Address().Hex() + ":" + hexutil.Encode(sig), nil
+}
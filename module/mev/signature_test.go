@@ -0,0 +1,51 @@
+package mev_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/module/mev"
+)
+
+func TestSign(t *testing.T) {
+	got, err := mev.Sign(signer, []byte(`{"jsonrpc":"2.0","id":1,"method":"eth_sendBundle","params":[]}`))
+	if err != nil {
+		t.Fatalf("Failed to sign: %v", err)
+	}
+
+	want := "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266:0xcd41cfb8d486f061a418ab5cd00782d0a91e5f968273ff3bdb90f07b538df7d72066d26047c0956fc0c1d70d3fb137df1bf57e8250bc1718f4e02b2c485498021c"
+	if want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+}
+
+func TestWithSigner(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Failed to read body: %v", err)
+		}
+		want, err := mev.Sign(signer, body)
+		if err != nil {
+			t.Errorf("Failed to sign: %v", err)
+		}
+		if got := r.Header.Get(mev.SignatureHeader); want != got {
+			t.Errorf("%s: want %q, got %q", mev.SignatureHeader, want, got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"jsonrpc":"2.0","id":1,"result":"0x1"}`))
+	}))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL, mev.WithSigner(signer))
+	defer client.Close()
+
+	var chainID uint64
+	if err := client.Call(eth.ChainID().Returns(&chainID)); err != nil {
+		t.Fatalf("Failed to call: %v", err)
+	}
+}
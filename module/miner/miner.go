@@ -0,0 +1,39 @@
+/*
+Package miner implements RPC API bindings for methods in the "miner" namespace.
+*/
+package miner
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// SetExtra sets the extra data of the blocks that are built by the node and
+// returns a bool indicating success.
+func SetExtra(extra string) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"miner_setExtra",
+		[]any{extra},
+	)
+}
+
+// SetGasPrice sets the minimum gas price of transactions that are included in
+// the blocks that are built by the node and returns a bool indicating success.
+func SetGasPrice(gasPrice *big.Int) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"miner_setGasPrice",
+		[]any{(*hexutil.Big)(gasPrice)},
+	)
+}
+
+// SetGasLimit sets the gas limit that the node targets for the blocks it builds
+// and returns a bool indicating success.
+func SetGasLimit(gasLimit uint64) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"miner_setGasLimit",
+		[]any{hexutil.Uint64(gasLimit)},
+	)
+}
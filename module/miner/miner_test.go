@@ -0,0 +1,39 @@
+package miner_test
+
+import (
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/miner"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestSetExtra(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "set_extra",
+			Call:    miner.SetExtra("w3"),
+			WantRet: true,
+		},
+	})
+}
+
+func TestSetGasPrice(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "set_gas_price",
+			Call:    miner.SetGasPrice(w3.I("1 gwei")),
+			WantRet: true,
+		},
+	})
+}
+
+func TestSetGasLimit(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "set_gas_limit",
+			Call:    miner.SetGasLimit(36_000_000),
+			WantRet: true,
+		},
+	})
+}
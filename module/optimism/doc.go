@@ -0,0 +1,7 @@
+/*
+Package optimism implements RPC API bindings for methods in the "optimism"
+namespace of OP Stack rollup nodes, and for the L2 specific fields of
+transaction receipts and the L1 data fee of OP Stack chains, such as Optimism
+and Base.
+*/
+package optimism
@@ -0,0 +1,78 @@
+package optimism
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	// GasPriceOracle is the address of the GasPriceOracle predeploy.
+	GasPriceOracle = common.HexToAddress("0x420000000000000000000000000000000000000F")
+
+	// FuncGetL1Fee is the getL1Fee function of the GasPriceOracle predeploy,
+	// that returns the L1 data fee of the given unsigned transaction.
+	FuncGetL1Fee = w3.MustNewFunc("getL1Fee(bytes)", "uint256")
+)
+
+// L1Fee requests the L1 data fee of the given message as transaction on the
+// chain with the given chain ID at the given blockNumber from the
+// GasPriceOracle predeploy. If blockNumber is nil, the L1 data fee at the
+// latest block is requested.
+//
+// See [L1FeeInput] for how the message is encoded as transaction.
+func L1Fee(msg *w3types.Message, chainID uint64, blockNumber *big.Int) w3types.RPCCallerFactory[*big.Int] {
+	return module.NewFactory(
+		"eth_call",
+		[]any{msg, chainID, module.BlockNumberArg(blockNumber)},
+		module.WithArgsWrapper[*big.Int](l1FeeArgsWrapper),
+		module.WithRetWrapper(func(ret **big.Int) any { return &l1Fee{ret} }),
+	)
+}
+
+// L1FeeInput returns the input data of the getL1Fee call of the given message
+// as transaction on the chain with the given chain ID.
+//
+// The L1 data fee depends on the size of the encoded transaction. Fields of
+// msg that are not set, such as Nonce, Gas or GasFeeCap, are encoded as zero,
+// which results in a smaller transaction and underestimates the L1 data fee.
+// For an accurate fee, msg must be populated like the transaction that will be
+// sent.
+func L1FeeInput(msg *w3types.Message, chainID uint64) ([]byte, error) {
+	tx, err := msg.ToTx(new(big.Int).SetUint64(chainID))
+	if err != nil {
+		return nil, err
+	}
+	rawTx, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return FuncGetL1Fee.EncodeArgs(rawTx)
+}
+
+func l1FeeArgsWrapper(slice []any) ([]any, error) {
+	msg := slice[0].(*w3types.Message)
+	chainID := slice[1].(uint64)
+
+	input, err := L1FeeInput(msg, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return []any{&w3types.Message{To: &GasPriceOracle, Input: input}, slice[2]}, nil
+}
+
+type l1Fee struct{ ret **big.Int }
+
+func (f *l1Fee) UnmarshalJSON(data []byte) error {
+	var output hexutil.Bytes
+	if err := json.Unmarshal(data, &output); err != nil {
+		return err
+	}
+
+	return FuncGetL1Fee.DecodeReturns(output, f.ret)
+}
@@ -0,0 +1,28 @@
+package optimism_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/optimism"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestL1Fee(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*big.Int]{
+		{
+			Golden: "l1_fee",
+			Call: optimism.L1Fee(&w3types.Message{
+				Nonce:     1,
+				GasFeeCap: w3.I("1 gwei"),
+				GasTipCap: w3.I("0.001 gwei"),
+				Gas:       21_000,
+				To:        w3.APtr("0x000000000000000000000000000000000000dEaD"),
+				Value:     w3.I("1 ether"),
+			}, 10, nil),
+			WantRet: big.NewInt(8352019879),
+		},
+	})
+}
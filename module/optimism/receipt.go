@@ -0,0 +1,72 @@
+package optimism
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// TxReceipt requests the receipt of the transaction with the given hash,
+// including the L2 specific fields.
+func TxReceipt(txHash common.Hash) w3types.RPCCallerFactory[*Receipt] {
+	return module.NewFactory[*Receipt](
+		"eth_getTransactionReceipt",
+		[]any{txHash},
+	)
+}
+
+// BlockReceipts requests all receipts of the transactions in the given block,
+// including the L2 specific fields.
+func BlockReceipts(blockNumber *big.Int) w3types.RPCCallerFactory[[]*Receipt] {
+	return module.NewFactory[[]*Receipt](
+		"eth_getBlockReceipts",
+		[]any{module.BlockNumberArg(blockNumber)},
+	)
+}
+
+// Receipt is a transaction receipt of an OP Stack chain. The L1 fields are nil
+// for deposit transactions.
+type Receipt struct {
+	*types.Receipt
+
+	L1GasPrice          *big.Int // L1 base fee used to calculate the L1 fee
+	L1GasUsed           *big.Int // Amount of L1 gas the transaction data is charged for
+	L1Fee               *big.Int // L1 data fee in wei
+	L1BlobBaseFee       *big.Int // L1 blob base fee used to calculate the L1 fee (since Ecotone)
+	L1BaseFeeScalar     *uint64  // Scalar of the L1 base fee (since Ecotone)
+	L1BlobBaseFeeScalar *uint64  // Scalar of the L1 blob base fee (since Ecotone)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *Receipt) UnmarshalJSON(data []byte) error {
+	type receipt struct {
+		L1GasPrice          *hexutil.Big    `json:"l1GasPrice"`
+		L1GasUsed           *hexutil.Big    `json:"l1GasUsed"`
+		L1Fee               *hexutil.Big    `json:"l1Fee"`
+		L1BlobBaseFee       *hexutil.Big    `json:"l1BlobBaseFee"`
+		L1BaseFeeScalar     *hexutil.Uint64 `json:"l1BaseFeeScalar"`
+		L1BlobBaseFeeScalar *hexutil.Uint64 `json:"l1BlobBaseFeeScalar"`
+	}
+
+	r.Receipt = new(types.Receipt)
+	if err := json.Unmarshal(data, r.Receipt); err != nil {
+		return err
+	}
+
+	var dec receipt
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	r.L1GasPrice = (*big.Int)(dec.L1GasPrice)
+	r.L1GasUsed = (*big.Int)(dec.L1GasUsed)
+	r.L1Fee = (*big.Int)(dec.L1Fee)
+	r.L1BlobBaseFee = (*big.Int)(dec.L1BlobBaseFee)
+	r.L1BaseFeeScalar = (*uint64)(dec.L1BaseFeeScalar)
+	r.L1BlobBaseFeeScalar = (*uint64)(dec.L1BlobBaseFeeScalar)
+	return nil
+}
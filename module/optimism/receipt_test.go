@@ -0,0 +1,40 @@
+package optimism_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/optimism"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestTxReceipt(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*optimism.Receipt]{
+		{
+			Golden: "tx_receipt",
+			Call:   optimism.TxReceipt(w3.H("0x0000000000000000000000000000000000000000000000000000000000000041")),
+			WantRet: &optimism.Receipt{
+				Receipt: &types.Receipt{
+					Type:              types.DynamicFeeTxType,
+					Status:            types.ReceiptStatusSuccessful,
+					CumulativeGasUsed: 69_000,
+					Logs:              []*types.Log{},
+					TxHash:            w3.H("0x0000000000000000000000000000000000000000000000000000000000000041"),
+					GasUsed:           21_000,
+					EffectiveGasPrice: w3.I("1 gwei"),
+					BlockHash:         w3.H("0x0000000000000000000000000000000000000000000000000000000000000021"),
+					BlockNumber:       big.NewInt(130_000_000),
+					TransactionIndex:  3,
+				},
+				L1GasPrice:          w3.I("8 gwei"),
+				L1GasUsed:           big.NewInt(1600),
+				L1Fee:               big.NewInt(8352019879),
+				L1BlobBaseFee:       big.NewInt(1),
+				L1BaseFeeScalar:     ptr[uint64](2269),
+				L1BlobBaseFeeScalar: ptr[uint64](1055762),
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package optimism
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// OutputAtBlock requests the output root of the L2 block with the given number.
+func OutputAtBlock(blockNumber uint64) w3types.RPCCallerFactory[*OutputResponse] {
+	return module.NewFactory[*OutputResponse](
+		"optimism_outputAtBlock",
+		[]any{hexutil.Uint64(blockNumber)},
+	)
+}
+
+// SyncStatus requests the sync status of the rollup node.
+func SyncStatus() w3types.RPCCallerFactory[*SyncStatusResponse] {
+	return module.NewFactory[*SyncStatusResponse](
+		"optimism_syncStatus",
+		nil,
+	)
+}
+
+// RollupConfig requests the rollup config of the rollup node.
+func RollupConfig() w3types.RPCCallerFactory[*RollupConfigResponse] {
+	return module.NewFactory[*RollupConfigResponse](
+		"optimism_rollupConfig",
+		nil,
+	)
+}
+
+// Version requests the version of the rollup node.
+func Version() w3types.RPCCallerFactory[string] {
+	return module.NewFactory[string](
+		"optimism_version",
+		nil,
+	)
+}
+
+type OutputResponse struct {
+	Version               common.Hash         `json:"version"`
+	OutputRoot            common.Hash         `json:"outputRoot"`
+	BlockRef              *L2BlockRef         `json:"blockRef"`
+	WithdrawalStorageRoot common.Hash         `json:"withdrawalStorageRoot"`
+	StateRoot             common.Hash         `json:"stateRoot"`
+	SyncStatus            *SyncStatusResponse `json:"syncStatus"`
+}
+
+type SyncStatusResponse struct {
+	CurrentL1          *L1BlockRef `json:"current_l1"`
+	CurrentL1Finalized *L1BlockRef `json:"current_l1_finalized"`
+	HeadL1             *L1BlockRef `json:"head_l1"`
+	SafeL1             *L1BlockRef `json:"safe_l1"`
+	FinalizedL1        *L1BlockRef `json:"finalized_l1"`
+	UnsafeL2           *L2BlockRef `json:"unsafe_l2"`
+	SafeL2             *L2BlockRef `json:"safe_l2"`
+	FinalizedL2        *L2BlockRef `json:"finalized_l2"`
+	PendingSafeL2      *L2BlockRef `json:"pending_safe_l2"`
+}
+
+// L1BlockRef is a reference to an L1 block.
+type L1BlockRef struct {
+	Hash       common.Hash `json:"hash"`
+	Number     uint64      `json:"number"`
+	ParentHash common.Hash `json:"parentHash"`
+	Time       uint64      `json:"timestamp"`
+}
+
+// L2BlockRef is a reference to an L2 block and the L1 block it was derived
+// from.
+type L2BlockRef struct {
+	Hash           common.Hash `json:"hash"`
+	Number         uint64      `json:"number"`
+	ParentHash     common.Hash `json:"parentHash"`
+	Time           uint64      `json:"timestamp"`
+	L1Origin       BlockID     `json:"l1origin"`
+	SequenceNumber uint64      `json:"sequenceNumber"` // Distance to the first block of the epoch
+}
+
+// BlockID identifies a block by its hash and number.
+type BlockID struct {
+	Hash   common.Hash `json:"hash"`
+	Number uint64      `json:"number"`
+}
+
+type RollupConfigResponse struct {
+	Genesis                Genesis        `json:"genesis"`
+	BlockTime              uint64         `json:"block_time"`
+	MaxSequencerDrift      uint64         `json:"max_sequencer_drift"`
+	SeqWindowSize          uint64         `json:"seq_window_size"`
+	ChannelTimeout         uint64         `json:"channel_timeout"`
+	L1ChainID              *big.Int       `json:"l1_chain_id"`
+	L2ChainID              *big.Int       `json:"l2_chain_id"`
+	RegolithTime           *uint64        `json:"regolith_time"`
+	CanyonTime             *uint64        `json:"canyon_time"`
+	DeltaTime              *uint64        `json:"delta_time"`
+	EcotoneTime            *uint64        `json:"ecotone_time"`
+	FjordTime              *uint64        `json:"fjord_time"`
+	GraniteTime            *uint64        `json:"granite_time"`
+	HoloceneTime           *uint64        `json:"holocene_time"`
+	IsthmusTime            *uint64        `json:"isthmus_time"`
+	BatchInboxAddress      common.Address `json:"batch_inbox_address"`
+	DepositContractAddress common.Address `json:"deposit_contract_address"`
+	L1SystemConfigAddress  common.Address `json:"l1_system_config_address"`
+}
+
+// Genesis is the genesis of a rollup.
+type Genesis struct {
+	L1           BlockID      `json:"l1"`
+	L2           BlockID      `json:"l2"`
+	L2Time       uint64       `json:"l2_time"`
+	SystemConfig SystemConfig `json:"system_config"`
+}
+
+// SystemConfig is the config of a rollup that is set on L1.
+type SystemConfig struct {
+	BatcherAddr common.Address `json:"batcherAddr"`
+	Overhead    common.Hash    `json:"overhead"`
+	Scalar      common.Hash    `json:"scalar"`
+	GasLimit    uint64         `json:"gasLimit"`
+}
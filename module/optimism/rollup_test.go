@@ -0,0 +1,122 @@
+package optimism_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/optimism"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	l1BlockRef = &optimism.L1BlockRef{
+		Hash:       w3.H("0x0000000000000000000000000000000000000000000000000000000000000011"),
+		Number:     21_500_000,
+		ParentHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000010"),
+		Time:       1735999990,
+	}
+	l2BlockRef = &optimism.L2BlockRef{
+		Hash:       w3.H("0x0000000000000000000000000000000000000000000000000000000000000021"),
+		Number:     130_000_000,
+		ParentHash: w3.H("0x0000000000000000000000000000000000000000000000000000000000000020"),
+		Time:       1736000000,
+		L1Origin: optimism.BlockID{
+			Hash:   w3.H("0x0000000000000000000000000000000000000000000000000000000000000011"),
+			Number: 21_500_000,
+		},
+		SequenceNumber: 3,
+	}
+	syncStatus = &optimism.SyncStatusResponse{
+		CurrentL1:          l1BlockRef,
+		CurrentL1Finalized: l1BlockRef,
+		HeadL1:             l1BlockRef,
+		SafeL1:             l1BlockRef,
+		FinalizedL1:        l1BlockRef,
+		UnsafeL2:           l2BlockRef,
+		SafeL2:             l2BlockRef,
+		FinalizedL2:        l2BlockRef,
+		PendingSafeL2:      l2BlockRef,
+	}
+)
+
+func TestOutputAtBlock(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*optimism.OutputResponse]{
+		{
+			Golden: "output_at_block",
+			Call:   optimism.OutputAtBlock(130_000_000),
+			WantRet: &optimism.OutputResponse{
+				OutputRoot:            w3.H("0x0000000000000000000000000000000000000000000000000000000000000031"),
+				BlockRef:              l2BlockRef,
+				WithdrawalStorageRoot: w3.H("0x0000000000000000000000000000000000000000000000000000000000000032"),
+				StateRoot:             w3.H("0x0000000000000000000000000000000000000000000000000000000000000033"),
+				SyncStatus:            syncStatus,
+			},
+		},
+	})
+}
+
+func TestSyncStatus(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*optimism.SyncStatusResponse]{
+		{
+			Golden:  "sync_status",
+			Call:    optimism.SyncStatus(),
+			WantRet: syncStatus,
+		},
+	})
+}
+
+func TestRollupConfig(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*optimism.RollupConfigResponse]{
+		{
+			Golden: "rollup_config",
+			Call:   optimism.RollupConfig(),
+			WantRet: &optimism.RollupConfigResponse{
+				Genesis: optimism.Genesis{
+					L1: optimism.BlockID{
+						Hash:   w3.H("0x438335a20d98863a4c0c97999eb2481921ccd28553eac6f913af7c12aec04108"),
+						Number: 17422590,
+					},
+					L2: optimism.BlockID{
+						Hash:   w3.H("0xdbf6a80fef073de06add9b0d14026d6e5a86c85f6d102c36d3d8e9cf89c2afd3"),
+						Number: 105235063,
+					},
+					L2Time: 1686068903,
+					SystemConfig: optimism.SystemConfig{
+						BatcherAddr: w3.A("0x6887246668a3b87F54DeB3b94Ba47a6f63F32985"),
+						Overhead:    w3.H("0x00000000000000000000000000000000000000000000000000000000000000bc"),
+						Scalar:      w3.H("0x00000000000000000000000000000000000000000000000000000000000a6fe0"),
+						GasLimit:    30_000_000,
+					},
+				},
+				BlockTime:              2,
+				MaxSequencerDrift:      600,
+				SeqWindowSize:          3600,
+				ChannelTimeout:         300,
+				L1ChainID:              big.NewInt(1),
+				L2ChainID:              big.NewInt(10),
+				RegolithTime:           ptr[uint64](0),
+				CanyonTime:             ptr[uint64](1704992401),
+				DeltaTime:              ptr[uint64](1708560000),
+				EcotoneTime:            ptr[uint64](1710374401),
+				FjordTime:              ptr[uint64](1720627201),
+				GraniteTime:            ptr[uint64](1726070401),
+				BatchInboxAddress:      w3.A("0xFF00000000000000000000000000000000000010"),
+				DepositContractAddress: w3.A("0xbEb5Fc579115071764c7423A4f12eDde41f106Ed"),
+				L1SystemConfigAddress:  w3.A("0x229047fed2591dbec1eF1118d64F7aF3dB9EB290"),
+			},
+		},
+	})
+}
+
+func TestVersion(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[string]{
+		{
+			Golden:  "version",
+			Call:    optimism.Version(),
+			WantRet: "v1.10.0",
+		},
+	})
+}
+
+func ptr[T any](v T) *T { return &v }
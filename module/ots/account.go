@@ -0,0 +1,51 @@
+package ots
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// HasCode requests whether the given address has code at the given block
+// number. If blockNumber is nil, the latest state is used.
+func HasCode(addr common.Address, blockNumber *big.Int) w3types.RPCCallerFactory[bool] {
+	return module.NewFactory[bool](
+		"ots_hasCode",
+		[]any{addr, module.BlockNumberArg(blockNumber)},
+	)
+}
+
+// ContractCreator requests the creator and the creation transaction of the
+// contract with the given address.
+func ContractCreator(addr common.Address) w3types.RPCCallerFactory[*Creator] {
+	return module.NewFactory[*Creator](
+		"ots_getContractCreator",
+		[]any{addr},
+	)
+}
+
+// Creator is the creator and the creation transaction of a contract.
+type Creator struct {
+	TxHash  common.Hash
+	Creator common.Address
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (c *Creator) UnmarshalJSON(data []byte) error {
+	type creator struct {
+		TxHash  common.Hash    `json:"hash"`
+		Creator common.Address `json:"creator"`
+	}
+
+	var dec creator
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	c.TxHash = dec.TxHash
+	c.Creator = dec.Creator
+	return nil
+}
@@ -0,0 +1,38 @@
+package ots_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/ots"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestHasCode(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[bool]{
+		{
+			Golden:  "has_code",
+			Call:    ots.HasCode(w3.A("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), nil),
+			WantRet: true,
+		},
+	})
+}
+
+func TestContractCreator(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*ots.Creator]{
+		{
+			Golden: "get_contract_creator",
+			Call:   ots.ContractCreator(w3.A("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")),
+			WantRet: &ots.Creator{
+				TxHash:  w3.H("0xb95343413e459a0f97461812111254163ae53467855c0d73e0f1e7c5b8442fa3"),
+				Creator: w3.A("0x4f26FfBe5F04ED43630fdC30A87638d53D0b0876"),
+			},
+		},
+		{
+			Golden:  "get_contract_creator__eoa",
+			Call:    ots.ContractCreator(w3.A("0x000000000000000000000000000000000000c0Fe")),
+			WantErr: errors.New("w3: call failed: not found"),
+		},
+	})
+}
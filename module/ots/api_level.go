@@ -0,0 +1,15 @@
+package ots
+
+import (
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// APILevel requests the level of the Otterscan API that is supported by the
+// node.
+func APILevel() w3types.RPCCallerFactory[uint64] {
+	return module.NewFactory[uint64](
+		"ots_getApiLevel",
+		nil,
+	)
+}
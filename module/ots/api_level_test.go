@@ -0,0 +1,18 @@
+package ots_test
+
+import (
+	"testing"
+
+	"github.com/lmittmann/w3/module/ots"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestAPILevel(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[uint64]{
+		{
+			Golden:  "get_api_level",
+			Call:    ots.APILevel(),
+			WantRet: 8,
+		},
+	})
+}
@@ -0,0 +1,170 @@
+package ots
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// BlockDetails requests the header, transaction count, issuance and fees of
+// the block with the given number. If number is nil, the latest block is
+// requested.
+func BlockDetails(number *big.Int) w3types.RPCCallerFactory[*BlockDetailsResponse] {
+	return module.NewFactory[*BlockDetailsResponse](
+		"ots_getBlockDetails",
+		[]any{module.BlockNumberArg(number)},
+	)
+}
+
+// BlockTxs requests the page with the given page number and page size of the
+// transactions and receipts of the block with the given number. The input of
+// the transactions is truncated to the function selector and logs of the
+// receipts are omitted.
+func BlockTxs(number *big.Int, pageNumber, pageSize uint8) w3types.RPCCallerFactory[*BlockTxsResponse] {
+	return module.NewFactory[*BlockTxsResponse](
+		"ots_getBlockTransactions",
+		[]any{module.BlockNumberArg(number), pageNumber, pageSize},
+	)
+}
+
+type BlockDetailsResponse struct {
+	Header      *types.Header
+	TxCount     uint64
+	BlockReward *big.Int
+	UncleReward *big.Int
+	Issuance    *big.Int
+	TotalFees   *big.Int
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *BlockDetailsResponse) UnmarshalJSON(data []byte) error {
+	type issuance struct {
+		BlockReward *hexutil.Big `json:"blockReward"`
+		UncleReward *hexutil.Big `json:"uncleReward"`
+		Issuance    *hexutil.Big `json:"issuance"`
+	}
+	type blockDetails struct {
+		Block     *block       `json:"block"`
+		Issuance  issuance     `json:"issuance"`
+		TotalFees *hexutil.Big `json:"totalFees"`
+	}
+
+	var dec blockDetails
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	if dec.Block != nil {
+		r.Header = dec.Block.Header()
+		r.TxCount = dec.Block.TxCount
+	}
+	r.BlockReward = (*big.Int)(dec.Issuance.BlockReward)
+	r.UncleReward = (*big.Int)(dec.Issuance.UncleReward)
+	r.Issuance = (*big.Int)(dec.Issuance.Issuance)
+	r.TotalFees = (*big.Int)(dec.TotalFees)
+	return nil
+}
+
+type BlockTxsResponse struct {
+	Block    *types.Block // Block with the transactions of the page
+	TxCount  uint64       // Total number of transactions of the block
+	Receipts types.Receipts
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *BlockTxsResponse) UnmarshalJSON(data []byte) error {
+	type blockTxs struct {
+		FullBlock *block     `json:"fullblock"`
+		Receipts  []*receipt `json:"receipts"`
+	}
+
+	var dec blockTxs
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	if dec.FullBlock != nil {
+		r.Block = dec.FullBlock.Block
+		r.TxCount = dec.FullBlock.TxCount
+	}
+	r.Receipts = receipts(dec.Receipts)
+	return nil
+}
+
+// block is a block as returned by the "ots"-namespace, which has no logs bloom.
+type block struct {
+	*types.Block
+	TxCount uint64
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (b *block) UnmarshalJSON(data []byte) error {
+	data, err := withDefaults(data, map[string]string{"logsBloom": zeroBloom})
+	if err != nil {
+		return err
+	}
+
+	var header types.Header
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+
+	var blockExtraData struct {
+		Transactions []*types.Transaction `json:"transactions"`
+		TxCount      uint64               `json:"transactionCount"`
+	}
+	if err := json.Unmarshal(data, &blockExtraData); err != nil {
+		return err
+	}
+
+	b.Block = types.NewBlockWithHeader(&header).WithBody(types.Body{Transactions: blockExtraData.Transactions})
+	b.TxCount = blockExtraData.TxCount
+	return nil
+}
+
+// receipt is a receipt as returned by the "ots"-namespace, which may have no
+// logs and logs bloom.
+type receipt types.Receipt
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *receipt) UnmarshalJSON(data []byte) error {
+	data, err := withDefaults(data, map[string]string{"logs": "[]", "logsBloom": zeroBloom})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, (*types.Receipt)(r))
+}
+
+func receipts(r []*receipt) types.Receipts {
+	if r == nil {
+		return nil
+	}
+	receipts := make(types.Receipts, len(r))
+	for i, receipt := range r {
+		receipts[i] = (*types.Receipt)(receipt)
+	}
+	return receipts
+}
+
+var zeroBloom = `"0x` + strings.Repeat("00", types.BloomByteLength) + `"`
+
+// withDefaults sets the missing or null fields of the given JSON object to the
+// given raw JSON values.
+func withDefaults(data []byte, defaults map[string]string) ([]byte, error) {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, err
+	}
+
+	for key, val := range defaults {
+		if v, ok := obj[key]; !ok || string(v) == "null" {
+			obj[key] = json.RawMessage(val)
+		}
+	}
+	return json.Marshal(obj)
+}
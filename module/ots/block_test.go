@@ -0,0 +1,81 @@
+package ots_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/ots"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	header46147 = &types.Header{
+		ParentHash:  w3.H("0x5a41d0e66b4120775176c09fcf39e7c0520517a13d2b57b18d33d342df038bfc"),
+		UncleHash:   w3.H("0x1dcc4de8dec75d7aab85b567b6ccd41ad312451b948a7413f0a142fd40d49347"),
+		Coinbase:    w3.A("0xe6A7a1d47ff21B6321162AEA7C6CB457D5476Bca"),
+		Root:        w3.H("0x0e0df2706b0a4fb8bd08c9246d472abbe850af446405d9eba1db41db18b4a169"),
+		TxHash:      w3.H("0x4513310fcb9f6f616972a3b948dc5d547f280849a87ebb5af0191f98b87be598"),
+		ReceiptHash: w3.H("0xfe2bf2a941abf41d72637e5b91750332a30283efd40c424dc522b77e6f0ed8c4"),
+		Difficulty:  w3.I("0x153886c1bbd"),
+		Number:      w3.I("0xb443"),
+		GasLimit:    0x520b,
+		GasUsed:     0x5208,
+		Time:        0x55c42659,
+		Extra:       w3.B("0x657468706f6f6c2e6f7267"),
+		MixDigest:   w3.H("0xb48c515a9dde8d346c3337ea520aa995a4738bb595495506125449c1149d6cf4"),
+		Nonce:       types.EncodeNonce(0xba4f8ecd18aab215),
+	}
+	tx46147 = types.NewTx(&types.LegacyTx{
+		Nonce:    0x0,
+		GasPrice: w3.I("0x2d79883d2000"),
+		Gas:      0x5208,
+		To:       w3.APtr("0x5DF9B87991262F6BA471F09758CDE1c0FC1De734"),
+		Value:    w3.I("0x7a69"),
+		V:        w3.I("0x1c"),
+		R:        w3.I("0x88ff6cf0fefd94db46111149ae4bfc179e9b94721fffd821d38d16464b3f71d0"),
+		S:        w3.I("0x45e0aff800961cfce805daef7016b9b675c137a6a41a548f7b60a3484c06a33a"),
+	})
+	receipt46147 = &types.Receipt{
+		Status:            types.ReceiptStatusSuccessful,
+		CumulativeGasUsed: 0x5208,
+		Logs:              []*types.Log{},
+		TxHash:            w3.H("0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"),
+		GasUsed:           0x5208,
+		EffectiveGasPrice: w3.I("0x2d79883d2000"),
+		BlockHash:         w3.H("0x4e3a3754410177e6937ef1f84bba68ea139e8d1a2258c5f85db9f1cd715a1bdd"),
+		BlockNumber:       big.NewInt(46147),
+	}
+)
+
+func TestBlockDetails(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*ots.BlockDetailsResponse]{
+		{
+			Golden: "get_block_details",
+			Call:   ots.BlockDetails(big.NewInt(46147)),
+			WantRet: &ots.BlockDetailsResponse{
+				Header:      header46147,
+				TxCount:     1,
+				BlockReward: w3.I("5 ether"),
+				UncleReward: new(big.Int),
+				Issuance:    w3.I("5 ether"),
+				TotalFees:   w3.I("1.05 ether"),
+			},
+		},
+	})
+}
+
+func TestBlockTxs(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*ots.BlockTxsResponse]{
+		{
+			Golden: "get_block_transactions",
+			Call:   ots.BlockTxs(big.NewInt(46147), 0, 25),
+			WantRet: &ots.BlockTxsResponse{
+				Block:    types.NewBlockWithHeader(header46147).WithBody(types.Body{Transactions: types.Transactions{tx46147}}),
+				TxCount:  1,
+				Receipts: types.Receipts{receipt46147},
+			},
+		},
+	})
+}
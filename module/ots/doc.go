@@ -0,0 +1,5 @@
+/*
+Package ots implements RPC API bindings for methods in the "ots" namespace of
+Otterscan compatible nodes, e.g. Erigon and Anvil.
+*/
+package ots
@@ -0,0 +1,58 @@
+package ots
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// SearchTxsBefore requests a page of at most pageSize transactions of the given
+// address before the block with the given number in descending order. If
+// blockNumber is zero, the search starts at the latest block.
+func SearchTxsBefore(addr common.Address, blockNumber uint64, pageSize uint16) w3types.RPCCallerFactory[*SearchResponse] {
+	return module.NewFactory[*SearchResponse](
+		"ots_searchTransactionsBefore",
+		[]any{addr, blockNumber, pageSize},
+	)
+}
+
+// SearchTxsAfter requests a page of at most pageSize transactions of the given
+// address after the block with the given number in descending order. If
+// blockNumber is zero, the search starts at the genesis block.
+func SearchTxsAfter(addr common.Address, blockNumber uint64, pageSize uint16) w3types.RPCCallerFactory[*SearchResponse] {
+	return module.NewFactory[*SearchResponse](
+		"ots_searchTransactionsAfter",
+		[]any{addr, blockNumber, pageSize},
+	)
+}
+
+type SearchResponse struct {
+	Txs       []*types.Transaction
+	Receipts  types.Receipts
+	FirstPage bool // Page contains the most recent transactions
+	LastPage  bool // Page contains the oldest transactions
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *SearchResponse) UnmarshalJSON(data []byte) error {
+	type search struct {
+		Txs       []*types.Transaction `json:"txs"`
+		Receipts  []*receipt           `json:"receipts"`
+		FirstPage bool                 `json:"firstPage"`
+		LastPage  bool                 `json:"lastPage"`
+	}
+
+	var dec search
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	r.Txs = dec.Txs
+	r.Receipts = receipts(dec.Receipts)
+	r.FirstPage = dec.FirstPage
+	r.LastPage = dec.LastPage
+	return nil
+}
@@ -0,0 +1,37 @@
+package ots_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/ots"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestSearchTxsBefore(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*ots.SearchResponse]{
+		{
+			Golden: "search_transactions_before",
+			Call:   ots.SearchTxsBefore(w3.A("0xa1e4380a3b1f749673e270229993ee55f35663b4"), 0, 25),
+			WantRet: &ots.SearchResponse{
+				Txs:       []*types.Transaction{tx46147},
+				Receipts:  types.Receipts{receipt46147},
+				FirstPage: true,
+				LastPage:  true,
+			},
+		},
+	})
+}
+
+func TestSearchTxsAfter(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*ots.SearchResponse]{
+		{
+			Golden: "search_transactions_after",
+			Call:   ots.SearchTxsAfter(w3.A("0xa1e4380a3b1f749673e270229993ee55f35663b4"), 46146, 25),
+			WantRet: &ots.SearchResponse{
+				FirstPage: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package ots
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// InternalOperations requests the internal ETH transfers, self-destructs and
+// contract creations of the transaction with the given hash.
+func InternalOperations(txHash common.Hash) w3types.RPCCallerFactory[[]*InternalOperation] {
+	return module.NewFactory[[]*InternalOperation](
+		"ots_getInternalOperations",
+		[]any{txHash},
+	)
+}
+
+// TxError requests the raw revert data of the transaction with the given hash.
+// The revert data is empty, if the transaction succeeded.
+func TxError(txHash common.Hash) w3types.RPCCallerFactory[[]byte] {
+	return module.NewFactory(
+		"ots_getTransactionError",
+		[]any{txHash},
+		module.WithRetWrapper(module.HexBytesRetWrapper),
+	)
+}
+
+// TraceTx requests the call trace of the transaction with the given hash.
+func TraceTx(txHash common.Hash) w3types.RPCCallerFactory[[]*Trace] {
+	return module.NewFactory[[]*Trace](
+		"ots_traceTransaction",
+		[]any{txHash},
+	)
+}
+
+// TxBySenderAndNonce requests the hash of the transaction of the given sender
+// with the given nonce.
+func TxBySenderAndNonce(sender common.Address, nonce uint64) w3types.RPCCallerFactory[common.Hash] {
+	return module.NewFactory[common.Hash](
+		"ots_getTransactionBySenderAndNonce",
+		[]any{sender, nonce},
+	)
+}
+
+// OperationType is the type of an [InternalOperation].
+type OperationType uint8
+
+const (
+	OpTransfer     OperationType = iota // ETH transfer
+	OpSelfDestruct                      // Self-destruct
+	OpCreate                            // Contract creation using CREATE
+	OpCreate2                           // Contract creation using CREATE2
+)
+
+// InternalOperation is an internal ETH transfer, self-destruct or contract
+// creation of a transaction.
+type InternalOperation struct {
+	Type  OperationType
+	From  common.Address
+	To    common.Address // Recipient, or address of the created contract
+	Value *big.Int
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (op *InternalOperation) UnmarshalJSON(data []byte) error {
+	type internalOperation struct {
+		Type  OperationType  `json:"type"`
+		From  common.Address `json:"from"`
+		To    common.Address `json:"to"`
+		Value *hexutil.Big   `json:"value"`
+	}
+
+	var dec internalOperation
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	op.Type = dec.Type
+	op.From = dec.From
+	op.To = dec.To
+	op.Value = (*big.Int)(dec.Value)
+	return nil
+}
+
+// Trace is a call frame of the call trace of a transaction.
+type Trace struct {
+	Type   string // "CALL", "STATICCALL", "DELEGATECALL", "CALLCODE", "CREATE", "CREATE2" or "SELFDESTRUCT"
+	Depth  uint64
+	From   common.Address
+	To     common.Address
+	Value  *big.Int // Nil for calls without value, e.g. "STATICCALL"
+	Input  []byte
+	Output []byte
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (t *Trace) UnmarshalJSON(data []byte) error {
+	type trace struct {
+		Type   string         `json:"type"`
+		Depth  uint64         `json:"depth"`
+		From   common.Address `json:"from"`
+		To     common.Address `json:"to"`
+		Value  *hexutil.Big   `json:"value"`
+		Input  hexutil.Bytes  `json:"input"`
+		Output hexutil.Bytes  `json:"output"`
+	}
+
+	var dec trace
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	t.Type = dec.Type
+	t.Depth = dec.Depth
+	t.From = dec.From
+	t.To = dec.To
+	t.Value = (*big.Int)(dec.Value)
+	t.Input = dec.Input
+	t.Output = dec.Output
+	return nil
+}
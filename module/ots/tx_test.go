@@ -0,0 +1,83 @@
+package ots_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/ots"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var txHash = w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")
+
+func TestInternalOperations(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*ots.InternalOperation]{
+		{
+			Golden: "get_internal_operations",
+			Call:   ots.InternalOperations(txHash),
+			WantRet: []*ots.InternalOperation{
+				{
+					Type:  ots.OpTransfer,
+					From:  w3.A("0x000000000000000000000000000000000000c0Fe"),
+					To:    w3.A("0x000000000000000000000000000000000000dEaD"),
+					Value: w3.I("1 ether"),
+				},
+				{
+					Type:  ots.OpCreate2,
+					From:  w3.A("0x000000000000000000000000000000000000c0Fe"),
+					To:    w3.A("0x000000000000000000000000000000000000bEEF"),
+					Value: new(big.Int),
+				},
+			},
+		},
+	})
+}
+
+func TestTxError(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]byte]{
+		{
+			Golden:  "get_transaction_error",
+			Call:    ots.TxError(txHash),
+			WantRet: w3.B("0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000047465737400000000000000000000000000000000000000000000000000000000"),
+		},
+	})
+}
+
+func TestTraceTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*ots.Trace]{
+		{
+			Golden: "trace_transaction",
+			Call:   ots.TraceTx(txHash),
+			WantRet: []*ots.Trace{
+				{
+					Type:   "CALL",
+					From:   w3.A("0x000000000000000000000000000000000000c0Fe"),
+					To:     w3.A("0x000000000000000000000000000000000000dEaD"),
+					Value:  big.NewInt(1),
+					Input:  w3.B("0xc0fe"),
+					Output: []byte{},
+				},
+				{
+					Type:   "STATICCALL",
+					Depth:  1,
+					From:   w3.A("0x000000000000000000000000000000000000dEaD"),
+					To:     w3.A("0x000000000000000000000000000000000000bEEF"),
+					Input:  w3.B("0x70a08231000000000000000000000000000000000000000000000000000000000000dead"),
+					Output: common.BigToHash(big.NewInt(42)).Bytes(),
+				},
+			},
+		},
+	})
+}
+
+func TestTxBySenderAndNonce(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[common.Hash]{
+		{
+			Golden:  "get_transaction_by_sender_and_nonce",
+			Call:    ots.TxBySenderAndNonce(w3.A("0xa1e4380a3b1f749673e270229993ee55f35663b4"), 0),
+			WantRet: w3.H("0x5c504ed432cb51138bcf09aa5e8a410dd4a1e204ef84bfed1be16dfba1b22060"),
+		},
+	})
+}
@@ -0,0 +1,5 @@
+/*
+Package trace implements RPC API bindings for methods in the "trace" namespace,
+as supported by e.g. Erigon, Reth and Nethermind.
+*/
+package trace
@@ -0,0 +1,139 @@
+package trace
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// StateDiff is the state difference of all accounts that are modified by a
+// transaction or message.
+type StateDiff map[common.Address]*AccountDiff
+
+// AccountDiff is the state difference of a single account. Fields are nil if
+// they are unchanged.
+type AccountDiff struct {
+	Balance *Diff[*big.Int]
+	Nonce   *Diff[uint64]
+	Code    *Diff[[]byte]
+	Storage map[common.Hash]*Diff[common.Hash] // Only contains changed slots
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (d *AccountDiff) UnmarshalJSON(data []byte) error {
+	type accountDiff struct {
+		Balance *Diff[*big.Int]                    `json:"balance"`
+		Nonce   *Diff[uint64]                      `json:"nonce"`
+		Code    *Diff[[]byte]                      `json:"code"`
+		Storage map[common.Hash]*Diff[common.Hash] `json:"storage"`
+	}
+
+	var dec accountDiff
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	d.Balance = dec.Balance.orNil()
+	d.Nonce = dec.Nonce.orNil()
+	d.Code = dec.Code.orNil()
+	for slot, diff := range dec.Storage {
+		if diff = diff.orNil(); diff == nil {
+			continue
+		}
+		if d.Storage == nil {
+			d.Storage = make(map[common.Hash]*Diff[common.Hash])
+		}
+		d.Storage[slot] = diff
+	}
+	return nil
+}
+
+// Diff is the difference of a value. If the value was created, From is the zero
+// value. If the value was removed, To is the zero value.
+type Diff[T any] struct {
+	Kind DiffKind
+	From T
+	To   T
+}
+
+// DiffKind is the kind of a [Diff].
+type DiffKind uint8
+
+const (
+	DiffUnchanged DiffKind = iota // "="
+	DiffCreated                   // "+"
+	DiffRemoved                   // "-"
+	DiffChanged                   // "*"
+)
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (d *Diff[T]) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte(`"="`)) {
+		d.Kind = DiffUnchanged
+		return nil
+	}
+
+	var dec map[string]json.RawMessage
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+	if len(dec) != 1 {
+		return fmt.Errorf("trace: invalid diff %s", data)
+	}
+
+	for kind, val := range dec {
+		switch kind {
+		case "+":
+			d.Kind = DiffCreated
+			return unmarshalHex(val, &d.To)
+		case "-":
+			d.Kind = DiffRemoved
+			return unmarshalHex(val, &d.From)
+		case "*":
+			d.Kind = DiffChanged
+			var change struct {
+				From json.RawMessage `json:"from"`
+				To   json.RawMessage `json:"to"`
+			}
+			if err := json.Unmarshal(val, &change); err != nil {
+				return err
+			}
+			if err := unmarshalHex(change.From, &d.From); err != nil {
+				return err
+			}
+			return unmarshalHex(change.To, &d.To)
+		}
+	}
+	return fmt.Errorf("trace: invalid diff %s", data)
+}
+
+// orNil returns nil, if the diff is unchanged.
+func (d *Diff[T]) orNil() *Diff[T] {
+	if d == nil || d.Kind == DiffUnchanged {
+		return nil
+	}
+	return d
+}
+
+// unmarshalHex unmarshals the given hex encoded JSON string into v.
+func unmarshalHex[T any](data []byte, v *T) error {
+	switch v := any(v).(type) {
+	case **big.Int:
+		var b hexutil.Big
+		if err := json.Unmarshal(data, &b); err != nil {
+			return err
+		}
+		*v = (*big.Int)(&b)
+		return nil
+	case *uint64:
+		return json.Unmarshal(data, (*hexutil.Uint64)(v))
+	case *[]byte:
+		return json.Unmarshal(data, (*hexutil.Bytes)(v))
+	default:
+		return json.Unmarshal(data, v)
+	}
+}
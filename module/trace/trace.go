@@ -0,0 +1,196 @@
+package trace
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Type is the type of a trace that is requested by [Call], [CallMany],
+// [ReplayTx] and [ReplayBlockTxs]. If no types are given, only [TypeTrace] is
+// requested.
+type Type string
+
+const (
+	TypeTrace     Type = "trace"     // Call trace
+	TypeVMTrace   Type = "vmTrace"   // Virtual machine execution trace
+	TypeStateDiff Type = "stateDiff" // State difference
+)
+
+// Call requests the traces of the given types of the given message.
+func Call(msg *w3types.Message, blockNumber *big.Int, types ...Type) w3types.RPCCallerFactory[*Result] {
+	return module.NewFactory(
+		"trace_call",
+		[]any{msg, typesArg(types), module.BlockNumberArg(blockNumber)},
+		module.WithArgsWrapper[*Result](msgArgsWrapper),
+	)
+}
+
+// CallMany requests the traces of the given types of the given messages, which
+// are executed on top of each other.
+func CallMany(msgs []*w3types.Message, blockNumber *big.Int, types ...Type) w3types.RPCCallerFactory[[]*Result] {
+	calls := make([][]any, len(msgs))
+	for i, msg := range msgs {
+		calls[i] = []any{msg, typesArg(types)}
+	}
+	return module.NewFactory(
+		"trace_callMany",
+		[]any{calls, module.BlockNumberArg(blockNumber)},
+		module.WithArgsWrapper[[]*Result](func(args []any) ([]any, error) {
+			for _, call := range args[0].([][]any) {
+				if err := encodeInput(call[0].(*w3types.Message)); err != nil {
+					return nil, err
+				}
+			}
+			return args, nil
+		}),
+	)
+}
+
+// Tx requests the call traces of the transaction with the given hash.
+func Tx(txHash common.Hash) w3types.RPCCallerFactory[[]*debug.FlatCallTrace] {
+	return module.NewFactory[[]*debug.FlatCallTrace](
+		"trace_transaction",
+		[]any{txHash},
+	)
+}
+
+// Block requests the call traces of all transactions of the block with the
+// given number.
+func Block(blockNumber *big.Int) w3types.RPCCallerFactory[[]*debug.FlatCallTrace] {
+	return module.NewFactory[[]*debug.FlatCallTrace](
+		"trace_block",
+		[]any{module.BlockNumberArg(blockNumber)},
+	)
+}
+
+// Filter requests the call traces that match the given filter.
+func Filter(filter *FilterQuery) w3types.RPCCallerFactory[[]*debug.FlatCallTrace] {
+	return module.NewFactory[[]*debug.FlatCallTrace](
+		"trace_filter",
+		[]any{filter},
+	)
+}
+
+// ReplayTx requests the traces of the given types of the transaction with the
+// given hash.
+func ReplayTx(txHash common.Hash, types ...Type) w3types.RPCCallerFactory[*Result] {
+	return module.NewFactory[*Result](
+		"trace_replayTransaction",
+		[]any{txHash, typesArg(types)},
+	)
+}
+
+// ReplayBlockTxs requests the traces of the given types of all transactions of
+// the block with the given number.
+func ReplayBlockTxs(blockNumber *big.Int, types ...Type) w3types.RPCCallerFactory[[]*Result] {
+	return module.NewFactory[[]*Result](
+		"trace_replayBlockTransactions",
+		[]any{module.BlockNumberArg(blockNumber), typesArg(types)},
+	)
+}
+
+// FilterQuery is the filter of [Filter]. Traces match the filter, if their
+// block is in the block range and their sender or recipient is one of the
+// given addresses (if any).
+type FilterQuery struct {
+	FromBlock   *big.Int         // Start of the block range (earliest if nil)
+	ToBlock     *big.Int         // End of the block range (latest if nil)
+	FromAddress []common.Address // Senders of the calls
+	ToAddress   []common.Address // Recipients of the calls
+	After       uint64           // Offset of the first trace
+	Count       uint64           // Maximum number of traces (all if zero)
+}
+
+// MarshalJSON implements the [json.Marshaler].
+func (f *FilterQuery) MarshalJSON() ([]byte, error) {
+	type filter struct {
+		FromBlock   string           `json:"fromBlock,omitempty"`
+		ToBlock     string           `json:"toBlock,omitempty"`
+		FromAddress []common.Address `json:"fromAddress,omitempty"`
+		ToAddress   []common.Address `json:"toAddress,omitempty"`
+		After       uint64           `json:"after,omitempty"`
+		Count       uint64           `json:"count,omitempty"`
+	}
+
+	enc := filter{
+		FromAddress: f.FromAddress,
+		ToAddress:   f.ToAddress,
+		After:       f.After,
+		Count:       f.Count,
+	}
+	if f.FromBlock != nil {
+		enc.FromBlock = module.BlockNumberArg(f.FromBlock)
+	}
+	if f.ToBlock != nil {
+		enc.ToBlock = module.BlockNumberArg(f.ToBlock)
+	}
+	return json.Marshal(enc)
+}
+
+// Result is the result of a replayed transaction or message. Only the traces of
+// the requested types are set.
+type Result struct {
+	Output    []byte
+	Trace     []*debug.FlatCallTrace // Set for [TypeTrace]
+	VMTrace   *VMTrace               // Set for [TypeVMTrace]
+	StateDiff StateDiff              // Set for [TypeStateDiff]
+	TxHash    common.Hash            // Only set by [ReplayBlockTxs]
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (r *Result) UnmarshalJSON(data []byte) error {
+	type result struct {
+		Output    hexutil.Bytes          `json:"output"`
+		Trace     []*debug.FlatCallTrace `json:"trace"`
+		VMTrace   *VMTrace               `json:"vmTrace"`
+		StateDiff StateDiff              `json:"stateDiff"`
+		TxHash    common.Hash            `json:"transactionHash"`
+	}
+
+	var dec result
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	r.Output = dec.Output
+	r.Trace = dec.Trace
+	r.VMTrace = dec.VMTrace
+	r.StateDiff = dec.StateDiff
+	r.TxHash = dec.TxHash
+	return nil
+}
+
+func typesArg(types []Type) []Type {
+	if types == nil {
+		return []Type{TypeTrace}
+	}
+	return types
+}
+
+func msgArgsWrapper(args []any) ([]any, error) {
+	if err := encodeInput(args[0].(*w3types.Message)); err != nil {
+		return nil, err
+	}
+	return args, nil
+}
+
+// encodeInput sets the input of the given message to its encoded function
+// arguments, if the input is not set.
+func encodeInput(msg *w3types.Message) error {
+	if msg.Input != nil || msg.Func == nil {
+		return nil
+	}
+
+	input, err := msg.Func.EncodeArgs(msg.Args...)
+	if err != nil {
+		return err
+	}
+	msg.Input = input
+	return nil
+}
@@ -0,0 +1,183 @@
+package trace_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/holiman/uint256"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/debug"
+	"github.com/lmittmann/w3/module/trace"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	addrC0fe = w3.A("0x000000000000000000000000000000000000c0Fe")
+	addrDead = w3.A("0x000000000000000000000000000000000000dEaD")
+	txHash   = w3.H("0x0000000000000000000000000000000000000000000000000000000000000001")
+	blkHash  = w3.H("0x0000000000000000000000000000000000000000000000000000000000000002")
+)
+
+func TestCall(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*trace.Result]{
+		{
+			Golden: "call",
+			Call: trace.Call(&w3types.Message{
+				From:  addrC0fe,
+				To:    &addrDead,
+				Value: big.NewInt(1),
+			}, nil, trace.TypeTrace, trace.TypeStateDiff),
+			WantRet: &trace.Result{
+				Output: []byte{},
+				Trace: []*debug.FlatCallTrace{{
+					Type:         "call",
+					CallType:     "call",
+					From:         addrC0fe,
+					To:           addrDead,
+					Gas:          500_000_000,
+					Value:        big.NewInt(1),
+					Input:        []byte{},
+					Output:       []byte{},
+					TraceAddress: []uint64{},
+				}},
+				StateDiff: trace.StateDiff{
+					addrC0fe: {
+						Balance: &trace.Diff[*big.Int]{Kind: trace.DiffChanged, From: w3.I("1 ether"), To: w3.I("999999999999999999")},
+						Nonce:   &trace.Diff[uint64]{Kind: trace.DiffChanged, From: 0, To: 1},
+					},
+					addrDead: {
+						Balance: &trace.Diff[*big.Int]{Kind: trace.DiffCreated, To: big.NewInt(1)},
+						Nonce:   &trace.Diff[uint64]{Kind: trace.DiffCreated},
+						Code:    &trace.Diff[[]byte]{Kind: trace.DiffCreated, To: []byte{}},
+						Storage: map[common.Hash]*trace.Diff[common.Hash]{
+							common.BigToHash(big.NewInt(1)): {Kind: trace.DiffChanged, To: common.BigToHash(big.NewInt(42))},
+						},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestCallMany(t *testing.T) {
+	funcBalanceOf := w3.MustNewFunc("balanceOf(address)", "uint256")
+
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*trace.Result]{
+		{
+			Golden: "call_many",
+			Call: trace.CallMany([]*w3types.Message{
+				{From: addrC0fe, To: &addrDead, Func: funcBalanceOf, Args: []any{addrC0fe}},
+				{From: addrC0fe, To: &addrDead},
+			}, big.NewInt(1)),
+			WantRet: []*trace.Result{
+				{Output: common.BigToHash(big.NewInt(42)).Bytes(), Trace: []*debug.FlatCallTrace{}},
+				{Output: []byte{}, Trace: []*debug.FlatCallTrace{}},
+			},
+		},
+	})
+}
+
+func TestTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.FlatCallTrace]{
+		{
+			Golden: "transaction",
+			Call:   trace.Tx(txHash),
+			WantRet: []*debug.FlatCallTrace{{
+				Type:         "suicide",
+				From:         addrDead,
+				To:           addrC0fe,
+				Value:        big.NewInt(1),
+				TraceAddress: []uint64{},
+				BlockNumber:  1,
+				BlockHash:    blkHash,
+				TxHash:       txHash,
+				TxIndex:      3,
+			}},
+		},
+	})
+}
+
+func TestBlock(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.FlatCallTrace]{
+		{
+			Golden: "block",
+			Call:   trace.Block(big.NewInt(1)),
+			WantRet: []*debug.FlatCallTrace{{
+				Type:         "call",
+				CallType:     "staticcall",
+				From:         addrC0fe,
+				To:           addrDead,
+				Gas:          21_000,
+				Value:        new(big.Int),
+				Input:        []byte{},
+				Error:        "Reverted",
+				TraceAddress: []uint64{},
+				BlockNumber:  1,
+				BlockHash:    blkHash,
+				TxHash:       txHash,
+			}},
+		},
+	})
+}
+
+func TestFilter(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*debug.FlatCallTrace]{
+		{
+			Golden: "filter",
+			Call: trace.Filter(&trace.FilterQuery{
+				FromBlock: big.NewInt(1),
+				ToAddress: []common.Address{addrDead},
+				Count:     10,
+			}),
+			WantRet: []*debug.FlatCallTrace{},
+		},
+	})
+}
+
+func TestReplayTx(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*trace.Result]{
+		{
+			Golden: "replay_transaction",
+			Call:   trace.ReplayTx(txHash, trace.TypeVMTrace),
+			WantRet: &trace.Result{
+				Output: []byte{},
+				Trace:  []*debug.FlatCallTrace{},
+				VMTrace: &trace.VMTrace{
+					Code: w3.B("0x602a60005500"),
+					Ops: []*trace.VMOp{
+						{Pc: 0, Cost: 3, Used: 99997, Push: []uint256.Int{*uint256.NewInt(42)}},
+						{Pc: 2, Cost: 3, Used: 99994, Push: []uint256.Int{{}}},
+						{Pc: 4, Cost: 22100, Used: 77894, Push: []uint256.Int{}, Store: &trace.VMStore{Val: common.BigToHash(big.NewInt(42))}},
+						{Pc: 5, Cost: 0, Used: 77894, Push: []uint256.Int{}},
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestReplayBlockTxs(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[[]*trace.Result]{
+		{
+			Golden: "replay_block_transactions",
+			Call:   trace.ReplayBlockTxs(big.NewInt(1)),
+			WantRet: []*trace.Result{{
+				Output: []byte{},
+				Trace: []*debug.FlatCallTrace{{
+					Type:         "create",
+					From:         addrC0fe,
+					To:           w3.A("0x000000000000000000000000000000000000bEEF"),
+					Gas:          0x1000,
+					GasUsed:      0x100,
+					Value:        new(big.Int),
+					Input:        w3.B("0x6000"),
+					Output:       []byte{},
+					TraceAddress: []uint64{},
+				}},
+				TxHash: txHash,
+			}},
+		},
+	})
+}
@@ -0,0 +1,99 @@
+package trace
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/holiman/uint256"
+)
+
+// VMTrace is the virtual machine execution trace of a call.
+type VMTrace struct {
+	Code []byte
+	Ops  []*VMOp
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (t *VMTrace) UnmarshalJSON(data []byte) error {
+	type vmTrace struct {
+		Code hexutil.Bytes `json:"code"`
+		Ops  []*VMOp       `json:"ops"`
+	}
+
+	var dec vmTrace
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	t.Code = dec.Code
+	t.Ops = dec.Ops
+	return nil
+}
+
+// VMOp is an executed operation of a [VMTrace].
+type VMOp struct {
+	Pc    uint64
+	Cost  uint64
+	Used  uint64        // Remaining gas after the operation
+	Push  []uint256.Int // Values pushed to the stack
+	Mem   *VMMem        // Memory write, if any
+	Store *VMStore      // Storage write, if any
+	Sub   *VMTrace      // Trace of the sub-call, if any
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler].
+func (op *VMOp) UnmarshalJSON(data []byte) error {
+	type mem struct {
+		Data hexutil.Bytes `json:"data"`
+		Off  uint64        `json:"off"`
+	}
+	type store struct {
+		Key uint256.Int `json:"key"`
+		Val uint256.Int `json:"val"`
+	}
+	type ex struct {
+		Mem   *mem          `json:"mem"`
+		Push  []uint256.Int `json:"push"`
+		Store *store        `json:"store"`
+		Used  uint64        `json:"used"`
+	}
+	type vmOp struct {
+		Pc   uint64   `json:"pc"`
+		Cost uint64   `json:"cost"`
+		Ex   *ex      `json:"ex"`
+		Sub  *VMTrace `json:"sub"`
+	}
+
+	var dec vmOp
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	op.Pc = dec.Pc
+	op.Cost = dec.Cost
+	op.Sub = dec.Sub
+	if dec.Ex != nil {
+		op.Used = dec.Ex.Used
+		op.Push = dec.Ex.Push
+		if dec.Ex.Mem != nil {
+			op.Mem = &VMMem{Offset: dec.Ex.Mem.Off, Data: dec.Ex.Mem.Data}
+		}
+		if dec.Ex.Store != nil {
+			op.Store = &VMStore{Key: dec.Ex.Store.Key.Bytes32(), Val: dec.Ex.Store.Val.Bytes32()}
+		}
+	}
+	return nil
+}
+
+// VMMem is a memory write of a [VMOp].
+type VMMem struct {
+	Offset uint64
+	Data   []byte
+}
+
+// VMStore is a storage write of a [VMOp].
+type VMStore struct {
+	Key common.Hash
+	Val common.Hash
+}
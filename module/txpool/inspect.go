@@ -0,0 +1,116 @@
+package txpool
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Inspect requests a summary of the pending and queued transactions in the
+// transaction pool.
+func Inspect() w3types.RPCCallerFactory[*InspectResponse] {
+	return module.NewFactory[*InspectResponse](
+		"txpool_inspect",
+		nil,
+	)
+}
+
+// InspectResponse is the response of [Inspect]. It contains the summaries of
+// the pending and queued transactions per sender, sorted by nonce.
+type InspectResponse struct {
+	Pending map[common.Address][]*TxSummary
+	Queued  map[common.Address][]*TxSummary
+}
+
+// TxSummary is the summary of a transaction in the transaction pool.
+type TxSummary struct {
+	Nonce    uint64
+	To       *common.Address // nil for contract creations
+	Value    *big.Int
+	Gas      uint64
+	GasPrice *big.Int
+}
+
+func (i *InspectResponse) UnmarshalJSON(data []byte) error {
+	type inspectResponse struct {
+		Pending map[common.Address]map[string]string `json:"pending"`
+		Queued  map[common.Address]map[string]string `json:"queued"`
+	}
+
+	var dec inspectResponse
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	var err error
+	if i.Pending, err = txSummaries(dec.Pending); err != nil {
+		return err
+	}
+	if i.Queued, err = txSummaries(dec.Queued); err != nil {
+		return err
+	}
+	return nil
+}
+
+func txSummaries(dec map[common.Address]map[string]string) (map[common.Address][]*TxSummary, error) {
+	summaries := make(map[common.Address][]*TxSummary, len(dec))
+	for addr, nonceSummary := range dec {
+		txs := make([]*TxSummary, 0, len(nonceSummary))
+		for nonce, summary := range nonceSummary {
+			tx, err := parseTxSummary(nonce, summary)
+			if err != nil {
+				return nil, err
+			}
+			txs = append(txs, tx)
+		}
+		slices.SortFunc(txs, func(a, b *TxSummary) int {
+			return cmp.Compare(a.Nonce, b.Nonce)
+		})
+		summaries[addr] = txs
+	}
+	return summaries, nil
+}
+
+// parseTxSummary parses a transaction summary of the form
+// "<to>: <value> wei + <gas> gas × <gasPrice> wei", where <to> is either an
+// address or "contract creation".
+func parseTxSummary(nonce, summary string) (*TxSummary, error) {
+	tx := new(TxSummary)
+
+	var err error
+	if tx.Nonce, err = strconv.ParseUint(nonce, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid nonce %q", nonce)
+	}
+
+	to, rest, ok := strings.Cut(summary, ": ")
+	if !ok {
+		return nil, fmt.Errorf("invalid tx summary %q", summary)
+	}
+	if to != "contract creation" {
+		if !common.IsHexAddress(to) {
+			return nil, fmt.Errorf("invalid tx summary %q", summary)
+		}
+		addr := common.HexToAddress(to)
+		tx.To = &addr
+	}
+
+	var value, gasPrice string
+	if _, err := fmt.Sscanf(rest, "%s wei + %d gas × %s wei", &value, &tx.Gas, &gasPrice); err != nil {
+		return nil, fmt.Errorf("invalid tx summary %q", summary)
+	}
+	if tx.Value, ok = new(big.Int).SetString(value, 10); !ok {
+		return nil, fmt.Errorf("invalid tx summary %q", summary)
+	}
+	if tx.GasPrice, ok = new(big.Int).SetString(gasPrice, 10); !ok {
+		return nil, fmt.Errorf("invalid tx summary %q", summary)
+	}
+	return tx, nil
+}
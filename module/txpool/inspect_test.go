@@ -0,0 +1,56 @@
+package txpool_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/txpool"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestInspect(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[*txpool.InspectResponse]{
+		{
+			Golden: "inspect",
+			Call:   txpool.Inspect(),
+			WantRet: &txpool.InspectResponse{
+				Pending: map[common.Address][]*txpool.TxSummary{
+					w3.A("0x000454307bB96E303044046a6eB2736D2aD560B6"): {
+						{
+							Nonce:    4652,
+							To:       w3.APtr("0xEf1c6E67703c7BD7107eed8303Fbe6EC2554BF6B"),
+							Value:    big.NewInt(81000000000000000),
+							Gas:      1100000,
+							GasPrice: big.NewInt(202871575924),
+						},
+						{
+							Nonce:    4653,
+							To:       w3.APtr("0x000000000000000000000000000000000000dEaD"),
+							Value:    big.NewInt(0),
+							Gas:      21000,
+							GasPrice: big.NewInt(202871575924),
+						},
+					},
+				},
+				Queued: map[common.Address][]*txpool.TxSummary{
+					w3.A("0x000000000000000000000000000000000000c0Fe"): {
+						{
+							Nonce:    7,
+							Value:    big.NewInt(0),
+							Gas:      1000000,
+							GasPrice: w3.I("30 gwei"),
+						},
+					},
+				},
+			},
+		},
+		{
+			Golden:  "inspect__invalid",
+			Call:    txpool.Inspect(),
+			WantErr: errors.New(`w3: call failed: invalid tx summary "0xEf1c6E67703c7BD7107eed8303Fbe6EC2554BF6B: 81000000000000000 wei"`),
+		},
+	})
+}
@@ -12,24 +12,28 @@ import (
 	"testing"
 )
 
-// Server is a fake RPC endpoint that responds only to a single requests that
-// is defined in a golden-file.
+// Server is a fake RPC endpoint that responds to the requests that are defined
+// in a golden-file.
 //
-// Request golden-files have the following format to define a single request
-// and the corresponding response:
+// Request golden-files have the following format to define a request and the
+// corresponding response:
 //
 //	// Comments and empty lines will be ignored.
 //	// Request starts with ">".
 //	> {"jsonrpc":"2.0","id":1,"method":"eth_chainId"}
 //	// Response starts with "<".
 //	< {"jsonrpc":"2.0","id":1,"result":"0x1"}
+//
+// A golden-file may define multiple request-response pairs, that are expected
+// in the given order. The last pair is repeated for all subsequent requests.
 type Server struct {
 	t *testing.T
 
 	reader   io.Reader
 	readOnce sync.Once
-	in       []byte
-	out      []byte
+	mux      sync.Mutex
+	pairs    []pair
+	i        int
 
 	httptestSrv *httptest.Server
 }
@@ -64,14 +68,23 @@ func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		srv.t.Fatalf("Failed to read body: %v", err)
 	}
 
+	// get next request-response pair
+	srv.mux.Lock()
+	var p pair
+	if len(srv.pairs) > 0 {
+		p = srv.pairs[min(srv.i, len(srv.pairs)-1)]
+	}
+	srv.i++
+	srv.mux.Unlock()
+
 	// check body
-	if !bytes.Equal(srv.in, body) {
-		srv.t.Fatalf("Invalid request body (-want, +got)\n-%s\n+%s", srv.in, body)
+	if !bytes.Equal(p.in, body) {
+		srv.t.Fatalf("Invalid request body (-want, +got)\n-%s\n+%s", p.in, body)
 	}
 
 	// respond
 	w.Header().Set("Content-Type", "application/json")
-	w.Write(srv.out)
+	w.Write(p.out)
 }
 
 // URL returns the servers RPC endpoint url.
@@ -99,12 +112,13 @@ func (srv *Server) readGolden() {
 		switch line[0] {
 		case '>':
 			trimedLine := bytes.Trim(line, "> ")
-			srv.in = make([]byte, len(trimedLine))
-			copy(srv.in, trimedLine)
+			srv.pairs = append(srv.pairs, pair{in: bytes.Clone(trimedLine)})
 		case '<':
+			if len(srv.pairs) <= 0 {
+				srv.t.Fatalf("Response %q without request", scan.Text())
+			}
 			trimedLine := bytes.Trim(line, "< ")
-			srv.out = make([]byte, len(trimedLine))
-			copy(srv.out, trimedLine)
+			srv.pairs[len(srv.pairs)-1].out = bytes.Clone(trimedLine)
 		case '/': // ignore lines starting with "/"
 		default:
 			srv.t.Fatalf("Invalid line %q", scan.Text())
@@ -114,3 +128,9 @@ func (srv *Server) readGolden() {
 		srv.t.Fatalf("Failed to scan file: %v", err)
 	}
 }
+
+// pair is a request-response pair.
+type pair struct {
+	in  []byte
+	out []byte
+}
@@ -0,0 +1,128 @@
+package w3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	// FuncIsValidSignature is the ERC-1271 function of contract accounts to
+	// verify signatures.
+	FuncIsValidSignature = MustNewFunc("isValidSignature(bytes32 hash, bytes signature)", "bytes4 magicValue")
+
+	// MagicValueERC1271 is returned by [FuncIsValidSignature] if the signature
+	// is valid.
+	MagicValueERC1271 = [4]byte{0x16, 0x26, 0xba, 0x7e}
+
+	// MagicValueERC6492 is the suffix of ERC-6492 signatures of counterfactual
+	// contract accounts.
+	MagicValueERC6492 = common.HexToHash("0x6492649264926492649264926492649264926492649264926492649264926492")
+)
+
+// HashMessage returns the EIP-191 hash of the given message:
+//
+//	keccak256("\x19Ethereum Signed Message:\n" + len(msg) + msg)
+func HashMessage(msg []byte) common.Hash {
+	return common.BytesToHash(accounts.TextHash(msg))
+}
+
+// SignMessage signs the EIP-191 hash of the given message with the given
+// signer, like "personal_sign". The signature is returned in the
+// [R || S || V] format, where V is 27 or 28.
+func SignMessage(signer Signer, msg []byte) ([]byte, error) {
+	if ms, ok := signer.(messageSigner); ok {
+		return ms.SignMessage(msg)
+	}
+
+	sig, err := signer.SignHash(HashMessage(msg))
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+// messageSigner is implemented by signers that can only sign EIP-191 messages,
+// but not arbitrary hashes.
+type messageSigner interface {
+	SignMessage(msg []byte) ([]byte, error)
+}
+
+// RecoverMessage returns the address of the signer of the EIP-191 hash of the
+// given message.
+func RecoverMessage(msg, sig []byte) (common.Address, error) {
+	return RecoverHash(HashMessage(msg), sig)
+}
+
+// RecoverHash returns the address of the signer of the given hash. V of the
+// signature may be 0 or 1, or 27 or 28.
+func RecoverHash(hash common.Hash, sig []byte) (common.Address, error) {
+	if len(sig) != crypto.SignatureLength {
+		return common.Address{}, fmt.Errorf("w3: invalid signature length %d", len(sig))
+	}
+
+	sig = common.CopyBytes(sig)
+	if sig[crypto.RecoveryIDOffset] >= 27 {
+		sig[crypto.RecoveryIDOffset] -= 27
+	}
+	pub, err := crypto.SigToPub(hash[:], sig)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pub), nil
+}
+
+// VerifySignatureCtx reports whether sig is a valid signature of the given
+// hash by the account with the given address. Signatures of externally owned
+// accounts are verified using ecrecover, signatures of contract accounts are
+// verified using ERC-1271.
+//
+// A signature is invalid, if the ERC-1271 call reverts, or the account has no
+// code. All other errors of the call are returned.
+func VerifySignatureCtx(ctx context.Context, client *Client, addr common.Address, hash common.Hash, sig []byte) (bool, error) {
+	if signer, err := RecoverHash(hash, sig); err == nil && signer == addr {
+		return true, nil
+	}
+
+	var output []byte
+	if err := client.CallCtx(ctx,
+		eth.Call(&w3types.Message{To: &addr, Func: FuncIsValidSignature, Args: []any{hash, sig}}, nil, nil).Returns(&output),
+	); err != nil {
+		var callErrs CallErrors
+		if errors.As(err, &callErrs) && isRevert(callErrs[0]) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// the account has no code, or returned no valid magic value
+	var magicValue [4]byte
+	if err := FuncIsValidSignature.DecodeReturns(output, &magicValue); err != nil {
+		return false, nil
+	}
+	return magicValue == MagicValueERC1271, nil
+}
+
+// VerifySignature is like [VerifySignatureCtx] with ctx equal to
+// context.Background().
+func VerifySignature(client *Client, addr common.Address, hash common.Hash, sig []byte) (bool, error) {
+	return VerifySignatureCtx(context.Background(), client, addr, hash, sig)
+}
+
+// isRevert reports whether the given RPC error of a call is a revert.
+func isRevert(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	return rpcErr.ErrorCode() == 3 || strings.HasPrefix(rpcErr.Error(), "execution reverted")
+}
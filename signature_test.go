@@ -0,0 +1,124 @@
+package w3_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+func TestHashMessage(t *testing.T) {
+	want := w3.H("0x50b2c43fd39106bafbba0da34fc430e1f91e3c96ea2acee2bc34119f92b37750")
+	if got := w3.HashMessage([]byte("hello")); want != got {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+}
+
+func TestSignMessage(t *testing.T) {
+	key, _ := crypto.ToECDSA(keyCow[:])
+	msg := []byte("hello")
+
+	sig, err := w3.SignMessage(w3.NewKeySigner(key), msg)
+	if err != nil {
+		t.Fatalf("Failed to sign message: %v", err)
+	}
+	if v := sig[64]; v != 27 && v != 28 {
+		t.Fatalf("V: want 27 or 28, got %d", v)
+	}
+
+	signer, err := w3.RecoverMessage(msg, sig)
+	if err != nil {
+		t.Fatalf("Failed to recover signer: %v", err)
+	}
+	if want, got := addrCow, signer; want != got {
+		t.Fatalf("Signer: want %s, got %s", want, got)
+	}
+
+	if _, err := w3.RecoverMessage(msg, sig[:64]); err == nil {
+		t.Fatal("Want error for invalid signature length")
+	}
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, _ := crypto.ToECDSA(keyCow[:])
+	hash := common.Hash{0xc0, 0xfe}
+	sig, _ := w3.NewKeySigner(key).SignHash(hash)
+	input, _ := w3.FuncIsValidSignature.EncodeArgs(hash, sig)
+
+	t.Run("eoa", func(t *testing.T) {
+		// no requests are sent for valid signatures of externally owned accounts
+		srv := rpctest.NewServer(t, bytes.NewBufferString(""))
+		defer srv.Close()
+
+		client := w3.MustDial(srv.URL())
+		defer client.Close()
+
+		valid, err := w3.VerifySignature(client, addrCow, hash, sig)
+		if err != nil {
+			t.Fatalf("Failed to verify signature: %v", err)
+		}
+		if !valid {
+			t.Fatal("Want valid signature")
+		}
+	})
+
+	tests := []struct {
+		Name      string
+		Response  string
+		WantValid bool
+		WantErr   string
+	}{
+		{
+			Name:      "erc1271",
+			Response:  `"result":"0x1626ba7e00000000000000000000000000000000000000000000000000000000"`,
+			WantValid: true,
+		},
+		{
+			Name:     "erc1271-invalid",
+			Response: `"result":"0xffffffff00000000000000000000000000000000000000000000000000000000"`,
+		},
+		{
+			Name:     "revert",
+			Response: `"error":{"code":3,"message":"execution reverted"}`,
+		},
+		{
+			Name:     "no-code",
+			Response: `"result":"0x"`,
+		},
+		{
+			Name:     "node-error",
+			Response: `"error":{"code":-32005,"message":"rate limit exceeded"}`,
+			WantErr:  "w3: call failed: rate limit exceeded",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			srv := rpctest.NewServer(t, bytes.NewBufferString(
+				`> {"jsonrpc":"2.0","id":1,"method":"eth_call","params":[{"to":"0x000000000000000000000000000000000000c0fe","data":"`+hexutil.Encode(input)+`"},"latest"]}`+"\n"+
+					`< {"jsonrpc":"2.0","id":1,`+test.Response+`}`,
+			))
+			defer srv.Close()
+
+			client := w3.MustDial(srv.URL())
+			defer client.Close()
+
+			valid, err := w3.VerifySignature(client, w3.A("0x000000000000000000000000000000000000c0Fe"), hash, sig)
+			if test.WantErr != "" {
+				if err == nil || err.Error() != test.WantErr {
+					t.Fatalf("Err: want %q, got %v", test.WantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Failed to verify signature: %v", err)
+			}
+			if test.WantValid != valid {
+				t.Fatalf("Valid: want %t, got %t", test.WantValid, valid)
+			}
+		})
+	}
+}
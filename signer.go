@@ -0,0 +1,239 @@
+package w3
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/lmittmann/w3/internal/module"
+)
+
+// Signer signs transactions, hashes and EIP-712 typed data on behalf of a
+// single account.
+type Signer interface {
+	// Address returns the address of the account.
+	Address() common.Address
+
+	// SignTx signs the given transaction for the given chain ID.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+
+	// SignHash signs the given hash. The signature is returned in the
+	// [R || S || V] format, where V is 0 or 1.
+	SignHash(hash common.Hash) ([]byte, error)
+
+	// SignTypedData signs the given EIP-712 typed data. The signature is
+	// returned in the [R || S || V] format, where V is 27 or 28.
+	SignTypedData(data *apitypes.TypedData) ([]byte, error)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Key Signer //////////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// KeySigner is a [Signer] that signs using a private key.
+type KeySigner struct {
+	key  *ecdsa.PrivateKey
+	addr common.Address
+}
+
+// NewKeySigner returns a new KeySigner for the given private key.
+func NewKeySigner(key *ecdsa.PrivateKey) *KeySigner {
+	return &KeySigner{
+		key:  key,
+		addr: crypto.PubkeyToAddress(key.PublicKey),
+	}
+}
+
+// NewKeystoreSigner returns a new KeySigner for the private key of the
+// go-ethereum encrypted keystore file at the given path.
+func NewKeystoreSigner(path, passphrase string) (*KeySigner, error) {
+	keyJSON, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("w3: failed to decrypt keystore: %w", err)
+	}
+	return NewKeySigner(key.PrivateKey), nil
+}
+
+// Address returns the address of the private key.
+func (s *KeySigner) Address() common.Address { return s.addr }
+
+// SignTx signs the given transaction for the given chain ID.
+func (s *KeySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.key)
+}
+
+// SignHash signs the given hash.
+func (s *KeySigner) SignHash(hash common.Hash) ([]byte, error) {
+	return crypto.Sign(hash[:], s.key)
+}
+
+// SignTypedData signs the given EIP-712 typed data.
+func (s *KeySigner) SignTypedData(data *apitypes.TypedData) ([]byte, error) {
+	hash, _, err := apitypes.TypedDataAndHash(*data)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := crypto.Sign(hash, s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[crypto.RecoveryIDOffset] += 27
+	return sig, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Remote Signer ///////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+// RemoteSigner is a [Signer] that signs using the account of a remote RPC
+// endpoint, e.g. Clef or a node with an unlocked account.
+//
+// Transactions are signed using "eth_signTransaction" and typed data is signed
+// using "eth_signTypedData_v4". EIP-191 messages are signed using
+// "personal_sign" (see [SignMessage]). Only legacy, access list and dynamic fee
+// transactions can be signed. Signing hashes is not supported.
+type RemoteSigner struct {
+	client *Client
+	addr   common.Address
+}
+
+// NewRemoteSigner returns a new RemoteSigner for the account with the given
+// address of the given client.
+func NewRemoteSigner(client *Client, addr common.Address) *RemoteSigner {
+	return &RemoteSigner{client: client, addr: addr}
+}
+
+// Address returns the address of the remote account.
+func (s *RemoteSigner) Address() common.Address { return s.addr }
+
+// SignTxCtx signs the given transaction for the given chain ID.
+func (s *RemoteSigner) SignTxCtx(ctx context.Context, tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := &signTxArgs{
+		From:    s.addr,
+		To:      tx.To(),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	switch tx.Type() {
+	case types.LegacyTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	case types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+		args.AccessList = tx.AccessList()
+	case types.DynamicFeeTxType:
+		args.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		args.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+		args.AccessList = tx.AccessList()
+	default:
+		return nil, fmt.Errorf("w3: remote signer does not support transaction type %d", tx.Type())
+	}
+
+	var signedTx signTxResult
+	if err := s.client.CallCtx(ctx,
+		module.NewFactory[signTxResult]("eth_signTransaction", []any{args}).Returns(&signedTx),
+	); err != nil {
+		return nil, err
+	}
+	return signedTx.tx, nil
+}
+
+// SignTx is like [RemoteSigner.SignTxCtx] with ctx equal to
+// context.Background().
+func (s *RemoteSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.SignTxCtx(context.Background(), tx, chainID)
+}
+
+// SignHash is not supported by the RemoteSigner and always returns an error.
+func (s *RemoteSigner) SignHash(hash common.Hash) ([]byte, error) {
+	return nil, errors.New("w3: remote signer does not support signing hashes")
+}
+
+// SignMessageCtx signs the given EIP-191 message using "personal_sign".
+func (s *RemoteSigner) SignMessageCtx(ctx context.Context, msg []byte) ([]byte, error) {
+	var sig hexutil.Bytes
+	if err := s.client.CallCtx(ctx,
+		module.NewFactory[hexutil.Bytes]("personal_sign", []any{hexutil.Bytes(msg), s.addr}).Returns(&sig),
+	); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignMessage is like [RemoteSigner.SignMessageCtx] with ctx equal to
+// context.Background().
+func (s *RemoteSigner) SignMessage(msg []byte) ([]byte, error) {
+	return s.SignMessageCtx(context.Background(), msg)
+}
+
+// SignTypedDataCtx signs the given EIP-712 typed data.
+func (s *RemoteSigner) SignTypedDataCtx(ctx context.Context, data *apitypes.TypedData) ([]byte, error) {
+	var sig hexutil.Bytes
+	if err := s.client.CallCtx(ctx,
+		module.NewFactory[hexutil.Bytes]("eth_signTypedData_v4", []any{s.addr, data}).Returns(&sig),
+	); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// SignTypedData is like [RemoteSigner.SignTypedDataCtx] with ctx equal to
+// context.Background().
+func (s *RemoteSigner) SignTypedData(data *apitypes.TypedData) ([]byte, error) {
+	return s.SignTypedDataCtx(context.Background(), data)
+}
+
+type signTxArgs struct {
+	From       common.Address   `json:"from"`
+	To         *common.Address  `json:"to,omitempty"`
+	Nonce      hexutil.Uint64   `json:"nonce"`
+	Gas        hexutil.Uint64   `json:"gas"`
+	GasPrice   *hexutil.Big     `json:"gasPrice,omitempty"`
+	GasFeeCap  *hexutil.Big     `json:"maxFeePerGas,omitempty"`
+	GasTipCap  *hexutil.Big     `json:"maxPriorityFeePerGas,omitempty"`
+	Value      *hexutil.Big     `json:"value"`
+	Data       hexutil.Bytes    `json:"data"`
+	AccessList types.AccessList `json:"accessList,omitempty"`
+	ChainID    *hexutil.Big     `json:"chainId,omitempty"`
+}
+
+// signTxResult is the result of "eth_signTransaction". Depending on the remote
+// endpoint, the result is either the raw transaction, or an object with the
+// raw transaction.
+type signTxResult struct {
+	tx *types.Transaction
+}
+
+func (r *signTxResult) UnmarshalJSON(data []byte) error {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var res struct {
+			Raw hexutil.Bytes `json:"raw"`
+		}
+		if err := json.Unmarshal(data, &res); err != nil {
+			return err
+		}
+		raw = res.Raw
+	}
+
+	r.tx = new(types.Transaction)
+	return r.tx.UnmarshalBinary(raw)
+}
@@ -0,0 +1,183 @@
+package w3_test
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+)
+
+var (
+	keyCow  = crypto.Keccak256Hash([]byte("cow"))
+	addrCow = w3.A("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826")
+)
+
+func TestKeySigner(t *testing.T) {
+	key, _ := crypto.ToECDSA(keyCow[:])
+	signer := w3.NewKeySigner(key)
+
+	if want, got := addrCow, signer.Address(); want != got {
+		t.Fatalf("Address: want %s, got %s", want, got)
+	}
+
+	t.Run("SignTx", func(t *testing.T) {
+		chainID := big.NewInt(1)
+		tx, err := signer.SignTx(types.NewTx(&types.DynamicFeeTx{ChainID: chainID}), chainID)
+		if err != nil {
+			t.Fatalf("Failed to sign tx: %v", err)
+		}
+
+		sender, err := types.Sender(types.LatestSignerForChainID(chainID), tx)
+		if err != nil {
+			t.Fatalf("Failed to recover sender: %v", err)
+		}
+		if want, got := addrCow, sender; want != got {
+			t.Fatalf("Sender: want %s, got %s", want, got)
+		}
+	})
+
+	t.Run("SignHash", func(t *testing.T) {
+		hash := common.Hash{0xc0, 0xfe}
+		sig, err := signer.SignHash(hash)
+		if err != nil {
+			t.Fatalf("Failed to sign hash: %v", err)
+		}
+
+		pub, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			t.Fatalf("Failed to recover public key: %v", err)
+		}
+		if want, got := addrCow, crypto.PubkeyToAddress(*pub); want != got {
+			t.Fatalf("Signer: want %s, got %s", want, got)
+		}
+	})
+
+	t.Run("SignTypedData", func(t *testing.T) {
+		sig, err := signer.SignTypedData(mailTypedData)
+		if err != nil {
+			t.Fatalf("Failed to sign typed data: %v", err)
+		}
+
+		// https://github.com/ethereum/EIPs/blob/master/assets/eip-712/Example.js
+		wantSig := w3.B("0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c")
+		if !bytes.Equal(wantSig, sig) {
+			t.Fatalf("Signature: want %x, got %x", wantSig, sig)
+		}
+	})
+}
+
+func TestNewKeystoreSigner(t *testing.T) {
+	key, _ := crypto.ToECDSA(keyCow[:])
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{
+		Address:    addrCow,
+		PrivateKey: key,
+	}, "passphrase", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatalf("Failed to encrypt key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, keyJSON, 0o600); err != nil {
+		t.Fatalf("Failed to write keystore: %v", err)
+	}
+
+	signer, err := w3.NewKeystoreSigner(path, "passphrase")
+	if err != nil {
+		t.Fatalf("Failed to create signer: %v", err)
+	}
+	if want, got := addrCow, signer.Address(); want != got {
+		t.Fatalf("Address: want %s, got %s", want, got)
+	}
+
+	if _, err := w3.NewKeystoreSigner(path, "wrong passphrase"); err == nil {
+		t.Fatal("Want error for wrong passphrase")
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, _ := crypto.ToECDSA(keyCow[:])
+	to := w3.A("0x000000000000000000000000000000000000c0Fe")
+	chainID := big.NewInt(1)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     1,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Gas:       21_000,
+		To:        &to,
+		Value:     big.NewInt(3),
+	})
+	wantTx, _ := w3.NewKeySigner(key).SignTx(tx, chainID)
+	rawTx, _ := wantTx.MarshalBinary()
+
+	srv := rpctest.NewServer(t, bytes.NewBufferString(
+		`> {"jsonrpc":"2.0","id":1,"method":"eth_signTransaction","params":[{"from":"0xcd2a3d9f938e13cd947ec05abc7fe734df8dd826","to":"0x000000000000000000000000000000000000c0fe","nonce":"0x1","gas":"0x5208","maxFeePerGas":"0x2","maxPriorityFeePerGas":"0x1","value":"0x3","data":"0x","chainId":"0x1"}]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":1,"result":{"raw":"`+hexutil.Encode(rawTx)+`"}}`,
+	))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	signer := w3.NewRemoteSigner(client, addrCow)
+	gotTx, err := signer.SignTx(tx, chainID)
+	if err != nil {
+		t.Fatalf("Failed to sign tx: %v", err)
+	}
+	if want, got := wantTx.Hash(), gotTx.Hash(); want != got {
+		t.Fatalf("Tx: want %s, got %s", want, got)
+	}
+
+	if _, err := signer.SignHash(common.Hash{}); err == nil {
+		t.Fatal("Want error for SignHash")
+	}
+}
+
+// mailTypedData is the typed data of the EIP-712 example.
+var mailTypedData = &apitypes.TypedData{
+	Types: apitypes.Types{
+		"EIP712Domain": {
+			{Name: "name", Type: "string"},
+			{Name: "version", Type: "string"},
+			{Name: "chainId", Type: "uint256"},
+			{Name: "verifyingContract", Type: "address"},
+		},
+		"Person": {
+			{Name: "name", Type: "string"},
+			{Name: "wallet", Type: "address"},
+		},
+		"Mail": {
+			{Name: "from", Type: "Person"},
+			{Name: "to", Type: "Person"},
+			{Name: "contents", Type: "string"},
+		},
+	},
+	PrimaryType: "Mail",
+	Domain: apitypes.TypedDataDomain{
+		Name:              "Ether Mail",
+		Version:           "1",
+		ChainId:           math.NewHexOrDecimal256(1),
+		VerifyingContract: "0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+	},
+	Message: apitypes.TypedDataMessage{
+		"from": map[string]any{
+			"name":   "Cow",
+			"wallet": "0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		},
+		"to": map[string]any{
+			"name":   "Bob",
+			"wallet": "0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+		},
+		"contents": "Hello, Bob!",
+	},
+}
@@ -0,0 +1,56 @@
+package siwe_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/siwe"
+)
+
+// Create a Sign-In with Ethereum message for the user to sign.
+func ExampleMessage() {
+	msg := &siwe.Message{
+		Domain:    "example.com",
+		Address:   w3.A("0x000000000000000000000000000000000000c0Fe"),
+		Statement: "Sign in to Example",
+		URI:       "https://example.com/login",
+		Version:   "1",
+		ChainID:   1,
+		Nonce:     "abcdEFGH1234",
+		IssuedAt:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	fmt.Println(msg)
+	// Output:
+	// example.com wants you to sign in with your Ethereum account:
+	// 0x000000000000000000000000000000000000c0Fe
+	//
+	// Sign in to Example
+	//
+	// URI: https://example.com/login
+	// Version: 1
+	// Chain ID: 1
+	// Nonce: abcdEFGH1234
+	// Issued At: 2025-01-01T00:00:00Z
+}
+
+// Verify a signed Sign-In with Ethereum message of an EOA or smart wallet.
+func ExampleVerifier() {
+	client := w3.MustDial("https://ethereum-rpc.publicnode.com")
+	defer client.Close()
+
+	var (
+		rawMsg string // message signed by the user
+		sig    []byte // signature of the message
+		nonce  string // nonce issued to the user
+	)
+
+	verifier := siwe.NewVerifier(client, "example.com", siwe.WithChainID(1))
+	msg, err := verifier.Verify(context.Background(), rawMsg, sig, nonce)
+	if err != nil {
+		// ...
+		return
+	}
+	fmt.Printf("Signed in as %s\n", msg.Address)
+}
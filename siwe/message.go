@@ -0,0 +1,301 @@
+/*
+Package siwe implements parsing, serialization and verification of EIP-4361
+Sign-In with Ethereum messages.
+*/
+package siwe
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	headerSuffix = " wants you to sign in with your Ethereum account:"
+
+	tagURI            = "URI: "
+	tagVersion        = "Version: "
+	tagChainID        = "Chain ID: "
+	tagNonce          = "Nonce: "
+	tagIssuedAt       = "Issued At: "
+	tagExpirationTime = "Expiration Time: "
+	tagNotBefore      = "Not Before: "
+	tagRequestID      = "Request ID: "
+	tagResources      = "Resources:"
+	tagResource       = "- "
+)
+
+// ErrInvalidMessage is returned by [Parse] if a message does not conform to the
+// EIP-4361 message format.
+var ErrInvalidMessage = errors.New("siwe: invalid message")
+
+// Message is an EIP-4361 Sign-In with Ethereum message.
+type Message struct {
+	Scheme         string         // Optional URI scheme of the origin of the request
+	Domain         string         // RFC 3986 authority that is requesting the signing
+	Address        common.Address // Address performing the signing
+	Statement      string         // Optional human-readable assertion, must not contain "\n"
+	URI            string         // RFC 3986 URI referring to the subject of the signing
+	Version        string         // Version of the message, must be "1"
+	ChainID        uint64         // EIP-155 chain ID
+	Nonce          string         // Randomized token of at least 8 alphanumeric characters
+	IssuedAt       time.Time      // Time when the message was generated
+	ExpirationTime time.Time      // Optional time when the message expires
+	NotBefore      time.Time      // Optional time when the message becomes valid
+	RequestID      string         // Optional system-specific identifier
+	Resources      []string       // Optional list of RFC 3986 URIs
+}
+
+// Parse parses the given EIP-4361 message. The grammar of the message is
+// strictly validated, including the EIP-55 checksum of the address.
+func Parse(s string) (*Message, error) {
+	p := &parser{lines: strings.Split(s, "\n")}
+	msg := new(Message)
+
+	// header
+	header, ok := strings.CutSuffix(p.next(), headerSuffix)
+	if !ok {
+		return nil, p.errorf("invalid header")
+	}
+	if scheme, domain, ok := strings.Cut(header, "://"); ok {
+		if !isScheme(scheme) {
+			return nil, p.errorf("invalid scheme %q", scheme)
+		}
+		msg.Scheme, header = scheme, domain
+	}
+	if !isAuthority(header) {
+		return nil, p.errorf("invalid domain %q", header)
+	}
+	msg.Domain = header
+
+	// address
+	rawAddr := p.next()
+	addr, err := common.NewMixedcaseAddressFromString(rawAddr)
+	if err != nil || len(rawAddr) != 2+2*common.AddressLength || !strings.HasPrefix(rawAddr, "0x") {
+		return nil, p.errorf("invalid address %q", rawAddr)
+	}
+	if !addr.ValidChecksum() {
+		return nil, p.errorf("invalid address checksum %q", rawAddr)
+	}
+	msg.Address = addr.Address()
+
+	// statement, a second empty line denotes no statement
+	if p.next() != "" {
+		return nil, p.errorf("missing empty line")
+	}
+	if p.peek() != "" {
+		msg.Statement = p.next()
+	}
+	if p.next() != "" {
+		return nil, p.errorf("missing empty line")
+	}
+
+	// required fields
+	if msg.URI, err = p.field(tagURI, true); err != nil {
+		return nil, err
+	} else if !isURI(msg.URI) {
+		return nil, p.errorf("invalid URI %q", msg.URI)
+	}
+
+	if msg.Version, err = p.field(tagVersion, true); err != nil {
+		return nil, err
+	} else if msg.Version != "1" {
+		return nil, p.errorf("invalid version %q", msg.Version)
+	}
+
+	rawChainID, err := p.field(tagChainID, true)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ChainID, err = parseChainID(rawChainID); err != nil {
+		return nil, p.errorf("invalid chain ID %q", rawChainID)
+	}
+
+	if msg.Nonce, err = p.field(tagNonce, true); err != nil {
+		return nil, err
+	} else if !isNonce(msg.Nonce) {
+		return nil, p.errorf("invalid nonce %q", msg.Nonce)
+	}
+
+	if msg.IssuedAt, err = p.timeField(tagIssuedAt, true); err != nil {
+		return nil, err
+	}
+
+	// optional fields
+	if msg.ExpirationTime, err = p.timeField(tagExpirationTime, false); err != nil {
+		return nil, err
+	}
+	if msg.NotBefore, err = p.timeField(tagNotBefore, false); err != nil {
+		return nil, err
+	}
+	if msg.RequestID, err = p.field(tagRequestID, false); err != nil {
+		return nil, err
+	}
+	if p.peek() == tagResources {
+		p.next()
+		for p.more() {
+			resource, ok := strings.CutPrefix(p.next(), tagResource)
+			if !ok || !isURI(resource) {
+				return nil, p.errorf("invalid resource")
+			}
+			msg.Resources = append(msg.Resources, resource)
+		}
+	}
+
+	if p.more() {
+		return nil, p.errorf("unexpected line")
+	}
+	return msg, nil
+}
+
+// String returns the EIP-4361 message. Times are formatted using
+// [time.RFC3339Nano].
+//
+// String does not necessarily reproduce the text a message was parsed from,
+// e.g. trailing zeros of fractional seconds are dropped. Signatures must thus
+// be verified against the raw message, as done by [Verifier.Verify].
+func (m *Message) String() string {
+	var b strings.Builder
+	if m.Scheme != "" {
+		b.WriteString(m.Scheme + "://")
+	}
+	b.WriteString(m.Domain + headerSuffix + "\n")
+	b.WriteString(m.Address.Hex() + "\n\n")
+	if m.Statement != "" {
+		b.WriteString(m.Statement + "\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(tagURI + m.URI + "\n")
+	b.WriteString(tagVersion + m.Version + "\n")
+	b.WriteString(tagChainID + strconv.FormatUint(m.ChainID, 10) + "\n")
+	b.WriteString(tagNonce + m.Nonce + "\n")
+	b.WriteString(tagIssuedAt + m.IssuedAt.Format(time.RFC3339Nano))
+	if !m.ExpirationTime.IsZero() {
+		b.WriteString("\n" + tagExpirationTime + m.ExpirationTime.Format(time.RFC3339Nano))
+	}
+	if !m.NotBefore.IsZero() {
+		b.WriteString("\n" + tagNotBefore + m.NotBefore.Format(time.RFC3339Nano))
+	}
+	if m.RequestID != "" {
+		b.WriteString("\n" + tagRequestID + m.RequestID)
+	}
+	if len(m.Resources) > 0 {
+		b.WriteString("\n" + tagResources)
+		for _, resource := range m.Resources {
+			b.WriteString("\n" + tagResource + resource)
+		}
+	}
+	return b.String()
+}
+
+// parser reads the lines of a message.
+type parser struct {
+	lines []string
+	i     int // index of the next line
+}
+
+func (p *parser) more() bool { return p.i < len(p.lines) }
+
+func (p *parser) peek() string {
+	if !p.more() {
+		return ""
+	}
+	return p.lines[p.i]
+}
+
+func (p *parser) next() string {
+	line := p.peek()
+	p.i++
+	return line
+}
+
+// field returns the value of the next line if it starts with the given tag.
+func (p *parser) field(tag string, required bool) (string, error) {
+	val, ok := strings.CutPrefix(p.peek(), tag)
+	if !ok {
+		if required {
+			p.i++
+			return "", p.errorf("missing %q", strings.TrimSuffix(tag, ": "))
+		}
+		return "", nil
+	}
+	p.i++
+	return val, nil
+}
+
+// timeField is like field, but parses the value as RFC 3339 date-time.
+func (p *parser) timeField(tag string, required bool) (time.Time, error) {
+	val, err := p.field(tag, required)
+	if err != nil || val == "" {
+		return time.Time{}, err
+	}
+	t, err := time.Parse(time.RFC3339Nano, val)
+	if err != nil {
+		return time.Time{}, p.errorf("invalid %s %q", strings.TrimSuffix(tag, ": "), val)
+	}
+	return t, nil
+}
+
+// errorf returns an error for the last read line.
+func (p *parser) errorf(format string, args ...any) error {
+	return fmt.Errorf("%w: line %d: %s", ErrInvalidMessage, p.i, fmt.Sprintf(format, args...))
+}
+
+func parseChainID(s string) (uint64, error) {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return 0, strconv.ErrSyntax
+	}
+	return strconv.ParseUint(s, 10, 64)
+}
+
+// isScheme reports whether s is a valid RFC 3986 URI scheme.
+func isScheme(s string) bool {
+	if s == "" || !isAlpha(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !isAlpha(c) && !isDigit(c) && c != '+' && c != '-' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+// isAuthority reports whether s is a valid RFC 3986 authority.
+func isAuthority(s string) bool {
+	if s == "" || strings.ContainsAny(s, " /?#") {
+		return false
+	}
+	u, err := url.Parse("//" + s)
+	return err == nil && u.Host != ""
+}
+
+// isURI reports whether s is a valid absolute RFC 3986 URI.
+func isURI(s string) bool {
+	if strings.ContainsAny(s, " \t") {
+		return false
+	}
+	u, err := url.Parse(s)
+	return err == nil && u.IsAbs()
+}
+
+// isNonce reports whether s consists of at least 8 alphanumeric characters.
+func isNonce(s string) bool {
+	if len(s) < 8 {
+		return false
+	}
+	for i := range len(s) {
+		if c := s[i]; !isAlpha(c) && !isDigit(c) {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(c byte) bool { return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' }
+func isDigit(c byte) bool { return '0' <= c && c <= '9' }
@@ -0,0 +1,174 @@
+package siwe_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/siwe"
+)
+
+// msgExample is the example message of EIP-4361.
+const msgExample = `service.invalid wants you to sign in with your Ethereum account:
+0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2
+
+I accept the ServiceOrg Terms of Service: https://service.invalid/tos
+
+URI: https://service.invalid/login
+Version: 1
+Chain ID: 1
+Nonce: 32891756
+Issued At: 2021-09-30T16:25:24Z
+Resources:
+- ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/
+- https://example.com/my-web2-claim.json`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		Name string
+		Msg  string
+		Want *siwe.Message
+	}{
+		{
+			Name: "example",
+			Msg:  msgExample,
+			Want: &siwe.Message{
+				Domain:    "service.invalid",
+				Address:   w3.A("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+				Statement: "I accept the ServiceOrg Terms of Service: https://service.invalid/tos",
+				URI:       "https://service.invalid/login",
+				Version:   "1",
+				ChainID:   1,
+				Nonce:     "32891756",
+				IssuedAt:  time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC),
+				Resources: []string{
+					"ipfs://bafybeiemxf5abjwjbikoz4mc3a3dla6ual3jsgpdr4cjr3oz3evfyavhwq/",
+					"https://example.com/my-web2-claim.json",
+				},
+			},
+		},
+		{
+			Name: "all-fields",
+			Msg: "https://example.com:3000 wants you to sign in with your Ethereum account:\n" +
+				"0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2\n" +
+				"\n" +
+				"\n" +
+				"URI: https://example.com:3000/login\n" +
+				"Version: 1\n" +
+				"Chain ID: 10\n" +
+				"Nonce: abcdEFGH1234\n" +
+				"Issued At: 2021-09-30T16:25:24.123Z\n" +
+				"Expiration Time: 2021-10-30T16:25:24Z\n" +
+				"Not Before: 2021-09-30T16:00:00Z\n" +
+				"Request ID: some-request-id",
+			Want: &siwe.Message{
+				Scheme:         "https",
+				Domain:         "example.com:3000",
+				Address:        w3.A("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"),
+				URI:            "https://example.com:3000/login",
+				Version:        "1",
+				ChainID:        10,
+				Nonce:          "abcdEFGH1234",
+				IssuedAt:       time.Date(2021, 9, 30, 16, 25, 24, 123_000_000, time.UTC),
+				ExpirationTime: time.Date(2021, 10, 30, 16, 25, 24, 0, time.UTC),
+				NotBefore:      time.Date(2021, 9, 30, 16, 0, 0, 0, time.UTC),
+				RequestID:      "some-request-id",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := siwe.Parse(test.Msg)
+			if err != nil {
+				t.Fatalf("Failed to parse message: %v", err)
+			}
+			if diff := cmp.Diff(test.Want, got); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+
+			// round trip
+			if want, got := test.Msg, got.String(); want != got {
+				t.Fatalf("String: want\n%s\ngot\n%s", want, got)
+			}
+		})
+	}
+}
+
+// TestParse_noStatement tests a message without statement, as generated by
+// siwe-js.
+func TestParse_noStatement(t *testing.T) {
+	const msg = "service.org wants you to sign in with your Ethereum account:\n" +
+		"0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946\n" +
+		"\n" +
+		"\n" +
+		"URI: https://service.org/login\n" +
+		"Version: 1\n" +
+		"Chain ID: 1\n" +
+		"Nonce: 32891757\n" +
+		"Issued At: 2021-09-30T16:25:24.000Z"
+
+	want := &siwe.Message{
+		Domain:   "service.org",
+		Address:  w3.A("0xe5A12547fe4E872D192E3eCecb76F2Ce1aeA4946"),
+		URI:      "https://service.org/login",
+		Version:  "1",
+		ChainID:  1,
+		Nonce:    "32891757",
+		IssuedAt: time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC),
+	}
+
+	got, err := siwe.Parse(msg)
+	if err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+
+	// round trip
+	got, err = siwe.Parse(got.String())
+	if err != nil {
+		t.Fatalf("Failed to parse message: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		Name string
+		Msg  string
+	}{
+		{Name: "empty", Msg: ""},
+		{Name: "header", Msg: strings.Replace(msgExample, "wants you to sign in", "wants you to log in", 1)},
+		{Name: "scheme", Msg: "1http://" + msgExample},
+		{Name: "domain", Msg: strings.Replace(msgExample, "service.invalid wants", "service.invalid/path wants", 1)},
+		{Name: "address-checksum", Msg: strings.Replace(msgExample, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2", 1)},
+		{Name: "address-length", Msg: strings.Replace(msgExample, "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2", "0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756C", 1)},
+		{Name: "missing-empty-line", Msg: strings.Replace(msgExample, "tos\n\nURI", "tos\nURI", 1)},
+		{Name: "missing-statement-line", Msg: strings.Replace(msgExample, "\n\nI accept the ServiceOrg Terms of Service: https://service.invalid/tos\n", "\n", 1)},
+		{Name: "uri", Msg: strings.Replace(msgExample, "URI: https://service.invalid/login", "URI: /login", 1)},
+		{Name: "version", Msg: strings.Replace(msgExample, "Version: 1", "Version: 2", 1)},
+		{Name: "chain-id", Msg: strings.Replace(msgExample, "Chain ID: 1", "Chain ID: 01", 1)},
+		{Name: "nonce-short", Msg: strings.Replace(msgExample, "Nonce: 32891756", "Nonce: 3289175", 1)},
+		{Name: "nonce-chars", Msg: strings.Replace(msgExample, "Nonce: 32891756", "Nonce: 32891756!", 1)},
+		{Name: "issued-at", Msg: strings.Replace(msgExample, "2021-09-30T16:25:24Z", "2021-09-30 16:25:24", 1)},
+		{Name: "missing-nonce", Msg: strings.Replace(msgExample, "Nonce: 32891756\n", "", 1)},
+		{Name: "field-order", Msg: strings.Replace(msgExample, "Version: 1\nChain ID: 1", "Chain ID: 1\nVersion: 1", 1)},
+		{Name: "resource", Msg: msgExample + "\n-https://example.com"},
+		{Name: "trailing-newline", Msg: msgExample + "\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if _, err := siwe.Parse(test.Msg); !errors.Is(err, siwe.ErrInvalidMessage) {
+				t.Fatalf("Want ErrInvalidMessage, got %v", err)
+			}
+		})
+	}
+}
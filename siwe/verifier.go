@@ -0,0 +1,144 @@
+package siwe
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+	"github.com/lmittmann/w3/w3vm"
+)
+
+var (
+	ErrDomainMismatch   = errors.New("siwe: domain mismatch")
+	ErrNonceMismatch    = errors.New("siwe: nonce mismatch")
+	ErrChainIDMismatch  = errors.New("siwe: chain ID mismatch")
+	ErrExpired          = errors.New("siwe: message expired")
+	ErrNotYetValid      = errors.New("siwe: message not yet valid")
+	ErrInvalidSignature = errors.New("siwe: invalid signature")
+)
+
+// Verifier verifies EIP-4361 messages and their signatures.
+type Verifier struct {
+	client *w3.Client
+	domain string
+	opts   *options
+}
+
+// NewVerifier returns a new Verifier that verifies that messages are issued
+// for the given domain, and that verifies signatures of contract accounts using
+// the given client.
+func NewVerifier(client *w3.Client, domain string, opts ...Option) *Verifier {
+	v := &Verifier{
+		client: client,
+		domain: domain,
+		opts:   new(options),
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(v)
+	}
+	return v
+}
+
+// Verify parses the given raw message and verifies its domain, nonce, chain ID,
+// expiration time, not before time, and the given signature of the message. The
+// parsed message is returned if it is valid.
+//
+// The signature is verified against the given raw message, not against the
+// String of the parsed message, which may differ from the signed text, e.g. in
+// the formatting of times.
+//
+// Signatures of externally owned accounts are verified using ecrecover,
+// signatures of contract accounts using ERC-1271. ERC-6492 signatures of
+// counterfactual contract accounts are verified by simulating the deployment of
+// the account in a [w3vm.VM] that is forked from the latest block. All requests,
+// including the requests of the VM, are sent using the given ctx.
+func (v *Verifier) Verify(ctx context.Context, rawMsg string, sig []byte, nonce string) (*Message, error) {
+	msg, err := Parse(rawMsg)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.Domain != v.domain {
+		return nil, ErrDomainMismatch
+	}
+	if msg.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	now := time.Now()
+	if !msg.ExpirationTime.IsZero() && !now.Before(msg.ExpirationTime) {
+		return nil, ErrExpired
+	}
+	if !msg.NotBefore.IsZero() && now.Before(msg.NotBefore) {
+		return nil, ErrNotYetValid
+	}
+
+	var (
+		chainID   = v.opts.chainID
+		isERC6492 = bytes.HasSuffix(sig, w3.MagicValueERC6492[:])
+		header    *types.Header
+		calls     []w3types.RPCCaller
+	)
+	if chainID == 0 {
+		calls = append(calls, eth.ChainID().Returns(&chainID))
+	}
+	if isERC6492 {
+		calls = append(calls, eth.HeaderByNumber(nil).Returns(&header))
+	}
+	if len(calls) > 0 {
+		if err := v.client.CallCtx(ctx, calls...); err != nil {
+			return nil, err
+		}
+	}
+	if msg.ChainID != chainID {
+		return nil, ErrChainIDMismatch
+	}
+
+	var (
+		hash  = w3.HashMessage([]byte(rawMsg))
+		valid bool
+	)
+	if isERC6492 {
+		vm, err := w3vm.New(
+			w3vm.WithFetcher(w3vm.NewRPCFetcherCtx(ctx, v.client, header.Number)),
+			w3vm.WithHeader(header),
+		)
+		if err != nil {
+			return nil, err
+		}
+		valid, err = vm.VerifySignature(msg.Address, hash, sig)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		valid, err = w3.VerifySignatureCtx(ctx, v.client, msg.Address, hash, sig)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+	return msg, nil
+}
+
+type options struct {
+	chainID uint64
+}
+
+// An Option configures a [Verifier].
+type Option func(*Verifier)
+
+// WithChainID sets the chain ID that messages must be issued for. By default,
+// the chain ID of the client is used.
+func WithChainID(chainID uint64) Option {
+	return func(v *Verifier) { v.opts.chainID = chainID }
+}
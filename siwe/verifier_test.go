@@ -0,0 +1,169 @@
+package siwe_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/siwe"
+)
+
+func TestVerifier(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := w3.NewKeySigner(key)
+
+	newMsg := func(modify func(*siwe.Message)) string {
+		msg := &siwe.Message{
+			Domain:   "example.com",
+			Address:  signer.Address(),
+			URI:      "https://example.com/login",
+			Version:  "1",
+			ChainID:  1,
+			Nonce:    "abcdEFGH1234",
+			IssuedAt: time.Now(),
+		}
+		if modify != nil {
+			modify(msg)
+		}
+		return msg.String()
+	}
+	sign := func(msg string) []byte {
+		sig, _ := w3.SignMessage(signer, []byte(msg))
+		return sig
+	}
+
+	// the signer has no code, so the ERC-1271 call of invalid signatures reverts
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+			return
+		}
+		if req.Method != "eth_call" {
+			t.Errorf("Unexpected method %q", req.Method)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"error":{"code":3,"message":"execution reverted"}}`, req.ID)
+	}))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL)
+	defer client.Close()
+
+	verifier := siwe.NewVerifier(client, "example.com", siwe.WithChainID(1))
+
+	validMsg := newMsg(nil)
+	msgMillis := strings.Replace(newMsg(func(m *siwe.Message) {
+		m.IssuedAt = time.Date(2021, 9, 30, 16, 25, 24, 0, time.UTC)
+	}), "2021-09-30T16:25:24Z", "2021-09-30T16:25:24.000Z", 1)
+	tests := []struct {
+		Name    string
+		Msg     string
+		Sig     []byte
+		WantErr error
+	}{
+		{
+			Name: "valid",
+			Msg:  validMsg,
+			Sig:  sign(validMsg),
+		},
+		{
+			Name: "valid-millis",
+			Msg:  msgMillis,
+			Sig:  sign(msgMillis),
+		},
+		{
+			Name:    "invalid-message",
+			Msg:     validMsg + "\n",
+			Sig:     sign(validMsg + "\n"),
+			WantErr: siwe.ErrInvalidMessage,
+		},
+		{
+			Name:    "domain-mismatch",
+			Msg:     newMsg(func(m *siwe.Message) { m.Domain = "evil.com" }),
+			WantErr: siwe.ErrDomainMismatch,
+		},
+		{
+			Name:    "nonce-mismatch",
+			Msg:     newMsg(func(m *siwe.Message) { m.Nonce = "1234abcdEFGH" }),
+			WantErr: siwe.ErrNonceMismatch,
+		},
+		{
+			Name:    "expired",
+			Msg:     newMsg(func(m *siwe.Message) { m.ExpirationTime = time.Now().Add(-time.Minute) }),
+			WantErr: siwe.ErrExpired,
+		},
+		{
+			Name:    "not-yet-valid",
+			Msg:     newMsg(func(m *siwe.Message) { m.NotBefore = time.Now().Add(time.Minute) }),
+			WantErr: siwe.ErrNotYetValid,
+		},
+		{
+			Name:    "chain-id-mismatch",
+			Msg:     newMsg(func(m *siwe.Message) { m.ChainID = 10 }),
+			WantErr: siwe.ErrChainIDMismatch,
+		},
+		{
+			Name:    "invalid-signature",
+			Msg:     validMsg,
+			Sig:     sign(newMsg(func(m *siwe.Message) { m.Statement = "Sign in" })),
+			WantErr: siwe.ErrInvalidSignature,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			msg, err := verifier.Verify(context.Background(), test.Msg, test.Sig, "abcdEFGH1234")
+			if test.WantErr != nil {
+				if !errors.Is(err, test.WantErr) {
+					t.Fatalf("Want %v, got %v", test.WantErr, err)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Failed to verify message: %v", err)
+			}
+			if want, got := signer.Address(), msg.Address; want != got {
+				t.Fatalf("Address: want %s, got %s", want, got)
+			}
+		})
+	}
+}
+
+func TestVerifier_ERC1271(t *testing.T) {
+	const rawMsg = msgExample
+	sig := w3.B("0x" + "00000000000000000000000000000000000000000000000000000000000000c0fe" + "000000000000000000000000000000000000000000000000000000000000c0fe1b")
+	input, _ := w3.FuncIsValidSignature.EncodeArgs(w3.HashMessage([]byte(rawMsg)), sig)
+
+	srv := rpctest.NewServer(t, bytes.NewBufferString(
+		`> {"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`+"\n"+
+			`< {"jsonrpc":"2.0","id":1,"result":"0x1"}`+"\n"+
+			`> {"jsonrpc":"2.0","id":2,"method":"eth_call","params":[{"to":"0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2","data":"`+hexutil.Encode(input)+`"},"latest"]}`+"\n"+
+			`< {"jsonrpc":"2.0","id":2,"result":"0x1626ba7e00000000000000000000000000000000000000000000000000000000"}`,
+	))
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	msg, err := siwe.NewVerifier(client, "service.invalid").Verify(context.Background(), rawMsg, sig, "32891756")
+	if err != nil {
+		t.Fatalf("Failed to verify message: %v", err)
+	}
+	if want, got := w3.A("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2"), msg.Address; want != got {
+		t.Fatalf("Address: want %s, got %s", want, got)
+	}
+}
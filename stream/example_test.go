@@ -0,0 +1,47 @@
+package stream_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/stream"
+)
+
+// Follow the logs of WETH9 Transfer events and handle chain reorganizations.
+func ExampleStream() {
+	client := w3.MustDial("https://ethereum-rpc.publicnode.com")
+	defer client.Close()
+
+	var (
+		addrWETH      = w3.A("0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2")
+		eventTransfer = w3.MustNewEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+		checkpoint    stream.Checkpoint // load persisted checkpoint
+	)
+
+	s := stream.New(client,
+		stream.WithCheckpoint(checkpoint),
+		stream.WithLogs(ethereum.FilterQuery{
+			Addresses: []common.Address{addrWETH},
+			Topics:    [][]common.Hash{{eventTransfer.Topic0}},
+		}),
+	)
+
+	err := s.Run(context.Background(), func(event *stream.Event) error {
+		switch event.Type {
+		case stream.Added:
+			fmt.Printf("Block %d added with %d transfers\n", event.Block.NumberU64(), len(event.Logs))
+		case stream.Removed:
+			fmt.Printf("Block %d removed with %d transfers\n", event.Block.NumberU64(), len(event.Logs))
+		}
+		return nil
+	})
+	if err != nil {
+		// ...
+	}
+
+	// persist checkpoint to resume later
+	fmt.Printf("Checkpoint: %v\n", s.Checkpoint())
+}
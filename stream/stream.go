@@ -0,0 +1,480 @@
+/*
+Package stream implements a chain-reorg-aware stream of blocks, receipts and
+logs.
+*/
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+const (
+	defaultWindowSize = 64
+	batchSize         = 100
+)
+
+// ErrReorgTooDeep is returned by [Stream.Run] if the canonical chain changed
+// below the oldest block in the window.
+var ErrReorgTooDeep = errors.New("stream: reorg deeper than window")
+
+// EventType is the type of an [Event].
+type EventType uint8
+
+const (
+	Added   EventType = iota // Block was added to the canonical chain
+	Removed                  // Block was removed from the canonical chain
+)
+
+func (t EventType) String() string {
+	switch t {
+	case Added:
+		return "added"
+	case Removed:
+		return "removed"
+	default:
+		return fmt.Sprintf("EventType(%d)", t)
+	}
+}
+
+// Event represents a block that was added to or removed from the canonical
+// chain.
+type Event struct {
+	Type     EventType
+	Block    *types.Block   // Block with full transactions
+	Receipts types.Receipts // Receipts of the block, if the stream is configured WithReceipts
+	Logs     []types.Log    // Logs of the block that match the query, if the stream is configured WithLogs
+}
+
+// BlockID identifies a block by its number and hash.
+type BlockID struct {
+	Number uint64
+	Hash   common.Hash
+}
+
+// Checkpoint is the position of a [Stream] that can be persisted to resume the
+// stream using [WithCheckpoint]. It contains the IDs of the most recent blocks
+// of the canonical chain, ordered from oldest to newest.
+type Checkpoint []BlockID
+
+// Stream follows the head of the chain and emits [Event]'s for blocks that are
+// added to or removed from the canonical chain.
+//
+// Stream keeps a window of the most recent blocks to detect chain
+// reorganizations. If the canonical chain changes, a [Removed] event is
+// emitted for each block that is no longer canonical, from newest to oldest,
+// followed by an [Added] event for each block of the new canonical chain.
+type Stream struct {
+	client *w3.Client
+	opts   *options
+
+	mux    sync.Mutex
+	window []*entry // ordered from oldest to newest
+	next   uint64   // number of the next block to add, if the window is empty
+}
+
+type entry struct {
+	BlockID
+	event *Event // nil, if the entry was restored from a checkpoint
+}
+
+// New returns a new [Stream] that follows the chain of the given client.
+func New(client *w3.Client, opts ...Option) *Stream {
+	s := &Stream{
+		client: client,
+		opts:   &options{windowSize: defaultWindowSize},
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(s)
+	}
+
+	for _, id := range s.opts.checkpoint {
+		s.window = append(s.window, &entry{BlockID: id})
+	}
+	s.trim()
+	return s
+}
+
+// Run follows the head of the chain and calls fn for each [Event] in order.
+// Run blocks until ctx is canceled, fn returns an error, or following the head
+// of the chain fails.
+//
+// The state of the stream is only advanced after fn returns without error, so
+// [Stream.Checkpoint] returns the position after the last successfully handled
+// event.
+func (s *Stream) Run(ctx context.Context, fn func(*Event) error) error {
+	headCh := make(chan *types.Header)
+	sub, err := s.client.SubscribeCtx(ctx, eth.NewHeads(headCh))
+	if err != nil {
+		return err
+	}
+	defer sub.Unsubscribe()
+
+	var head *types.Header
+	if err := s.client.CallCtx(ctx, eth.HeaderByNumber(nil).Returns(&head)); err != nil {
+		return err
+	}
+
+	s.mux.Lock()
+	if len(s.window) <= 0 {
+		if s.opts.startBlock != nil {
+			s.next = s.opts.startBlock.Uint64()
+		} else {
+			s.next = head.Number.Uint64()
+		}
+	}
+	s.mux.Unlock()
+
+	for {
+		if err := s.update(ctx, head, fn); err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-sub.Err():
+			return err
+		case head = <-headCh:
+		}
+	}
+}
+
+// Checkpoint returns the current position of the stream.
+func (s *Stream) Checkpoint() Checkpoint {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	cp := make(Checkpoint, len(s.window))
+	for i, e := range s.window {
+		cp[i] = e.BlockID
+	}
+	return cp
+}
+
+// update brings the stream up to date with the given head.
+func (s *Stream) update(ctx context.Context, head *types.Header, fn func(*Event) error) error {
+	if last := s.last(); last != nil && head.Number.Uint64() <= last.Number {
+		if s.contains(BlockID{head.Number.Uint64(), head.Hash()}) {
+			return nil // head is already known
+		}
+		if err := s.reorg(ctx, fn); err != nil {
+			return err
+		}
+	}
+	return s.sync(ctx, head.Number.Uint64(), fn)
+}
+
+// sync adds all blocks up to the given target block number.
+func (s *Stream) sync(ctx context.Context, target uint64, fn func(*Event) error) error {
+	for {
+		next := s.nextNumber()
+		if next > target {
+			return nil
+		}
+		to := min(next+batchSize-1, target)
+
+		// fetch blocks
+		blocks := make([]*types.Block, to-next+1)
+		calls := make([]w3types.RPCCaller, len(blocks))
+		for i := range blocks {
+			calls[i] = eth.BlockByNumber(new(big.Int).SetUint64(next + uint64(i))).Returns(&blocks[i])
+		}
+		if err := s.client.CallCtx(ctx, calls...); err != nil {
+			return err
+		}
+
+		// only keep the blocks that extend the canonical chain of the window
+		var (
+			parentHash common.Hash
+			isReorg    bool
+		)
+		if last := s.last(); last != nil {
+			parentHash = last.Hash
+		} else {
+			parentHash = blocks[0].ParentHash()
+		}
+		for i, block := range blocks {
+			if block.ParentHash() != parentHash {
+				blocks, isReorg = blocks[:i], true
+				break
+			}
+			parentHash = block.Hash()
+		}
+
+		// fetch receipts and logs
+		events, err := s.events(ctx, Added, blocks)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := s.add(event, fn); err != nil {
+				return err
+			}
+		}
+
+		if isReorg {
+			if err := s.reorg(ctx, fn); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// reorg removes all blocks from the window that are no longer part of the
+// canonical chain.
+func (s *Stream) reorg(ctx context.Context, fn func(*Event) error) error {
+	s.mux.Lock()
+	window := make([]*entry, len(s.window))
+	copy(window, s.window)
+	s.mux.Unlock()
+
+	// fetch the canonical headers at the heights of the window
+	headers := make([]*types.Header, len(window))
+	calls := make([]w3types.RPCCaller, len(window))
+	for i, e := range window {
+		calls[i] = eth.HeaderByNumber(new(big.Int).SetUint64(e.Number)).Returns(&headers[i])
+	}
+	if err := s.client.CallCtx(ctx, calls...); err != nil {
+		// headers above the current head are not found, if the new canonical
+		// chain is shorter
+		var callErrs w3.CallErrors
+		if !errors.As(err, &callErrs) {
+			return err
+		}
+		for _, callErr := range callErrs {
+			if callErr != nil && !errors.Is(callErr, module.ErrNotFound) {
+				return err
+			}
+		}
+	}
+
+	for i := len(window) - 1; i >= 0; i-- {
+		if headers[i] != nil && headers[i].Hash() == window[i].Hash {
+			return nil
+		}
+
+		event, err := s.removedEvent(ctx, window[i])
+		if err != nil {
+			return err
+		}
+		if err := s.remove(event, fn); err != nil {
+			return err
+		}
+	}
+	return ErrReorgTooDeep
+}
+
+// events fetches the receipts and logs of the given blocks and returns an
+// event of the given type for each block.
+func (s *Stream) events(ctx context.Context, typ EventType, blocks []*types.Block) ([]*Event, error) {
+	events := make([]*Event, len(blocks))
+	var calls []w3types.RPCCaller
+	for i, block := range blocks {
+		events[i] = &Event{Type: typ, Block: block}
+
+		hash := block.Hash()
+		if s.opts.withReceipts {
+			calls = append(calls, blockReceiptsByHash(hash).Returns(&events[i].Receipts))
+		}
+		if s.opts.withLogs {
+			calls = append(calls, eth.Logs(ethereum.FilterQuery{
+				BlockHash: &hash,
+				Addresses: s.opts.query.Addresses,
+				Topics:    s.opts.query.Topics,
+			}).Returns(&events[i].Logs))
+		}
+	}
+	if err := s.client.CallCtx(ctx, calls...); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// removedEvent returns the [Removed] event of the given window entry.
+func (s *Stream) removedEvent(ctx context.Context, e *entry) (*Event, error) {
+	var event Event
+	if e.event != nil {
+		event = *e.event
+	} else {
+		// entry was restored from a checkpoint
+		var block *types.Block
+		if err := s.client.CallCtx(ctx, eth.BlockByHash(e.Hash).Returns(&block)); err != nil {
+			return nil, err
+		}
+		events, err := s.events(ctx, Removed, []*types.Block{block})
+		if err != nil {
+			return nil, err
+		}
+		event = *events[0]
+	}
+
+	event.Type = Removed
+	if len(event.Logs) > 0 {
+		logs := make([]types.Log, len(event.Logs))
+		for i, log := range event.Logs {
+			log.Removed = true
+			logs[i] = log
+		}
+		event.Logs = logs
+	}
+	if len(event.Receipts) > 0 {
+		receipts := make(types.Receipts, len(event.Receipts))
+		for i, receipt := range event.Receipts {
+			r := *receipt
+			r.Logs = make([]*types.Log, len(receipt.Logs))
+			for j, log := range receipt.Logs {
+				l := *log
+				l.Removed = true
+				r.Logs[j] = &l
+			}
+			receipts[i] = &r
+		}
+		event.Receipts = receipts
+	}
+	return &event, nil
+}
+
+func (s *Stream) add(event *Event, fn func(*Event) error) error {
+	if err := fn(event); err != nil {
+		return err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.window = append(s.window, &entry{
+		BlockID: BlockID{event.Block.NumberU64(), event.Block.Hash()},
+		event:   event,
+	})
+	s.trim()
+	return nil
+}
+
+func (s *Stream) remove(event *Event, fn func(*Event) error) error {
+	if err := fn(event); err != nil {
+		return err
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	s.window = s.window[:len(s.window)-1]
+	s.next = event.Block.NumberU64()
+	return nil
+}
+
+// trim drops the oldest entries of the window, if it exceeds the window size.
+func (s *Stream) trim() {
+	if n := len(s.window) - s.opts.windowSize; n > 0 {
+		s.window = s.window[n:]
+	}
+}
+
+func (s *Stream) last() *BlockID {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if len(s.window) <= 0 {
+		return nil
+	}
+	id := s.window[len(s.window)-1].BlockID
+	return &id
+}
+
+func (s *Stream) nextNumber() uint64 {
+	if last := s.last(); last != nil {
+		return last.Number + 1
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	return s.next
+}
+
+func (s *Stream) contains(id BlockID) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, e := range s.window {
+		if e.BlockID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// blockReceiptsByHash is like [eth.BlockReceipts], but requests the receipts of
+// the block with the given hash.
+func blockReceiptsByHash(hash common.Hash) w3types.RPCCallerFactory[types.Receipts] {
+	return module.NewFactory[types.Receipts](
+		"eth_getBlockReceipts",
+		[]any{hash},
+	)
+}
+
+////////////////////////////////////////////////////////////////////////////////////////////////////
+// Stream Option ///////////////////////////////////////////////////////////////////////////////////
+////////////////////////////////////////////////////////////////////////////////////////////////////
+
+type options struct {
+	windowSize   int
+	startBlock   *big.Int
+	checkpoint   Checkpoint
+	withReceipts bool
+	withLogs     bool
+	query        ethereum.FilterQuery
+}
+
+// An Option configures a [Stream].
+type Option func(*Stream)
+
+// WithWindow sets the number of recent blocks that are kept to detect chain
+// reorganizations. The default window size is 64 blocks.
+func WithWindow(size int) Option {
+	return func(s *Stream) {
+		if size > 0 {
+			s.opts.windowSize = size
+		}
+	}
+}
+
+// WithStartBlock sets the number of the first block of the stream. If not set,
+// the stream starts at the current head of the chain.
+//
+// WithStartBlock is ignored, if the stream is resumed using [WithCheckpoint].
+func WithStartBlock(number *big.Int) Option {
+	return func(s *Stream) { s.opts.startBlock = number }
+}
+
+// WithCheckpoint resumes the stream from the given checkpoint, that was
+// obtained from [Stream.Checkpoint].
+func WithCheckpoint(cp Checkpoint) Option {
+	return func(s *Stream) { s.opts.checkpoint = cp }
+}
+
+// WithReceipts enables fetching the receipts of each block.
+func WithReceipts() Option {
+	return func(s *Stream) { s.opts.withReceipts = true }
+}
+
+// WithLogs enables fetching the logs of each block that match the given query.
+// The block range and block hash of the query are ignored.
+func WithLogs(q ethereum.FilterQuery) Option {
+	return func(s *Stream) {
+		s.opts.withLogs = true
+		s.opts.query = q
+	}
+}
@@ -0,0 +1,354 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/stream"
+)
+
+func TestStream(t *testing.T) {
+	chain := newFakeChain(t)
+	chain.extend(0, 0, 6) // blocks 0..5
+	defer chain.Close()
+
+	client := w3.MustDial(chain.URL, w3.WithPollInterval(10*time.Millisecond))
+	defer client.Close()
+
+	s := stream.New(client, stream.WithStartBlock(big.NewInt(3)), stream.WithWindow(8))
+	events, stop := run(t, s)
+
+	// initial sync
+	wantEvents(t, events,
+		event{stream.Added, 3, 0},
+		event{stream.Added, 4, 0},
+		event{stream.Added, 5, 0},
+	)
+
+	// reorg: replace blocks 4..5 with 4'..6'
+	chain.extend(4, 1, 3)
+	wantEvents(t, events,
+		event{stream.Removed, 5, 0},
+		event{stream.Removed, 4, 0},
+		event{stream.Added, 4, 1},
+		event{stream.Added, 5, 1},
+		event{stream.Added, 6, 1},
+	)
+	stop()
+
+	cp := s.Checkpoint()
+	wantCp := stream.Checkpoint{
+		{3, chain.block(3, 0).Hash()},
+		{4, chain.block(4, 1).Hash()},
+		{5, chain.block(5, 1).Hash()},
+		{6, chain.block(6, 1).Hash()},
+	}
+	if diff := cmp.Diff(wantCp, cp); diff != "" {
+		t.Fatalf("Checkpoint (-want, +got)\n%s", diff)
+	}
+
+	// resume from checkpoint after block 6' was replaced with 6''
+	chain.extend(6, 2, 1)
+	s = stream.New(client, stream.WithCheckpoint(cp))
+	events, stop = run(t, s)
+	defer stop()
+
+	wantEvents(t, events,
+		event{stream.Removed, 6, 1},
+		event{stream.Added, 6, 2},
+	)
+}
+
+func TestStream_ReorgTooDeep(t *testing.T) {
+	chain := newFakeChain(t)
+	chain.extend(0, 0, 6) // blocks 0..5
+	defer chain.Close()
+
+	client := w3.MustDial(chain.URL, w3.WithPollInterval(10*time.Millisecond))
+	defer client.Close()
+
+	// replace blocks 3..5 with 3'..5', while the checkpoint only knows 4..5
+	cp := stream.Checkpoint{
+		{4, chain.block(4, 0).Hash()},
+		{5, chain.block(5, 0).Hash()},
+	}
+	chain.extend(3, 1, 3)
+
+	s := stream.New(client, stream.WithCheckpoint(cp))
+	err := s.Run(context.Background(), func(*stream.Event) error { return nil })
+	if !errors.Is(err, stream.ErrReorgTooDeep) {
+		t.Fatalf("Want ErrReorgTooDeep, got %v", err)
+	}
+}
+
+func TestStream_RemovedLogs(t *testing.T) {
+	chain := newFakeChain(t)
+	chain.extend(0, 0, 3) // blocks 0..2
+	defer chain.Close()
+
+	client := w3.MustDial(chain.URL, w3.WithPollInterval(10*time.Millisecond))
+	defer client.Close()
+
+	s := stream.New(client,
+		stream.WithStartBlock(big.NewInt(1)),
+		stream.WithReceipts(),
+		stream.WithLogs(ethereum.FilterQuery{}),
+	)
+	events, stop := run(t, s)
+	defer stop()
+
+	wantLogsRemoved(t, events, false, false)
+
+	// reorg: replace block 2 with 2'
+	chain.extend(2, 1, 1)
+	wantLogsRemoved(t, events, true, false)
+}
+
+type event struct {
+	Type   stream.EventType
+	Number uint64
+	Fork   byte
+}
+
+func run(t *testing.T, s *stream.Stream) (<-chan *stream.Event, func()) {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events := make(chan *stream.Event)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		err := s.Run(ctx, func(e *stream.Event) error {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Run failed: %v", err)
+		}
+	}()
+	return events, func() { cancel(); <-done }
+}
+
+// wantLogsRemoved checks the Removed flag of the logs and receipt logs of the
+// next events.
+func wantLogsRemoved(t *testing.T, events <-chan *stream.Event, want ...bool) {
+	t.Helper()
+
+	for i, w := range want {
+		var e *stream.Event
+		select {
+		case e = <-events:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Event %d: timeout", i)
+		}
+		if len(e.Logs) != 1 || e.Logs[0].Removed != w {
+			t.Fatalf("Event %d: want log removed %v, got %+v", i, w, e.Logs)
+		}
+		if len(e.Receipts) != 1 || len(e.Receipts[0].Logs) != 1 || e.Receipts[0].Logs[0].Removed != w {
+			t.Fatalf("Event %d: want receipt log removed %v, got %+v", i, w, e.Receipts)
+		}
+	}
+}
+
+func wantEvents(t *testing.T, events <-chan *stream.Event, want ...event) {
+	t.Helper()
+
+	for i, w := range want {
+		select {
+		case e := <-events:
+			got := event{e.Type, e.Block.NumberU64(), e.Block.Extra()[0]}
+			if got != w {
+				t.Fatalf("Event %d: want %+v, got %+v", i, w, got)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Event %d: timeout", i)
+		}
+	}
+}
+
+// fakeChain is a fake RPC endpoint that serves a chain of blocks.
+type fakeChain struct {
+	*httptest.Server
+	t *testing.T
+
+	mux       sync.Mutex
+	canonical []*types.Block
+	blocks    map[common.Hash]*types.Block
+	lastHead  common.Hash
+}
+
+func newFakeChain(t *testing.T) *fakeChain {
+	c := &fakeChain{t: t, blocks: make(map[common.Hash]*types.Block)}
+	c.Server = httptest.NewServer(c)
+	return c
+}
+
+// block returns the block with the given number of the given fork.
+func (c *fakeChain) block(number uint64, fork byte) *types.Block {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	for _, block := range c.blocks {
+		if block.NumberU64() == number && block.Extra()[0] == fork {
+			return block
+		}
+	}
+	c.t.Fatalf("Block %d of fork %d not found", number, fork)
+	return nil
+}
+
+// extend replaces the canonical chain from the given block number with n
+// blocks of the given fork.
+func (c *fakeChain) extend(from uint64, fork byte, n int) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.canonical = c.canonical[:from]
+	for i := range n {
+		header := &types.Header{
+			Number:     new(big.Int).SetUint64(from + uint64(i)),
+			Difficulty: new(big.Int),
+			Extra:      []byte{fork},
+		}
+		if len(c.canonical) > 0 {
+			header.ParentHash = c.canonical[len(c.canonical)-1].Hash()
+		}
+		block := types.NewBlockWithHeader(header)
+		c.canonical = append(c.canonical, block)
+		c.blocks[block.Hash()] = block
+	}
+}
+
+func (c *fakeChain) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		c.t.Errorf("Failed to read body: %v", err)
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if body[0] == '[' {
+		var reqs []*request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			c.t.Errorf("Failed to decode requests: %v", err)
+			return
+		}
+		resps := make([]*response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = c.handle(req)
+		}
+		json.NewEncoder(w).Encode(resps)
+	} else {
+		var req *request
+		if err := json.Unmarshal(body, &req); err != nil {
+			c.t.Errorf("Failed to decode request: %v", err)
+			return
+		}
+		json.NewEncoder(w).Encode(c.handle(req))
+	}
+}
+
+func (c *fakeChain) handle(req *request) *response {
+	resp := &response{Version: "2.0", ID: req.ID}
+	switch req.Method {
+	case "eth_newBlockFilter":
+		resp.Result = "0x1"
+	case "eth_uninstallFilter":
+		resp.Result = true
+	case "eth_getFilterChanges":
+		hashes := []common.Hash{}
+		if head := c.canonical[len(c.canonical)-1].Hash(); head != c.lastHead {
+			hashes = append(hashes, head)
+			c.lastHead = head
+		}
+		resp.Result = hashes
+	case "eth_getBlockByNumber":
+		var number string
+		json.Unmarshal(req.Params[0], &number)
+		var block *types.Block
+		if number == "latest" {
+			block = c.canonical[len(c.canonical)-1]
+		} else if n, _ := strconv.ParseUint(number[2:], 16, 64); n < uint64(len(c.canonical)) {
+			block = c.canonical[n]
+		}
+		resp.Result = rpcBlock(block)
+	case "eth_getBlockByHash":
+		var hash common.Hash
+		json.Unmarshal(req.Params[0], &hash)
+		resp.Result = rpcBlock(c.blocks[hash])
+	case "eth_getBlockReceipts":
+		var hash common.Hash
+		json.Unmarshal(req.Params[0], &hash)
+		resp.Result = types.Receipts{{
+			Status:    types.ReceiptStatusSuccessful,
+			Logs:      []*types.Log{blockLog(hash)},
+			BlockHash: hash,
+		}}
+	case "eth_getLogs":
+		var query struct {
+			BlockHash common.Hash `json:"blockHash"`
+		}
+		json.Unmarshal(req.Params[0], &query)
+		resp.Result = []*types.Log{blockLog(query.BlockHash)}
+	default:
+		resp.Error = &rpcError{Code: -32601, Message: "method not found"}
+	}
+	return resp
+}
+
+func rpcBlock(block *types.Block) any {
+	if block == nil {
+		return nil
+	}
+
+	fields := make(map[string]any)
+	data, _ := json.Marshal(block.Header())
+	json.Unmarshal(data, &fields)
+	fields["hash"] = block.Hash()
+	fields["transactions"] = []any{}
+	return fields
+}
+
+// blockLog returns the single log of the block with the given hash.
+func blockLog(hash common.Hash) *types.Log {
+	return &types.Log{BlockHash: hash, Topics: []common.Hash{}, Data: []byte{}}
+}
+
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
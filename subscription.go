@@ -0,0 +1,126 @@
+package w3
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/lmittmann/w3/w3types"
+)
+
+const defaultPollInterval = 4 * time.Second
+
+// pollSubscription emulates a subscription by polling a filter.
+//
+// pollSubscription implements the [ethereum.Subscription] interface.
+type pollSubscription struct {
+	client *Client
+	s      w3types.RPCPollSubscriber
+	id     rpc.ID
+
+	cancel    context.CancelFunc
+	done      chan struct{}
+	errCh     chan error
+	unsubOnce sync.Once
+}
+
+func (c *Client) pollSubscribe(ctx context.Context, s w3types.RPCPollSubscriber) (ethereum.Subscription, error) {
+	var id rpc.ID
+	if err := c.CallCtx(ctx, s.NewFilter(&id)); err != nil {
+		return nil, err
+	}
+
+	pollCtx, cancel := context.WithCancel(context.Background())
+	sub := &pollSubscription{
+		client: c,
+		s:      s,
+		id:     id,
+		cancel: cancel,
+		done:   make(chan struct{}),
+		errCh:  make(chan error, 1),
+	}
+	go sub.loop(pollCtx)
+	return sub, nil
+}
+
+// Unsubscribe stops polling and uninstalls the filter.
+func (sub *pollSubscription) Unsubscribe() {
+	sub.unsubOnce.Do(func() {
+		sub.cancel()
+		<-sub.done
+	})
+}
+
+// Err returns the subscription error channel. The error channel receives a
+// value if polling the filter fails. It is closed when Unsubscribe is called.
+func (sub *pollSubscription) Err() <-chan error {
+	return sub.errCh
+}
+
+func (sub *pollSubscription) loop(ctx context.Context) {
+	defer close(sub.done)
+	defer close(sub.errCh)
+
+	timer := time.NewTimer(sub.client.pollInterval)
+	defer timer.Stop()
+
+	var err error
+loop:
+	for {
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-timer.C:
+			if err = sub.poll(ctx); err != nil {
+				break loop
+			}
+			timer.Reset(sub.client.pollInterval)
+		}
+	}
+
+	// uninstall filter
+	sub.client.Call(sub.s.UninstallFilter(sub.id))
+
+	if err != nil && ctx.Err() == nil {
+		sub.errCh <- err
+	}
+}
+
+func (sub *pollSubscription) poll(ctx context.Context) error {
+	err := sub.s.Poll(ctx, sub.id, sub.client.CallCtx)
+	if err == nil || ctx.Err() != nil || !isFilterNotFound(err) {
+		return err
+	}
+
+	// filters expire if they are not polled for some time or get lost (e.g.
+	// after a node restart), so reinstall the filter
+	if reinstallErr := sub.client.CallCtx(ctx, sub.s.NewFilter(&sub.id)); reinstallErr != nil {
+		return err
+	}
+	return nil
+}
+
+// isFilterNotFound returns true if err contains an RPC error of the node, that
+// reports that the polled filter does not exist.
+func isFilterNotFound(err error) bool {
+	var callErrs CallErrors
+	if !errors.As(err, &callErrs) {
+		return false
+	}
+	for _, callErr := range callErrs {
+		var rpcErr rpc.Error
+		if !errors.As(callErr, &rpcErr) {
+			continue
+		}
+		msg := strings.ToLower(rpcErr.Error())
+		if strings.Contains(msg, "filter") &&
+			(strings.Contains(msg, "not found") || strings.Contains(msg, "does not exist")) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,22 @@
+package tokens
+
+import "github.com/lmittmann/w3"
+
+// ERC-1155 functions.
+var (
+	ERC1155URI                   = w3.MustNewFunc("uri(uint256 id)", "string")
+	ERC1155BalanceOf             = w3.MustNewFunc("balanceOf(address account, uint256 id)", "uint256")
+	ERC1155BalanceOfBatch        = w3.MustNewFunc("balanceOfBatch(address[] accounts, uint256[] ids)", "uint256[]")
+	ERC1155IsApprovedForAll      = w3.MustNewFunc("isApprovedForAll(address account, address operator)", "bool")
+	ERC1155SetApprovalForAll     = w3.MustNewFunc("setApprovalForAll(address operator, bool approved)", "")
+	ERC1155SafeTransferFrom      = w3.MustNewFunc("safeTransferFrom(address from, address to, uint256 id, uint256 value, bytes data)", "")
+	ERC1155SafeBatchTransferFrom = w3.MustNewFunc("safeBatchTransferFrom(address from, address to, uint256[] ids, uint256[] values, bytes data)", "")
+)
+
+// ERC-1155 events.
+var (
+	ERC1155TransferSingleEvent = w3.MustNewEvent("TransferSingle(address indexed operator, address indexed from, address indexed to, uint256 id, uint256 value)")
+	ERC1155TransferBatchEvent  = w3.MustNewEvent("TransferBatch(address indexed operator, address indexed from, address indexed to, uint256[] ids, uint256[] values)")
+	ERC1155ApprovalForAllEvent = w3.MustNewEvent("ApprovalForAll(address indexed account, address indexed operator, bool approved)")
+	ERC1155URIEvent            = w3.MustNewEvent("URI(string value, uint256 indexed id)")
+)
@@ -0,0 +1,33 @@
+/*
+Package tokens implements ABI bindings and helpers for the ERC-20, ERC-721,
+ERC-1155 and ERC-4626 token standards.
+*/
+package tokens
+
+import "github.com/lmittmann/w3"
+
+// ERC-20 functions.
+var (
+	ERC20Name         = w3.MustNewFunc("name()", "string")
+	ERC20Symbol       = w3.MustNewFunc("symbol()", "string")
+	ERC20Decimals     = w3.MustNewFunc("decimals()", "uint8")
+	ERC20TotalSupply  = w3.MustNewFunc("totalSupply()", "uint256")
+	ERC20BalanceOf    = w3.MustNewFunc("balanceOf(address account)", "uint256")
+	ERC20Allowance    = w3.MustNewFunc("allowance(address owner, address spender)", "uint256")
+	ERC20Transfer     = w3.MustNewFunc("transfer(address to, uint256 value)", "bool")
+	ERC20TransferFrom = w3.MustNewFunc("transferFrom(address from, address to, uint256 value)", "bool")
+	ERC20Approve      = w3.MustNewFunc("approve(address spender, uint256 value)", "bool")
+)
+
+// ERC-20 permit extension functions (EIP-2612).
+var (
+	ERC20Permit          = w3.MustNewFunc("permit(address owner, address spender, uint256 value, uint256 deadline, uint8 v, bytes32 r, bytes32 s)", "")
+	ERC20Nonces          = w3.MustNewFunc("nonces(address owner)", "uint256")
+	ERC20DomainSeparator = w3.MustNewFunc("DOMAIN_SEPARATOR()", "bytes32")
+)
+
+// ERC-20 events.
+var (
+	ERC20TransferEvent = w3.MustNewEvent("Transfer(address indexed from, address indexed to, uint256 value)")
+	ERC20ApprovalEvent = w3.MustNewEvent("Approval(address indexed owner, address indexed spender, uint256 value)")
+)
@@ -0,0 +1,30 @@
+package tokens
+
+import "github.com/lmittmann/w3"
+
+// ERC-4626 functions. ERC-4626 vaults are ERC-20 tokens of their shares, whose
+// ERC-20 functions can be used as well.
+var (
+	ERC4626Asset           = w3.MustNewFunc("asset()", "address")
+	ERC4626TotalAssets     = w3.MustNewFunc("totalAssets()", "uint256")
+	ERC4626ConvertToShares = w3.MustNewFunc("convertToShares(uint256 assets)", "uint256")
+	ERC4626ConvertToAssets = w3.MustNewFunc("convertToAssets(uint256 shares)", "uint256")
+	ERC4626MaxDeposit      = w3.MustNewFunc("maxDeposit(address receiver)", "uint256")
+	ERC4626PreviewDeposit  = w3.MustNewFunc("previewDeposit(uint256 assets)", "uint256")
+	ERC4626Deposit         = w3.MustNewFunc("deposit(uint256 assets, address receiver)", "uint256")
+	ERC4626MaxMint         = w3.MustNewFunc("maxMint(address receiver)", "uint256")
+	ERC4626PreviewMint     = w3.MustNewFunc("previewMint(uint256 shares)", "uint256")
+	ERC4626Mint            = w3.MustNewFunc("mint(uint256 shares, address receiver)", "uint256")
+	ERC4626MaxWithdraw     = w3.MustNewFunc("maxWithdraw(address owner)", "uint256")
+	ERC4626PreviewWithdraw = w3.MustNewFunc("previewWithdraw(uint256 assets)", "uint256")
+	ERC4626Withdraw        = w3.MustNewFunc("withdraw(uint256 assets, address receiver, address owner)", "uint256")
+	ERC4626MaxRedeem       = w3.MustNewFunc("maxRedeem(address owner)", "uint256")
+	ERC4626PreviewRedeem   = w3.MustNewFunc("previewRedeem(uint256 shares)", "uint256")
+	ERC4626Redeem          = w3.MustNewFunc("redeem(uint256 shares, address receiver, address owner)", "uint256")
+)
+
+// ERC-4626 events.
+var (
+	ERC4626DepositEvent  = w3.MustNewEvent("Deposit(address indexed sender, address indexed owner, uint256 assets, uint256 shares)")
+	ERC4626WithdrawEvent = w3.MustNewEvent("Withdraw(address indexed sender, address indexed receiver, address indexed owner, uint256 assets, uint256 shares)")
+)
@@ -0,0 +1,26 @@
+package tokens
+
+import "github.com/lmittmann/w3"
+
+// ERC-721 functions.
+var (
+	ERC721Name                     = w3.MustNewFunc("name()", "string")
+	ERC721Symbol                   = w3.MustNewFunc("symbol()", "string")
+	ERC721TokenURI                 = w3.MustNewFunc("tokenURI(uint256 tokenId)", "string")
+	ERC721BalanceOf                = w3.MustNewFunc("balanceOf(address owner)", "uint256")
+	ERC721OwnerOf                  = w3.MustNewFunc("ownerOf(uint256 tokenId)", "address")
+	ERC721GetApproved              = w3.MustNewFunc("getApproved(uint256 tokenId)", "address")
+	ERC721IsApprovedForAll         = w3.MustNewFunc("isApprovedForAll(address owner, address operator)", "bool")
+	ERC721Approve                  = w3.MustNewFunc("approve(address to, uint256 tokenId)", "")
+	ERC721SetApprovalForAll        = w3.MustNewFunc("setApprovalForAll(address operator, bool approved)", "")
+	ERC721TransferFrom             = w3.MustNewFunc("transferFrom(address from, address to, uint256 tokenId)", "")
+	ERC721SafeTransferFrom         = w3.MustNewFunc("safeTransferFrom(address from, address to, uint256 tokenId)", "")
+	ERC721SafeTransferFromWithData = w3.MustNewFunc("safeTransferFrom(address from, address to, uint256 tokenId, bytes data)", "")
+)
+
+// ERC-721 events.
+var (
+	ERC721TransferEvent       = w3.MustNewEvent("Transfer(address indexed from, address indexed to, uint256 indexed tokenId)")
+	ERC721ApprovalEvent       = w3.MustNewEvent("Approval(address indexed owner, address indexed approved, uint256 indexed tokenId)")
+	ERC721ApprovalForAllEvent = w3.MustNewEvent("ApprovalForAll(address indexed owner, address indexed operator, bool approved)")
+)
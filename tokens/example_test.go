@@ -0,0 +1,53 @@
+package tokens_test
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/tokens"
+)
+
+// Request the metadata of multiple tokens in a single batch request.
+func ExampleMetadataCalls() {
+	client := w3.MustDial("https://ethereum-rpc.publicnode.com")
+	defer client.Close()
+
+	var dai, mkr tokens.Metadata
+	if err := client.Call(append(
+		tokens.MetadataCalls(w3.A("0x6B175474E89094C44Da98b954EedeAC495271d0F"), &dai),
+		tokens.MetadataCalls(w3.A("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2"), &mkr)...,
+	)...); err != nil {
+		// ...
+		return
+	}
+	fmt.Printf("%s: %s\n", dai.Name, dai.FormatAmount(dai.TotalSupply))
+	fmt.Printf("%s: %s\n", mkr.Name, mkr.FormatAmount(mkr.TotalSupply))
+}
+
+// Decode an ERC-20 Transfer log.
+func Example_transferEvent() {
+	log := &types.Log{
+		Topics: []common.Hash{
+			tokens.ERC20TransferEvent.Topic0,
+			w3.H("0x000000000000000000000000000000000000000000000000000000000000c0fe"),
+			w3.H("0x000000000000000000000000000000000000000000000000000000000000dead"),
+		},
+		Data: w3.B("0x00000000000000000000000000000000000000000000000014d1120d7b160000"),
+	}
+
+	var (
+		from, to common.Address
+		value    big.Int
+	)
+	if err := tokens.ERC20TransferEvent.DecodeArgs(log, &from, &to, &value); err != nil {
+		// ...
+		return
+	}
+	md := &tokens.Metadata{Symbol: "DAI", Decimals: 18}
+	fmt.Printf("%s -> %s: %s\n", from, to, md.FormatAmount(&value))
+	// Output:
+	// 0x000000000000000000000000000000000000c0Fe -> 0x000000000000000000000000000000000000dEaD: 1.5 DAI
+}
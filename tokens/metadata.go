@@ -0,0 +1,123 @@
+package tokens
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/internal/module"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Metadata is the metadata of an ERC-20 token.
+type Metadata struct {
+	Name        string
+	Symbol      string
+	Decimals    uint8
+	TotalSupply *big.Int
+}
+
+// MetadataCalls returns the calls that request the name, symbol, decimals and
+// total supply of the given token and store them in md. The calls can be
+// batched with other calls.
+//
+// Example:
+//
+//	var dai, mkr tokens.Metadata
+//	err := client.Call(append(
+//		tokens.MetadataCalls(addrDAI, &dai),
+//		tokens.MetadataCalls(addrMKR, &mkr)...,
+//	)...)
+func MetadataCalls(token common.Address, md *Metadata) []w3types.RPCCaller {
+	return []w3types.RPCCaller{
+		Name(token).Returns(&md.Name),
+		Symbol(token).Returns(&md.Symbol),
+		Decimals(token).Returns(&md.Decimals),
+		TotalSupply(token).Returns(&md.TotalSupply),
+	}
+}
+
+// FormatAmount returns the given amount of the token as decimal followed by the
+// tokens symbol, e.g. "1.5 DAI".
+func (md *Metadata) FormatAmount(amount *big.Int) string {
+	s := w3.FromWei(amount, md.Decimals)
+	if md.Symbol == "" {
+		return s
+	}
+	return s + " " + md.Symbol
+}
+
+// Name requests the name of the given token. The name of non-compliant tokens
+// that return bytes32 instead of string, such as MKR, is decoded as well.
+func Name(token common.Address) w3types.RPCCallerFactory[string] {
+	return newStringCall(token, ERC20Name)
+}
+
+// Symbol requests the symbol of the given token. The symbol of non-compliant
+// tokens that return bytes32 instead of string, such as MKR, is decoded as
+// well.
+func Symbol(token common.Address) w3types.RPCCallerFactory[string] {
+	return newStringCall(token, ERC20Symbol)
+}
+
+// Decimals requests the decimals of the given token.
+func Decimals(token common.Address) w3types.RPCCallerFactory[uint8] {
+	return newCall[uint8](token, ERC20Decimals)
+}
+
+// TotalSupply requests the total supply of the given token.
+func TotalSupply(token common.Address) w3types.RPCCallerFactory[*big.Int] {
+	return newCall[*big.Int](token, ERC20TotalSupply)
+}
+
+func newCall[T any](token common.Address, fn *w3.Func) w3types.RPCCallerFactory[T] {
+	return module.NewFactory(
+		"eth_call",
+		[]any{&w3types.Message{To: &token, Input: fn.Selector[:]}, module.BlockNumberArg(nil)},
+		module.WithRetWrapper(func(ret *T) any { return &returns[T]{fn, ret} }),
+	)
+}
+
+func newStringCall(token common.Address, fn *w3.Func) w3types.RPCCallerFactory[string] {
+	return module.NewFactory(
+		"eth_call",
+		[]any{&w3types.Message{To: &token, Input: fn.Selector[:]}, module.BlockNumberArg(nil)},
+		module.WithRetWrapper(func(ret *string) any { return &stringReturns{fn, ret} }),
+	)
+}
+
+// returns decodes the output of a call of fn to ret.
+type returns[T any] struct {
+	fn  *w3.Func
+	ret *T
+}
+
+func (r *returns[T]) UnmarshalJSON(data []byte) error {
+	var output hexutil.Bytes
+	if err := json.Unmarshal(data, &output); err != nil {
+		return err
+	}
+	return r.fn.DecodeReturns(output, r.ret)
+}
+
+// stringReturns decodes the string output of a call of fn to ret. An output
+// of exactly 32 bytes is decoded as null-padded bytes32.
+type stringReturns struct {
+	fn  *w3.Func
+	ret *string
+}
+
+func (r *stringReturns) UnmarshalJSON(data []byte) error {
+	var output hexutil.Bytes
+	if err := json.Unmarshal(data, &output); err != nil {
+		return err
+	}
+	if len(output) == 32 {
+		*r.ret = string(bytes.TrimRight(output, "\x00"))
+		return nil
+	}
+	return r.fn.DecodeReturns(output, r.ret)
+}
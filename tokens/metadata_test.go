@@ -0,0 +1,81 @@
+package tokens_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/rpctest"
+	"github.com/lmittmann/w3/tokens"
+)
+
+var (
+	addrDAI = w3.A("0x6B175474E89094C44Da98b954EedeAC495271d0F")
+	addrMKR = w3.A("0x9f8F72aA9304c8B593d555F12eF6589cC3A579A2")
+)
+
+func TestName(t *testing.T) {
+	rpctest.RunTestCases(t, []rpctest.TestCase[string]{
+		{
+			Golden:  "name",
+			Call:    tokens.Name(addrDAI),
+			WantRet: "Dai Stablecoin",
+		},
+	})
+}
+
+func TestMetadataCalls(t *testing.T) {
+	srv := rpctest.NewFileServer(t, "testdata/metadata.golden")
+	defer srv.Close()
+
+	client := w3.MustDial(srv.URL())
+	defer client.Close()
+
+	var md tokens.Metadata
+	if err := client.Call(tokens.MetadataCalls(addrMKR, &md)...); err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+
+	want := tokens.Metadata{
+		Name:        "Maker",
+		Symbol:      "MKR",
+		Decimals:    18,
+		TotalSupply: w3.I("977631 ether"),
+	}
+	if diff := cmp.Diff(want, md, cmp.AllowUnexported(big.Int{})); diff != "" {
+		t.Fatalf("(-want, +got)\n%s", diff)
+	}
+}
+
+func TestMetadataFormatAmount(t *testing.T) {
+	tests := []struct {
+		Metadata *tokens.Metadata
+		Amount   *big.Int
+		Want     string
+	}{
+		{
+			Metadata: &tokens.Metadata{Symbol: "DAI", Decimals: 18},
+			Amount:   w3.I("1.5 ether"),
+			Want:     "1.5 DAI",
+		},
+		{
+			Metadata: &tokens.Metadata{Symbol: "USDC", Decimals: 6},
+			Amount:   big.NewInt(1_000_001),
+			Want:     "1.000001 USDC",
+		},
+		{
+			Metadata: &tokens.Metadata{Decimals: 2},
+			Amount:   big.NewInt(-150),
+			Want:     "-1.5",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Want, func(t *testing.T) {
+			if got := test.Metadata.FormatAmount(test.Amount); test.Want != got {
+				t.Fatalf("want %q, got %q", test.Want, got)
+			}
+		})
+	}
+}
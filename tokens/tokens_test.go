@@ -0,0 +1,55 @@
+package tokens_test
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/tokens"
+)
+
+func TestSelectors(t *testing.T) {
+	tests := []struct {
+		Func *w3.Func
+		Want string
+	}{
+		{Func: tokens.ERC20Transfer, Want: "0xa9059cbb"},
+		{Func: tokens.ERC20Approve, Want: "0x095ea7b3"},
+		{Func: tokens.ERC20Permit, Want: "0xd505accf"},
+		{Func: tokens.ERC721SafeTransferFrom, Want: "0x42842e0e"},
+		{Func: tokens.ERC721SafeTransferFromWithData, Want: "0xb88d4fde"},
+		{Func: tokens.ERC1155SafeTransferFrom, Want: "0xf242432a"},
+		{Func: tokens.ERC1155SafeBatchTransferFrom, Want: "0x2eb2c2d6"},
+		{Func: tokens.ERC4626Deposit, Want: "0x6e553f65"},
+		{Func: tokens.ERC4626Redeem, Want: "0xba087652"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Func.Signature, func(t *testing.T) {
+			if got := w3.B(test.Want); string(got) != string(test.Func.Selector[:]) {
+				t.Fatalf("want %s, got 0x%x", test.Want, test.Func.Selector)
+			}
+		})
+	}
+}
+
+func TestTopics(t *testing.T) {
+	tests := []struct {
+		Event *w3.Event
+		Want  common.Hash
+	}{
+		{Event: tokens.ERC20TransferEvent, Want: w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+		{Event: tokens.ERC721TransferEvent, Want: w3.H("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")},
+		{Event: tokens.ERC20ApprovalEvent, Want: w3.H("0x8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925")},
+		{Event: tokens.ERC1155TransferSingleEvent, Want: w3.H("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")},
+		{Event: tokens.ERC1155TransferBatchEvent, Want: w3.H("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Event.Signature, func(t *testing.T) {
+			if test.Want != test.Event.Topic0 {
+				t.Fatalf("want %s, got %s", test.Want, test.Event.Topic0)
+			}
+		})
+	}
+}
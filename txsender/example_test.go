@@ -0,0 +1,36 @@
+package txsender_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/txsender"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// Send 1 ETH and wait for 3 confirmations.
+func ExampleSender() {
+	client := w3.MustDial("https://ethereum-rpc.publicnode.com")
+	defer client.Close()
+
+	signer, err := w3.NewKeystoreSigner("path/to/keystore.json", "passphrase")
+	if err != nil {
+		// ...
+	}
+
+	sender := txsender.New(client, signer, txsender.WithConfirmations(3))
+	pending, err := sender.Send(context.Background(), &w3types.Message{
+		To:    w3.APtr("0x000000000000000000000000000000000000c0Fe"),
+		Value: w3.I("1 ether"),
+	})
+	if err != nil {
+		// ...
+	}
+
+	receipt, err := pending.Wait(context.Background())
+	if err != nil {
+		// ...
+	}
+	fmt.Printf("Transaction %s included in block %v\n", receipt.TxHash, receipt.BlockNumber)
+}
@@ -0,0 +1,60 @@
+package txsender
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+)
+
+var pendingBlockNumber = big.NewInt(-1)
+
+// NonceManager hands out the nonces of one or more accounts. It is safe for
+// concurrent use.
+//
+// The nonce of an account is fetched from the pending state of the node on its
+// first use, or after it was reset, and is incremented locally afterwards.
+type NonceManager struct {
+	client *w3.Client
+
+	mux    sync.Mutex
+	nonces map[common.Address]uint64
+}
+
+// NewNonceManager returns a new NonceManager that fetches nonces using the
+// given client.
+func NewNonceManager(client *w3.Client) *NonceManager {
+	return &NonceManager{
+		client: client,
+		nonces: make(map[common.Address]uint64),
+	}
+}
+
+// Next returns the next nonce of the given account and increments it.
+func (m *NonceManager) Next(ctx context.Context, addr common.Address) (uint64, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	nonce, ok := m.nonces[addr]
+	if !ok {
+		if err := m.client.CallCtx(ctx,
+			eth.Nonce(addr, pendingBlockNumber).Returns(&nonce),
+		); err != nil {
+			return 0, err
+		}
+	}
+	m.nonces[addr] = nonce + 1
+	return nonce, nil
+}
+
+// Reset discards the local nonce of the given account. The nonce is fetched
+// again on the next call of [NonceManager.Next].
+func (m *NonceManager) Reset(addr common.Address) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	delete(m.nonces, addr)
+}
@@ -0,0 +1,237 @@
+package txsender
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+// PendingTx is a transaction that was sent by a [Sender]. If the transaction
+// gets stuck, it may be replaced by transactions with the same nonce and
+// bumped fees while waiting for its confirmation.
+type PendingTx struct {
+	sender  *Sender
+	chainID *big.Int
+
+	mux sync.Mutex
+	txs []*types.Transaction // sent transactions, from oldest to newest
+
+	underpriced uint64 // number of consecutive underpriced replacements
+}
+
+// Hash returns the hash of the most recent transaction.
+func (p *PendingTx) Hash() common.Hash {
+	return p.Tx().Hash()
+}
+
+// Tx returns the most recent transaction.
+func (p *PendingTx) Tx() *types.Transaction {
+	p.mux.Lock()
+	defer p.mux.Unlock()
+
+	return p.txs[len(p.txs)-1]
+}
+
+// Wait waits until one of the sent transactions is confirmed and returns its
+// receipt. The receipt is fetched using [eth.TxReceipt].
+//
+// While waiting, a transaction that is unknown to the node is rebroadcast and a
+// stuck transaction is replaced by a transaction with bumped fees. If the node
+// rejects a replacement as underpriced, the fees are bumped further on the next
+// replacement. If the transaction was dropped, Wait returns an error that wraps
+// [ErrDropped].
+func (p *PendingTx) Wait(ctx context.Context) (*types.Receipt, error) {
+	ticker := time.NewTicker(p.sender.opts.pollInterval)
+	defer ticker.Stop()
+
+	replaceAt := time.Now().Add(p.sender.opts.replaceAfter)
+	for {
+		receipt, included, err := p.poll(ctx)
+		if err != nil {
+			return nil, err
+		} else if receipt != nil {
+			return receipt, nil
+		}
+
+		if now := time.Now(); !included && p.sender.opts.replaceAfter > 0 && now.After(replaceAt) {
+			if err := p.replace(ctx); err != nil {
+				return nil, err
+			}
+			replaceAt = now.Add(p.sender.opts.replaceAfter)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches the receipts of all sent transactions and returns the receipt
+// of the included transaction, if it has enough confirmations. included
+// reports whether one of the transactions was included in a block.
+func (p *PendingTx) poll(ctx context.Context) (receipt *types.Receipt, included bool, err error) {
+	p.mux.Lock()
+	txs := p.txs
+	p.mux.Unlock()
+	last := txs[len(txs)-1]
+
+	var (
+		blockNumber *big.Int
+		nonce       uint64
+		tx          *types.Transaction
+		receipts    = make([]*types.Receipt, len(txs))
+		calls       = make([]w3types.RPCCaller, 0, 3+len(txs))
+	)
+	calls = append(calls,
+		eth.BlockNumber().Returns(&blockNumber),
+		eth.Nonce(p.sender.signer.Address(), nil).Returns(&nonce),
+		eth.Tx(last.Hash()).Returns(&tx),
+	)
+	for i, tx := range txs {
+		calls = append(calls, eth.TxReceipt(tx.Hash()).Returns(&receipts[i]))
+	}
+
+	if err := p.sender.client.CallCtx(ctx, calls...); err != nil {
+		// transactions and receipts that are not found are expected
+		var callErrs w3.CallErrors
+		if !errors.As(err, &callErrs) {
+			return nil, false, err
+		} else if callErrs[0] != nil || callErrs[1] != nil {
+			return nil, false, err
+		}
+	}
+
+	for _, r := range receipts {
+		if r == nil {
+			continue
+		}
+		head, number := blockNumber.Uint64(), r.BlockNumber.Uint64()
+		if head >= number && head-number+1 >= p.sender.opts.confirmations {
+			return r, true, nil
+		}
+		return nil, true, nil
+	}
+
+	// the nonce was used by an unknown transaction
+	if nonce > last.Nonce() {
+		return nil, false, ErrDropped
+	}
+
+	// rebroadcast the transaction if the node does not know it
+	if tx == nil {
+		err := p.sender.client.CallCtx(ctx, eth.SendTx(last).Returns(new(common.Hash)))
+		if err != nil && !isKnown(err) && !isNonceTooLow(err) {
+			return nil, false, fmt.Errorf("%w: %w", ErrDropped, err)
+		}
+	}
+	return nil, false, nil
+}
+
+// replace replaces the most recent transaction by a transaction with bumped
+// fees. The fees are bumped by the fee bump times the number of attempts since
+// the last accepted replacement. Only legacy, access list and dynamic fee
+// transactions are replaced, transactions of other types are kept.
+func (p *PendingTx) replace(ctx context.Context) error {
+	last := p.Tx()
+	bump := p.sender.opts.feeBump * (p.underpriced + 1)
+
+	var txData types.TxData
+	switch last.Type() {
+	case types.LegacyTxType:
+		txData = &types.LegacyTx{
+			Nonce:    last.Nonce(),
+			GasPrice: bumpFee(last.GasPrice(), bump),
+			Gas:      last.Gas(),
+			To:       last.To(),
+			Value:    last.Value(),
+			Data:     last.Data(),
+		}
+	case types.AccessListTxType:
+		txData = &types.AccessListTx{
+			ChainID:    p.chainID,
+			Nonce:      last.Nonce(),
+			GasPrice:   bumpFee(last.GasPrice(), bump),
+			Gas:        last.Gas(),
+			To:         last.To(),
+			Value:      last.Value(),
+			Data:       last.Data(),
+			AccessList: last.AccessList(),
+		}
+	case types.DynamicFeeTxType:
+		txData = &types.DynamicFeeTx{
+			ChainID:    p.chainID,
+			Nonce:      last.Nonce(),
+			GasTipCap:  bumpFee(last.GasTipCap(), bump),
+			GasFeeCap:  bumpFee(last.GasFeeCap(), bump),
+			Gas:        last.Gas(),
+			To:         last.To(),
+			Value:      last.Value(),
+			Data:       last.Data(),
+			AccessList: last.AccessList(),
+		}
+	default:
+		return nil
+	}
+
+	tx, err := p.sender.signer.SignTx(types.NewTx(txData), p.chainID)
+	if err != nil {
+		return err
+	}
+	if err := p.sender.client.CallCtx(ctx, eth.SendTx(tx).Returns(new(common.Hash))); err != nil {
+		switch {
+		case isUnderpriced(err):
+			// bump the fees further on the next replacement
+			p.underpriced++
+			return nil
+		case isNonceTooLow(err):
+			// the nonce was used in the meantime, poll reports whether it was
+			// used by one of the sent transactions
+			return nil
+		case !isKnown(err):
+			return err
+		}
+	}
+	p.underpriced = 0
+
+	p.mux.Lock()
+	p.txs = append(p.txs, tx)
+	p.mux.Unlock()
+	return nil
+}
+
+// bumpFee returns fee increased by the given percentage, rounded up.
+func bumpFee(fee *big.Int, percent uint64) *big.Int {
+	bumped := new(big.Int).Mul(fee, new(big.Int).SetUint64(100+percent))
+	bumped.Add(bumped, big.NewInt(99))
+	return bumped.Div(bumped, big.NewInt(100))
+}
+
+// isKnown returns true if err reports that the node already knows the
+// transaction.
+func isKnown(err error) bool {
+	return strings.Contains(err.Error(), "already known")
+}
+
+// isNonceTooLow returns true if err reports that the nonce of the transaction
+// was already used.
+func isNonceTooLow(err error) bool {
+	return strings.Contains(err.Error(), "nonce too low")
+}
+
+// isUnderpriced returns true if err reports that the fees of a replacement
+// transaction are too low.
+func isUnderpriced(err error) bool {
+	return strings.Contains(err.Error(), "underpriced")
+}
@@ -0,0 +1,188 @@
+/*
+Package txsender implements sending transactions with nonce management, fee
+estimation and confirmation tracking.
+*/
+package txsender
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/eth"
+	"github.com/lmittmann/w3/w3types"
+)
+
+const (
+	defaultConfirmations = 1
+	defaultPollInterval  = 4 * time.Second
+	defaultReplaceAfter  = time.Minute
+	defaultFeeBump       = 10
+)
+
+// ErrDropped is returned by [PendingTx.Wait] if the transaction was dropped,
+// i.e. its nonce was used by another transaction, or the node refused to
+// rebroadcast it.
+var ErrDropped = errors.New("txsender: transaction dropped")
+
+// Signer signs the transactions of a single account. It is implemented by
+// every [w3.Signer].
+type Signer interface {
+	// Address returns the address of the account.
+	Address() common.Address
+
+	// SignTx signs the given transaction for the given chain ID.
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// Sender signs and sends transactions of the account of its [Signer] and
+// tracks their confirmation. Sender is safe for concurrent use.
+type Sender struct {
+	client *w3.Client
+	signer Signer
+	opts   *options
+
+	mux     sync.Mutex
+	chainID *big.Int
+}
+
+// New returns a new Sender that sends transactions using the given client and
+// signer.
+func New(client *w3.Client, signer Signer, opts ...Option) *Sender {
+	s := &Sender{
+		client: client,
+		signer: signer,
+		opts: &options{
+			confirmations: defaultConfirmations,
+			pollInterval:  defaultPollInterval,
+			replaceAfter:  defaultReplaceAfter,
+			feeBump:       defaultFeeBump,
+		},
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(s)
+	}
+	if s.opts.nonces == nil {
+		s.opts.nonces = NewNonceManager(client)
+	}
+	return s
+}
+
+// Send fills, signs and broadcasts the given message and returns the pending
+// transaction.
+//
+// The sender of the message is set to the address of the signer and its nonce
+// is taken from the [NonceManager]. All other missing fields are filled using
+// [w3.FillMessageCtx]. The transaction type is derived from the message (see
+// [w3types.Message.ToTx]).
+func (s *Sender) Send(ctx context.Context, msg *w3types.Message) (*PendingTx, error) {
+	msg = new(w3types.Message).Set(msg)
+	msg.From = s.signer.Address()
+
+	chainID, err := s.chainIDCtx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := s.opts.nonces.Next(ctx, msg.From)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := w3.FillMessageCtx(ctx, s.client, msg, w3.WithNonce(nonce), w3.WithChainID(chainID))
+	if err == nil {
+		tx, err = s.signer.SignTx(tx, chainID)
+	}
+	if err != nil {
+		s.opts.nonces.Reset(msg.From)
+		return nil, err
+	}
+	if err := s.client.CallCtx(ctx, eth.SendTx(tx).Returns(new(common.Hash))); err != nil {
+		s.opts.nonces.Reset(msg.From)
+		return nil, err
+	}
+
+	return &PendingTx{
+		sender:  s,
+		chainID: chainID,
+		txs:     []*types.Transaction{tx},
+	}, nil
+}
+
+// chainIDCtx returns the chain ID of the client. The chain ID is only fetched
+// once.
+func (s *Sender) chainIDCtx(ctx context.Context) (*big.Int, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	if s.chainID != nil {
+		return s.chainID, nil
+	}
+
+	var chainID uint64
+	if err := s.client.CallCtx(ctx, eth.ChainID().Returns(&chainID)); err != nil {
+		return nil, err
+	}
+	s.chainID = new(big.Int).SetUint64(chainID)
+	return s.chainID, nil
+}
+
+type options struct {
+	confirmations uint64
+	pollInterval  time.Duration
+	replaceAfter  time.Duration
+	feeBump       uint64
+	nonces        *NonceManager
+}
+
+// An Option configures a [Sender].
+type Option func(*Sender)
+
+// WithConfirmations sets the number of blocks, including the block of the
+// transaction, after which a transaction is considered confirmed. The default
+// is 1 confirmation.
+func WithConfirmations(n uint64) Option {
+	return func(s *Sender) {
+		if n > 0 {
+			s.opts.confirmations = n
+		}
+	}
+}
+
+// WithPollInterval sets the interval in which the state of pending transactions
+// is polled. The default poll interval is 4s.
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Sender) {
+		if interval > 0 {
+			s.opts.pollInterval = interval
+		}
+	}
+}
+
+// WithReplaceAfter sets the duration after which a transaction that was not
+// included in a block is considered stuck and replaced by a transaction with
+// bumped fees. The default is 1m. A duration of zero disables replacements.
+func WithReplaceAfter(d time.Duration) Option {
+	return func(s *Sender) { s.opts.replaceAfter = d }
+}
+
+// WithFeeBump sets the percentage by which the fees of a stuck transaction are
+// bumped when it is replaced. The default is 10%, the minimum bump most nodes
+// accept for replacements.
+func WithFeeBump(percent uint64) Option {
+	return func(s *Sender) { s.opts.feeBump = percent }
+}
+
+// WithNonceManager sets the [NonceManager] of the sender. Use a shared
+// NonceManager if multiple senders send transactions of the same account.
+func WithNonceManager(m *NonceManager) Option {
+	return func(s *Sender) { s.opts.nonces = m }
+}
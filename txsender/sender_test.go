@@ -0,0 +1,495 @@
+package txsender_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/txsender"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	key, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	signer = w3.NewKeySigner(key)
+	addr0  = w3.A("0x000000000000000000000000000000000000c0Fe")
+
+	gwei = big.NewInt(1_000_000_000)
+)
+
+func TestSender(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+
+	client := w3.MustDial(node.URL)
+	defer client.Close()
+
+	sender := txsender.New(client, signer,
+		txsender.WithConfirmations(2),
+		txsender.WithPollInterval(10*time.Millisecond),
+	)
+	pending, err := sender.Send(context.Background(), &w3types.Message{To: &addr0, Value: big.NewInt(1)})
+	if err != nil {
+		t.Fatalf("Failed to send: %v", err)
+	}
+
+	// check filled transaction
+	tx := pending.Tx()
+	if want, got := uint8(types.DynamicFeeTxType), tx.Type(); want != got {
+		t.Fatalf("Type: want %d, got %d", want, got)
+	}
+	if want, got := uint64(0), tx.Nonce(); want != got {
+		t.Fatalf("Nonce: want %d, got %d", want, got)
+	}
+	if want, got := uint64(21_000), tx.Gas(); want != got {
+		t.Fatalf("Gas: want %d, got %d", want, got)
+	}
+	if want, got := gwei, tx.GasTipCap(); want.Cmp(got) != 0 {
+		t.Fatalf("GasTipCap: want %v, got %v", want, got)
+	}
+	if want, got := new(big.Int).Mul(gwei, big.NewInt(3)), tx.GasFeeCap(); want.Cmp(got) != 0 {
+		t.Fatalf("GasFeeCap: want %v, got %v", want, got)
+	}
+
+	// wait for 2 confirmations
+	node.mine()
+	receiptCh := wait(t, pending)
+	select {
+	case <-receiptCh:
+		t.Fatal("Want no receipt after 1 confirmation")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	node.mine()
+	receipt := <-receiptCh
+	if want, got := tx.Hash(), receipt.TxHash; want != got {
+		t.Fatalf("Receipt: want tx %s, got %s", want, got)
+	}
+}
+
+func TestSender_Concurrent(t *testing.T) {
+	node := newFakeNode(t)
+	defer node.Close()
+
+	client := w3.MustDial(node.URL)
+	defer client.Close()
+
+	sender := txsender.New(client, signer)
+
+	const n = 10
+	var (
+		wg     sync.WaitGroup
+		mux    sync.Mutex
+		nonces = make(map[uint64]bool)
+	)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pending, err := sender.Send(context.Background(), &w3types.Message{To: &addr0})
+			if err != nil {
+				t.Errorf("Failed to send: %v", err)
+				return
+			}
+			mux.Lock()
+			nonces[pending.Tx().Nonce()] = true
+			mux.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	wantNonces := make(map[uint64]bool)
+	for i := range uint64(n) {
+		wantNonces[i] = true
+	}
+	if diff := cmp.Diff(wantNonces, nonces); diff != "" {
+		t.Fatalf("Nonces (-want, +got)\n%s", diff)
+	}
+}
+
+func TestPendingTx_Wait(t *testing.T) {
+	t.Run("replace", func(t *testing.T) {
+		node := newFakeNode(t)
+		node.autoMine = true
+		node.minTip = new(big.Int).Add(gwei, big.NewInt(1)) // only include bumped transactions
+		defer node.Close()
+
+		client := w3.MustDial(node.URL)
+		defer client.Close()
+
+		sender := txsender.New(client, signer,
+			txsender.WithPollInterval(10*time.Millisecond),
+			txsender.WithReplaceAfter(30*time.Millisecond),
+		)
+		pending, err := sender.Send(context.Background(), &w3types.Message{To: &addr0})
+		if err != nil {
+			t.Fatalf("Failed to send: %v", err)
+		}
+		firstTx := pending.Tx()
+
+		receipt := <-wait(t, pending)
+		tx := pending.Tx()
+		if tx.Hash() == firstTx.Hash() {
+			t.Fatal("Want replaced transaction")
+		}
+		if want, got := receipt.TxHash, tx.Hash(); want != got {
+			t.Fatalf("Receipt: want tx %s, got %s", want, got)
+		}
+		if want, got := big.NewInt(1_100_000_000), tx.GasTipCap(); want.Cmp(got) != 0 {
+			t.Fatalf("GasTipCap: want %v, got %v", want, got)
+		}
+		if want, got := big.NewInt(3_300_000_000), tx.GasFeeCap(); want.Cmp(got) != 0 {
+			t.Fatalf("GasFeeCap: want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("replace underpriced", func(t *testing.T) {
+		node := newFakeNode(t)
+		node.autoMine = true
+		node.minTip = big.NewInt(1_200_000_000)     // only include transactions bumped twice
+		node.replaceTip = big.NewInt(1_200_000_000) // reject transactions bumped once
+		defer node.Close()
+
+		client := w3.MustDial(node.URL)
+		defer client.Close()
+
+		sender := txsender.New(client, signer,
+			txsender.WithPollInterval(10*time.Millisecond),
+			txsender.WithReplaceAfter(30*time.Millisecond),
+		)
+		pending, err := sender.Send(context.Background(), &w3types.Message{To: &addr0})
+		if err != nil {
+			t.Fatalf("Failed to send: %v", err)
+		}
+
+		receipt := <-wait(t, pending)
+		tx := pending.Tx()
+		if want, got := receipt.TxHash, tx.Hash(); want != got {
+			t.Fatalf("Receipt: want tx %s, got %s", want, got)
+		}
+		if want, got := big.NewInt(1_200_000_000), tx.GasTipCap(); want.Cmp(got) != 0 {
+			t.Fatalf("GasTipCap: want %v, got %v", want, got)
+		}
+	})
+
+	t.Run("not replaceable", func(t *testing.T) {
+		node := newFakeNode(t)
+		node.minTip = new(big.Int).Add(gwei, big.NewInt(1)) // only include bumped transactions
+		defer node.Close()
+
+		client := w3.MustDial(node.URL)
+		defer client.Close()
+
+		sender := txsender.New(client, signer,
+			txsender.WithPollInterval(10*time.Millisecond),
+			txsender.WithReplaceAfter(30*time.Millisecond),
+		)
+		pending, err := sender.Send(context.Background(), &w3types.Message{
+			To:                    &addr0,
+			SetCodeAuthorizations: []types.SetCodeAuthorization{{}},
+		})
+		if err != nil {
+			t.Fatalf("Failed to send: %v", err)
+		}
+		firstTx := pending.Tx()
+		if want, got := uint8(types.SetCodeTxType), firstTx.Type(); want != got {
+			t.Fatalf("Type: want %d, got %d", want, got)
+		}
+
+		receiptCh := wait(t, pending)
+		time.Sleep(100 * time.Millisecond)
+
+		// include the stuck transaction
+		node.mux.Lock()
+		node.minTip = nil
+		node.autoMine = true
+		node.mux.Unlock()
+
+		receipt := <-receiptCh
+		if want, got := firstTx.Hash(), pending.Hash(); want != got {
+			t.Fatalf("Want no replacement, got tx %s", got)
+		}
+		if want, got := firstTx.Hash(), receipt.TxHash; want != got {
+			t.Fatalf("Receipt: want tx %s, got %s", want, got)
+		}
+	})
+
+	t.Run("rebroadcast", func(t *testing.T) {
+		node := newFakeNode(t)
+		defer node.Close()
+
+		client := w3.MustDial(node.URL)
+		defer client.Close()
+
+		sender := txsender.New(client, signer, txsender.WithPollInterval(10*time.Millisecond))
+		pending, err := sender.Send(context.Background(), &w3types.Message{To: &addr0})
+		if err != nil {
+			t.Fatalf("Failed to send: %v", err)
+		}
+
+		node.dropPool()
+		node.mux.Lock()
+		node.autoMine = true
+		node.mux.Unlock()
+
+		receipt := <-wait(t, pending)
+		if want, got := pending.Hash(), receipt.TxHash; want != got {
+			t.Fatalf("Receipt: want tx %s, got %s", want, got)
+		}
+	})
+
+	t.Run("dropped", func(t *testing.T) {
+		node := newFakeNode(t)
+		defer node.Close()
+
+		client := w3.MustDial(node.URL)
+		defer client.Close()
+
+		sender := txsender.New(client, signer, txsender.WithPollInterval(10*time.Millisecond))
+		pending, err := sender.Send(context.Background(), &w3types.Message{To: &addr0})
+		if err != nil {
+			t.Fatalf("Failed to send: %v", err)
+		}
+
+		// the nonce is used by another transaction
+		node.dropPool()
+		node.mux.Lock()
+		node.nonce++
+		node.mux.Unlock()
+
+		_, err = pending.Wait(context.Background())
+		if !errors.Is(err, txsender.ErrDropped) {
+			t.Fatalf("Want ErrDropped, got %v", err)
+		}
+	})
+}
+
+func wait(t *testing.T, pending *txsender.PendingTx) <-chan *types.Receipt {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	receiptCh := make(chan *types.Receipt, 1)
+	go func() {
+		defer cancel()
+		receipt, err := pending.Wait(ctx)
+		if err != nil {
+			t.Errorf("Failed to wait: %v", err)
+		}
+		receiptCh <- receipt
+	}()
+	return receiptCh
+}
+
+// fakeNode is a fake RPC endpoint with a transaction pool, that includes
+// transactions of a single account.
+type fakeNode struct {
+	*httptest.Server
+	t *testing.T
+
+	mux        sync.Mutex
+	head       uint64
+	nonce      uint64                        // latest nonce of the account
+	pool       map[uint64]*types.Transaction // pending transactions by nonce
+	txs        map[common.Hash]*types.Transaction
+	receipts   map[common.Hash]*types.Receipt
+	autoMine   bool     // mine a block on each eth_blockNumber request
+	minTip     *big.Int // minimum gas tip cap of included transactions
+	replaceTip *big.Int // minimum gas tip cap of replacement transactions
+}
+
+func newFakeNode(t *testing.T) *fakeNode {
+	n := &fakeNode{
+		t:        t,
+		head:     100,
+		pool:     make(map[uint64]*types.Transaction),
+		txs:      make(map[common.Hash]*types.Transaction),
+		receipts: make(map[common.Hash]*types.Receipt),
+	}
+	n.Server = httptest.NewServer(n)
+	return n
+}
+
+// mine mines a new block that includes all executable pool transactions.
+func (n *fakeNode) mine() {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	n.mineLocked()
+}
+
+func (n *fakeNode) mineLocked() {
+	n.head++
+	for {
+		tx, ok := n.pool[n.nonce]
+		if !ok || n.minTip != nil && tx.GasTipCap().Cmp(n.minTip) < 0 {
+			return
+		}
+		delete(n.pool, n.nonce)
+		n.nonce++
+		n.receipts[tx.Hash()] = &types.Receipt{
+			Type:              tx.Type(),
+			Status:            types.ReceiptStatusSuccessful,
+			CumulativeGasUsed: tx.Gas(),
+			Logs:              []*types.Log{},
+			TxHash:            tx.Hash(),
+			GasUsed:           tx.Gas(),
+			BlockNumber:       new(big.Int).SetUint64(n.head),
+		}
+	}
+}
+
+// dropPool removes all transactions from the pool.
+func (n *fakeNode) dropPool() {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	for _, tx := range n.pool {
+		delete(n.txs, tx.Hash())
+	}
+	clear(n.pool)
+}
+
+func (n *fakeNode) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		n.t.Errorf("Failed to read body: %v", err)
+		return
+	}
+
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if body[0] == '[' {
+		var reqs []*request
+		if err := json.Unmarshal(body, &reqs); err != nil {
+			n.t.Errorf("Failed to decode requests: %v", err)
+			return
+		}
+		resps := make([]*response, len(reqs))
+		for i, req := range reqs {
+			resps[i] = newResponse(req, n.handle)
+		}
+		json.NewEncoder(w).Encode(resps)
+	} else {
+		var req *request
+		if err := json.Unmarshal(body, &req); err != nil {
+			n.t.Errorf("Failed to decode request: %v", err)
+			return
+		}
+		json.NewEncoder(w).Encode(newResponse(req, n.handle))
+	}
+}
+
+func (n *fakeNode) handle(method string, params []json.RawMessage) (any, error) {
+	switch method {
+	case "eth_chainId":
+		return hexutil.Uint64(1), nil
+	case "eth_blockNumber":
+		if n.autoMine {
+			n.mineLocked()
+		}
+		return hexutil.Uint64(n.head), nil
+	case "eth_getTransactionCount":
+		var blockNumber string
+		json.Unmarshal(params[1], &blockNumber)
+		nonce := n.nonce
+		if blockNumber == "pending" {
+			for n.pool[nonce] != nil {
+				nonce++
+			}
+		}
+		return hexutil.Uint64(nonce), nil
+	case "eth_estimateGas":
+		return hexutil.Uint64(21_000), nil
+	case "eth_createAccessList":
+		return map[string]any{"accessList": types.AccessList{}, "gasUsed": hexutil.Uint64(21_000)}, nil
+	case "eth_maxPriorityFeePerGas":
+		return (*hexutil.Big)(gwei), nil
+	case "eth_getBlockByNumber":
+		return &types.Header{
+			Number:     new(big.Int).SetUint64(n.head),
+			Difficulty: new(big.Int),
+			BaseFee:    gwei,
+		}, nil
+	case "eth_sendRawTransaction":
+		var rawTx hexutil.Bytes
+		json.Unmarshal(params[0], &rawTx)
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(rawTx); err != nil {
+			return nil, err
+		}
+		if _, ok := n.txs[tx.Hash()]; ok {
+			return nil, errors.New("already known")
+		} else if tx.Nonce() < n.nonce {
+			return nil, errors.New("nonce too low")
+		}
+		if old, ok := n.pool[tx.Nonce()]; ok {
+			if n.replaceTip != nil && tx.GasTipCap().Cmp(n.replaceTip) < 0 {
+				return nil, errors.New("replacement transaction underpriced")
+			}
+			delete(n.txs, old.Hash())
+		}
+		n.pool[tx.Nonce()] = tx
+		n.txs[tx.Hash()] = tx
+		return tx.Hash(), nil
+	case "eth_getTransactionByHash":
+		var hash common.Hash
+		json.Unmarshal(params[0], &hash)
+		if tx, ok := n.txs[hash]; ok {
+			return tx, nil
+		}
+		return nil, nil
+	case "eth_getTransactionReceipt":
+		var hash common.Hash
+		json.Unmarshal(params[0], &hash)
+		if receipt, ok := n.receipts[hash]; ok {
+			return receipt, nil
+		}
+		return nil, nil
+	default:
+		return nil, errors.New("method not found")
+	}
+}
+
+type request struct {
+	ID     json.RawMessage   `json:"id"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type response struct {
+	Version string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// newResponse handles the given request using fn and returns its response.
+func newResponse(req *request, fn func(method string, params []json.RawMessage) (any, error)) *response {
+	resp := &response{Version: "2.0", ID: req.ID}
+	if result, err := fn(req.Method, req.Params); err != nil {
+		resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+	} else {
+		resp.Result = result
+	}
+	return resp
+}
@@ -3,7 +3,11 @@ Package w3types implements common types.
 */
 package w3types
 
-import "github.com/ethereum/go-ethereum/rpc"
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
 
 // Func is the interface that wraps the methods for ABI encoding and decoding.
 type Func interface {
@@ -35,9 +39,39 @@ type RPCCallerFactory[T any] interface {
 	Returns(*T) RPCCaller
 }
 
+// RPCFollowUpCaller is the interface that is implemented by RPCCallers that may
+// require further requests after their response has been handled, e.g. to
+// follow an EIP-3668 offchain lookup.
+type RPCFollowUpCaller interface {
+	RPCCaller
+
+	// FollowUp does the further requests using call, if the handled response
+	// requires them. FollowUp is only called if HandleResponse succeeded.
+	FollowUp(ctx context.Context, call func(context.Context, ...RPCCaller) error) error
+}
+
 // RPCSubscriber is the interface that wraps the basic CreateRequest method.
 type RPCSubscriber interface {
 	// CreateRequest returns the namespace, channel, params for starting a new
 	// subscription and an error if the request cannot be created.
 	CreateRequest() (namespace string, ch any, params []any, err error)
 }
+
+// RPCPollSubscriber is the interface that is implemented by RPCSubscribers that
+// can be emulated by polling a filter, if the RPC endpoint does not support
+// subscriptions (e.g. HTTP).
+type RPCPollSubscriber interface {
+	RPCSubscriber
+
+	// NewFilter returns the RPCCaller that installs the filter and stores its
+	// filter ID in id.
+	NewFilter(id *rpc.ID) RPCCaller
+
+	// Poll requests the changes of the filter with the given id using call and
+	// sends them to the subscriptions channel. Poll returns if ctx is canceled.
+	Poll(ctx context.Context, id rpc.ID, call func(context.Context, ...RPCCaller) error) error
+
+	// UninstallFilter returns the RPCCaller that uninstalls the filter with the
+	// given id.
+	UninstallFilter(id rpc.ID) RPCCaller
+}
@@ -2,12 +2,15 @@ package w3types
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
 )
 
 // Message represents a transaction without the signature.
@@ -102,6 +105,129 @@ func (msg *Message) SetCallMsg(callMsg ethereum.CallMsg) *Message {
 	return msg
 }
 
+// ToTx returns an unsigned [types.Transaction] of msg for the given chainID.
+//
+// The transaction type is derived from the populated fields of msg:
+//   - set-code transaction, if SetCodeAuthorizations are given,
+//   - blob transaction, if BlobHashes are given,
+//   - access list transaction, if GasPrice and an AccessList are given,
+//   - legacy transaction, if GasPrice is given,
+//   - dynamic fee transaction, otherwise.
+//
+// Blob transactions are returned without sidecar.
+func (msg *Message) ToTx(chainID *big.Int) (*types.Transaction, error) {
+	input := msg.Input
+	if input == nil && msg.Func != nil {
+		var err error
+		if input, err = msg.Func.EncodeArgs(msg.Args...); err != nil {
+			return nil, err
+		}
+	}
+	value := msg.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+
+	var txData types.TxData
+	switch {
+	case len(msg.SetCodeAuthorizations) > 0 || len(msg.BlobHashes) > 0:
+		if msg.To == nil {
+			return nil, errors.New("w3types: blob and set-code transactions must have a recipient")
+		}
+		u256s, err := toU256s(chainID, msg.GasTipCap, msg.GasFeeCap, value, msg.BlobGasFeeCap)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(msg.SetCodeAuthorizations) > 0 {
+			txData = &types.SetCodeTx{
+				ChainID:    u256s[0],
+				Nonce:      msg.Nonce,
+				GasTipCap:  u256s[1],
+				GasFeeCap:  u256s[2],
+				Gas:        msg.Gas,
+				To:         *msg.To,
+				Value:      u256s[3],
+				Data:       input,
+				AccessList: msg.AccessList,
+				AuthList:   msg.SetCodeAuthorizations,
+			}
+		} else {
+			txData = &types.BlobTx{
+				ChainID:    u256s[0],
+				Nonce:      msg.Nonce,
+				GasTipCap:  u256s[1],
+				GasFeeCap:  u256s[2],
+				Gas:        msg.Gas,
+				To:         *msg.To,
+				Value:      u256s[3],
+				Data:       input,
+				AccessList: msg.AccessList,
+				BlobFeeCap: u256s[4],
+				BlobHashes: msg.BlobHashes,
+			}
+		}
+	case msg.GasPrice != nil && msg.AccessList != nil:
+		txData = &types.AccessListTx{
+			ChainID:    chainID,
+			Nonce:      msg.Nonce,
+			GasPrice:   msg.GasPrice,
+			Gas:        msg.Gas,
+			To:         msg.To,
+			Value:      value,
+			Data:       input,
+			AccessList: msg.AccessList,
+		}
+	case msg.GasPrice != nil:
+		txData = &types.LegacyTx{
+			Nonce:    msg.Nonce,
+			GasPrice: msg.GasPrice,
+			Gas:      msg.Gas,
+			To:       msg.To,
+			Value:    value,
+			Data:     input,
+		}
+	default:
+		txData = &types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      msg.Nonce,
+			GasTipCap:  nilToZero(msg.GasTipCap),
+			GasFeeCap:  nilToZero(msg.GasFeeCap),
+			Gas:        msg.Gas,
+			To:         msg.To,
+			Value:      value,
+			Data:       input,
+			AccessList: msg.AccessList,
+		}
+	}
+	return types.NewTx(txData), nil
+}
+
+// toU256s converts the given big integers to uint256 integers. Nil is converted
+// to zero.
+func toU256s(xs ...*big.Int) ([]*uint256.Int, error) {
+	u256s := make([]*uint256.Int, len(xs))
+	for i, x := range xs {
+		if x == nil {
+			u256s[i] = new(uint256.Int)
+			continue
+		}
+
+		var overflow bool
+		if u256s[i], overflow = uint256.FromBig(x); overflow {
+			return nil, fmt.Errorf("w3types: %v overflows uint256", x)
+		}
+	}
+	return u256s, nil
+}
+
+func nilToZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
+
 type message struct {
 	From                  *common.Address              `json:"from,omitempty"`
 	To                    *common.Address              `json:"to,omitempty"`
@@ -0,0 +1,84 @@
+package w3types_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/w3types"
+)
+
+func TestMessageToTx(t *testing.T) {
+	var (
+		chainID       = big.NewInt(1)
+		addr          = w3.A("0x000000000000000000000000000000000000c0Fe")
+		funcBalanceOf = w3.MustNewFunc("balanceOf(address)", "uint256")
+	)
+
+	tests := []struct {
+		Name    string
+		Msg     *w3types.Message
+		Want    *types.Transaction
+		WantErr bool
+	}{
+		{
+			Name: "dynamic-fee",
+			Msg:  &w3types.Message{To: &addr, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21_000},
+			Want: types.NewTx(&types.DynamicFeeTx{ChainID: chainID, Nonce: 1, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2), Gas: 21_000, To: &addr, Value: new(big.Int)}),
+		},
+		{
+			Name: "dynamic-fee-func",
+			Msg:  &w3types.Message{To: &addr, Func: funcBalanceOf, Args: []any{addr}},
+			Want: types.NewTx(&types.DynamicFeeTx{ChainID: chainID, GasTipCap: new(big.Int), GasFeeCap: new(big.Int), To: &addr, Value: new(big.Int), Data: w3.B("0x70a08231000000000000000000000000000000000000000000000000000000000000c0fe")}),
+		},
+		{
+			Name: "legacy",
+			Msg:  &w3types.Message{To: &addr, GasPrice: big.NewInt(1), Value: big.NewInt(1)},
+			Want: types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(1), To: &addr, Value: big.NewInt(1)}),
+		},
+		{
+			Name: "access-list",
+			Msg:  &w3types.Message{To: &addr, GasPrice: big.NewInt(1), AccessList: types.AccessList{{Address: addr}}},
+			Want: types.NewTx(&types.AccessListTx{ChainID: chainID, GasPrice: big.NewInt(1), To: &addr, Value: new(big.Int), AccessList: types.AccessList{{Address: addr}}}),
+		},
+		{
+			Name: "blob",
+			Msg:  &w3types.Message{To: &addr, GasFeeCap: big.NewInt(2), BlobGasFeeCap: big.NewInt(3), BlobHashes: []common.Hash{{0x01}}},
+			Want: types.NewTx(&types.BlobTx{ChainID: uint256.NewInt(1), GasTipCap: new(uint256.Int), GasFeeCap: uint256.NewInt(2), To: addr, Value: new(uint256.Int), BlobFeeCap: uint256.NewInt(3), BlobHashes: []common.Hash{{0x01}}}),
+		},
+		{
+			Name: "set-code",
+			Msg:  &w3types.Message{To: &addr, GasFeeCap: big.NewInt(2), SetCodeAuthorizations: []types.SetCodeAuthorization{{Address: addr}}},
+			Want: types.NewTx(&types.SetCodeTx{ChainID: uint256.NewInt(1), GasTipCap: new(uint256.Int), GasFeeCap: uint256.NewInt(2), To: addr, Value: new(uint256.Int), AuthList: []types.SetCodeAuthorization{{Address: addr}}}),
+		},
+		{
+			Name:    "blob-without-recipient",
+			Msg:     &w3types.Message{BlobHashes: []common.Hash{{0x01}}},
+			WantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			got, err := test.Msg.ToTx(chainID)
+			if test.WantErr {
+				if err == nil {
+					t.Fatal("Want error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("Failed to convert message: %v", err)
+			}
+
+			if test.Want.Type() != got.Type() {
+				t.Fatalf("Type: want %d, got %d", test.Want.Type(), got.Type())
+			}
+			if test.Want.Hash() != got.Hash() {
+				t.Fatalf("Hash: want %s, got %s", test.Want.Hash(), got.Hash())
+			}
+		})
+	}
+}
@@ -0,0 +1,307 @@
+package w3types
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// EntryPointVersion is the version of the ERC-4337 EntryPoint a
+// [UserOperation] is sent to.
+//
+// The zero value is [EntryPointV07], i.e. a [UserOperation] without an explicit
+// Version is encoded and hashed for EntryPoint v0.7.
+type EntryPointVersion uint8
+
+const (
+	EntryPointV07 EntryPointVersion = 0 // EntryPoint v0.7 (default)
+	EntryPointV06 EntryPointVersion = 1 // EntryPoint v0.6
+)
+
+// UserOperation represents an ERC-4337 user operation.
+//
+// The fields follow the unpacked layout of EntryPoint v0.7. For EntryPoint
+// v0.6, the init code is the concatenation of Factory and FactoryData, the
+// paymaster and data is the concatenation of Paymaster and PaymasterData. The
+// fields PaymasterVerificationGasLimit and PaymasterPostOpGasLimit are ignored
+// for EntryPoint v0.6.
+type UserOperation struct {
+	Version EntryPointVersion // Version of the EntryPoint (default: EntryPointV07)
+
+	Sender                        common.Address
+	Nonce                         *big.Int
+	Factory                       *common.Address // Optional factory of the sender, if it is not deployed yet
+	FactoryData                   []byte
+	CallData                      []byte
+	CallGasLimit                  uint64
+	VerificationGasLimit          uint64
+	PreVerificationGas            uint64
+	MaxFeePerGas                  *big.Int
+	MaxPriorityFeePerGas          *big.Int
+	Paymaster                     *common.Address // Optional paymaster that pays for the operation
+	PaymasterVerificationGasLimit uint64
+	PaymasterPostOpGasLimit       uint64
+	PaymasterData                 []byte
+	Signature                     []byte
+}
+
+// InitCode returns the init code of the operation, which is the concatenation
+// of Factory and FactoryData.
+func (op *UserOperation) InitCode() []byte {
+	if op.Factory == nil {
+		return []byte{}
+	}
+	return append(op.Factory.Bytes(), op.FactoryData...)
+}
+
+// PaymasterAndData returns the paymaster and data of the operation. For
+// EntryPoint v0.7 it is the concatenation of Paymaster, the 16 byte
+// PaymasterVerificationGasLimit, the 16 byte PaymasterPostOpGasLimit and
+// PaymasterData. For EntryPoint v0.6 it is the concatenation of Paymaster and
+// PaymasterData.
+func (op *UserOperation) PaymasterAndData() []byte {
+	if op.Paymaster == nil {
+		return []byte{}
+	}
+	data := op.Paymaster.Bytes()
+	if op.Version == EntryPointV07 {
+		gasLimits := packUint128s(op.PaymasterVerificationGasLimit, op.PaymasterPostOpGasLimit)
+		data = append(data, gasLimits[:]...)
+	}
+	return append(data, op.PaymasterData...)
+}
+
+// AccountGasLimits returns the packed VerificationGasLimit and CallGasLimit of
+// the operation, as used by EntryPoint v0.7.
+func (op *UserOperation) AccountGasLimits() [32]byte {
+	return packUint128s(op.VerificationGasLimit, op.CallGasLimit)
+}
+
+// GasFees returns the packed MaxPriorityFeePerGas and MaxFeePerGas of the
+// operation, as used by EntryPoint v0.7.
+func (op *UserOperation) GasFees() [32]byte {
+	var gasFees [32]byte
+	nilToZero(op.MaxPriorityFeePerGas).FillBytes(gasFees[:16])
+	nilToZero(op.MaxFeePerGas).FillBytes(gasFees[16:])
+	return gasFees
+}
+
+// Hash returns the hash of the operation for the given EntryPoint address and
+// chain ID. The hash is signed by the sender of the operation.
+func (op *UserOperation) Hash(entryPoint common.Address, chainID uint64) common.Hash {
+	var enc []byte
+	switch op.Version {
+	case EntryPointV06:
+		enc = make([]byte, 0, 10*32)
+		enc = append(enc, common.LeftPadBytes(op.Sender[:], 32)...)
+		enc = append(enc, word(op.Nonce)...)
+		enc = append(enc, crypto.Keccak256(op.InitCode())...)
+		enc = append(enc, crypto.Keccak256(op.CallData)...)
+		enc = append(enc, word(new(big.Int).SetUint64(op.CallGasLimit))...)
+		enc = append(enc, word(new(big.Int).SetUint64(op.VerificationGasLimit))...)
+		enc = append(enc, word(new(big.Int).SetUint64(op.PreVerificationGas))...)
+		enc = append(enc, word(op.MaxFeePerGas)...)
+		enc = append(enc, word(op.MaxPriorityFeePerGas)...)
+		enc = append(enc, crypto.Keccak256(op.PaymasterAndData())...)
+	default:
+		accountGasLimits, gasFees := op.AccountGasLimits(), op.GasFees()
+
+		enc = make([]byte, 0, 8*32)
+		enc = append(enc, common.LeftPadBytes(op.Sender[:], 32)...)
+		enc = append(enc, word(op.Nonce)...)
+		enc = append(enc, crypto.Keccak256(op.InitCode())...)
+		enc = append(enc, crypto.Keccak256(op.CallData)...)
+		enc = append(enc, accountGasLimits[:]...)
+		enc = append(enc, word(new(big.Int).SetUint64(op.PreVerificationGas))...)
+		enc = append(enc, gasFees[:]...)
+		enc = append(enc, crypto.Keccak256(op.PaymasterAndData())...)
+	}
+
+	return crypto.Keccak256Hash(
+		crypto.Keccak256(enc),
+		common.LeftPadBytes(entryPoint[:], 32),
+		word(new(big.Int).SetUint64(chainID)),
+	)
+}
+
+type userOperationV06 struct {
+	Sender               common.Address `json:"sender"`
+	Nonce                *hexutil.Big   `json:"nonce"`
+	InitCode             hexutil.Bytes  `json:"initCode"`
+	CallData             hexutil.Bytes  `json:"callData"`
+	CallGasLimit         hexutil.Uint64 `json:"callGasLimit"`
+	VerificationGasLimit hexutil.Uint64 `json:"verificationGasLimit"`
+	PreVerificationGas   hexutil.Uint64 `json:"preVerificationGas"`
+	MaxFeePerGas         *hexutil.Big   `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas *hexutil.Big   `json:"maxPriorityFeePerGas"`
+	PaymasterAndData     hexutil.Bytes  `json:"paymasterAndData"`
+	Signature            hexutil.Bytes  `json:"signature"`
+}
+
+type userOperationV07 struct {
+	Sender                        common.Address  `json:"sender"`
+	Nonce                         *hexutil.Big    `json:"nonce"`
+	Factory                       *common.Address `json:"factory,omitempty"`
+	FactoryData                   hexutil.Bytes   `json:"factoryData,omitempty"`
+	CallData                      hexutil.Bytes   `json:"callData"`
+	CallGasLimit                  hexutil.Uint64  `json:"callGasLimit"`
+	VerificationGasLimit          hexutil.Uint64  `json:"verificationGasLimit"`
+	PreVerificationGas            hexutil.Uint64  `json:"preVerificationGas"`
+	MaxFeePerGas                  *hexutil.Big    `json:"maxFeePerGas"`
+	MaxPriorityFeePerGas          *hexutil.Big    `json:"maxPriorityFeePerGas"`
+	Paymaster                     *common.Address `json:"paymaster,omitempty"`
+	PaymasterVerificationGasLimit *hexutil.Uint64 `json:"paymasterVerificationGasLimit,omitempty"`
+	PaymasterPostOpGasLimit       *hexutil.Uint64 `json:"paymasterPostOpGasLimit,omitempty"`
+	PaymasterData                 hexutil.Bytes   `json:"paymasterData,omitempty"`
+	Signature                     hexutil.Bytes   `json:"signature"`
+}
+
+// MarshalJSON implements the [json.Marshaler]. The operation is encoded in the
+// RPC format of its EntryPoint version.
+func (op *UserOperation) MarshalJSON() ([]byte, error) {
+	if op.Version == EntryPointV06 {
+		return json.Marshal(&userOperationV06{
+			Sender:               op.Sender,
+			Nonce:                (*hexutil.Big)(nilToZero(op.Nonce)),
+			InitCode:             op.InitCode(),
+			CallData:             nilToEmpty(op.CallData),
+			CallGasLimit:         hexutil.Uint64(op.CallGasLimit),
+			VerificationGasLimit: hexutil.Uint64(op.VerificationGasLimit),
+			PreVerificationGas:   hexutil.Uint64(op.PreVerificationGas),
+			MaxFeePerGas:         (*hexutil.Big)(nilToZero(op.MaxFeePerGas)),
+			MaxPriorityFeePerGas: (*hexutil.Big)(nilToZero(op.MaxPriorityFeePerGas)),
+			PaymasterAndData:     op.PaymasterAndData(),
+			Signature:            nilToEmpty(op.Signature),
+		})
+	}
+
+	enc := &userOperationV07{
+		Sender:               op.Sender,
+		Nonce:                (*hexutil.Big)(nilToZero(op.Nonce)),
+		CallData:             nilToEmpty(op.CallData),
+		CallGasLimit:         hexutil.Uint64(op.CallGasLimit),
+		VerificationGasLimit: hexutil.Uint64(op.VerificationGasLimit),
+		PreVerificationGas:   hexutil.Uint64(op.PreVerificationGas),
+		MaxFeePerGas:         (*hexutil.Big)(nilToZero(op.MaxFeePerGas)),
+		MaxPriorityFeePerGas: (*hexutil.Big)(nilToZero(op.MaxPriorityFeePerGas)),
+		Signature:            nilToEmpty(op.Signature),
+	}
+	if op.Factory != nil {
+		enc.Factory = op.Factory
+		enc.FactoryData = nilToEmpty(op.FactoryData)
+	}
+	if op.Paymaster != nil {
+		enc.Paymaster = op.Paymaster
+		enc.PaymasterVerificationGasLimit = (*hexutil.Uint64)(&op.PaymasterVerificationGasLimit)
+		enc.PaymasterPostOpGasLimit = (*hexutil.Uint64)(&op.PaymasterPostOpGasLimit)
+		enc.PaymasterData = nilToEmpty(op.PaymasterData)
+	}
+	return json.Marshal(enc)
+}
+
+// UnmarshalJSON implements the [json.Unmarshaler]. The EntryPoint version of
+// the operation is detected from the given fields: operations with an
+// "initCode" or "paymasterAndData" field are decoded as EntryPoint v0.6
+// operations.
+func (op *UserOperation) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	_, hasInitCode := fields["initCode"]
+	_, hasPaymasterAndData := fields["paymasterAndData"]
+
+	if hasInitCode || hasPaymasterAndData {
+		var dec userOperationV06
+		if err := json.Unmarshal(data, &dec); err != nil {
+			return err
+		}
+
+		*op = UserOperation{
+			Version:              EntryPointV06,
+			Sender:               dec.Sender,
+			Nonce:                (*big.Int)(dec.Nonce),
+			CallData:             dec.CallData,
+			CallGasLimit:         uint64(dec.CallGasLimit),
+			VerificationGasLimit: uint64(dec.VerificationGasLimit),
+			PreVerificationGas:   uint64(dec.PreVerificationGas),
+			MaxFeePerGas:         (*big.Int)(dec.MaxFeePerGas),
+			MaxPriorityFeePerGas: (*big.Int)(dec.MaxPriorityFeePerGas),
+			Signature:            dec.Signature,
+		}
+		var err error
+		if op.Factory, op.FactoryData, err = splitAddress(dec.InitCode); err != nil {
+			return err
+		}
+		if op.Paymaster, op.PaymasterData, err = splitAddress(dec.PaymasterAndData); err != nil {
+			return err
+		}
+		return nil
+	}
+
+	var dec userOperationV07
+	if err := json.Unmarshal(data, &dec); err != nil {
+		return err
+	}
+
+	*op = UserOperation{
+		Version:              EntryPointV07,
+		Sender:               dec.Sender,
+		Nonce:                (*big.Int)(dec.Nonce),
+		Factory:              dec.Factory,
+		FactoryData:          dec.FactoryData,
+		CallData:             dec.CallData,
+		CallGasLimit:         uint64(dec.CallGasLimit),
+		VerificationGasLimit: uint64(dec.VerificationGasLimit),
+		PreVerificationGas:   uint64(dec.PreVerificationGas),
+		MaxFeePerGas:         (*big.Int)(dec.MaxFeePerGas),
+		MaxPriorityFeePerGas: (*big.Int)(dec.MaxPriorityFeePerGas),
+		Paymaster:            dec.Paymaster,
+		PaymasterData:        dec.PaymasterData,
+		Signature:            dec.Signature,
+	}
+	if dec.PaymasterVerificationGasLimit != nil {
+		op.PaymasterVerificationGasLimit = uint64(*dec.PaymasterVerificationGasLimit)
+	}
+	if dec.PaymasterPostOpGasLimit != nil {
+		op.PaymasterPostOpGasLimit = uint64(*dec.PaymasterPostOpGasLimit)
+	}
+	return nil
+}
+
+// splitAddress splits the given data into its leading address and the
+// remaining data. Empty data results in a nil address.
+func splitAddress(data []byte) (*common.Address, []byte, error) {
+	if len(data) == 0 {
+		return nil, nil, nil
+	}
+	if len(data) < common.AddressLength {
+		return nil, nil, errors.New("w3types: invalid address prefix")
+	}
+	addr := common.BytesToAddress(data[:common.AddressLength])
+	return &addr, data[common.AddressLength:], nil
+}
+
+// packUint128s packs the given values into the high and low 16 bytes of a
+// 32 byte word.
+func packUint128s(hi, lo uint64) (w [32]byte) {
+	new(big.Int).SetUint64(hi).FillBytes(w[:16])
+	new(big.Int).SetUint64(lo).FillBytes(w[16:])
+	return w
+}
+
+// word returns the given value as 32 byte word.
+func word(v *big.Int) []byte {
+	return common.LeftPadBytes(nilToZero(v).Bytes(), 32)
+}
+
+func nilToEmpty(b []byte) []byte {
+	if b == nil {
+		return []byte{}
+	}
+	return b
+}
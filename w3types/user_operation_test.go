@@ -0,0 +1,190 @@
+package w3types_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	entryPointV06 = w3.A("0x5FF137D4b0FDCD49DcA30c7CF57E578a026d2789")
+	entryPointV07 = w3.A("0x0000000071727De22E5E9d8BAf0edAc6f37da032")
+
+	userOpV06 = &w3types.UserOperation{
+		Version:              w3types.EntryPointV06,
+		Sender:               w3.A("0x000000000000000000000000000000000000c0Fe"),
+		Nonce:                w3.I("1"),
+		Factory:              w3.APtr("0x000000000000000000000000000000000000fAc7"),
+		FactoryData:          w3.B("0xc0fe"),
+		CallData:             w3.B("0xb61d27f6"),
+		CallGasLimit:         100_000,
+		VerificationGasLimit: 200_000,
+		PreVerificationGas:   50_000,
+		MaxFeePerGas:         w3.I("30 gwei"),
+		MaxPriorityFeePerGas: w3.I("1 gwei"),
+		Paymaster:            w3.APtr("0x000000000000000000000000000000000000Fee0"),
+		PaymasterData:        w3.B("0x01"),
+		Signature:            w3.B("0x5191"),
+	}
+	userOpV07 = &w3types.UserOperation{
+		Sender:                        w3.A("0x000000000000000000000000000000000000c0Fe"),
+		Nonce:                         w3.I("1"),
+		Factory:                       w3.APtr("0x000000000000000000000000000000000000fAc7"),
+		FactoryData:                   w3.B("0xc0fe"),
+		CallData:                      w3.B("0xb61d27f6"),
+		CallGasLimit:                  100_000,
+		VerificationGasLimit:          200_000,
+		PreVerificationGas:            50_000,
+		MaxFeePerGas:                  w3.I("30 gwei"),
+		MaxPriorityFeePerGas:          w3.I("1 gwei"),
+		Paymaster:                     w3.APtr("0x000000000000000000000000000000000000Fee0"),
+		PaymasterVerificationGasLimit: 60_000,
+		PaymasterPostOpGasLimit:       10_000,
+		PaymasterData:                 w3.B("0x01"),
+		Signature:                     w3.B("0x5191"),
+	}
+
+	userOpV06JSON = `{"sender":"0x000000000000000000000000000000000000c0fe","nonce":"0x1","initCode":"0x000000000000000000000000000000000000fac7c0fe","callData":"0xb61d27f6","callGasLimit":"0x186a0","verificationGasLimit":"0x30d40","preVerificationGas":"0xc350","maxFeePerGas":"0x6fc23ac00","maxPriorityFeePerGas":"0x3b9aca00","paymasterAndData":"0x000000000000000000000000000000000000fee001","signature":"0x5191"}`
+	userOpV07JSON = `{"sender":"0x000000000000000000000000000000000000c0fe","nonce":"0x1","factory":"0x000000000000000000000000000000000000fac7","factoryData":"0xc0fe","callData":"0xb61d27f6","callGasLimit":"0x186a0","verificationGasLimit":"0x30d40","preVerificationGas":"0xc350","maxFeePerGas":"0x6fc23ac00","maxPriorityFeePerGas":"0x3b9aca00","paymaster":"0x000000000000000000000000000000000000fee0","paymasterVerificationGasLimit":"0xea60","paymasterPostOpGasLimit":"0x2710","paymasterData":"0x01","signature":"0x5191"}`
+)
+
+func TestUserOperationHash(t *testing.T) {
+	tests := []struct {
+		Name       string
+		Op         *w3types.UserOperation
+		EntryPoint common.Address
+		ChainID    uint64
+		Want       common.Hash
+	}{
+		{
+			Name:       "v0.6",
+			Op:         userOpV06,
+			EntryPoint: entryPointV06,
+			ChainID:    1,
+			Want:       w3.H("0xbc86ed47d605de6f5b8087758c1455a8dc648e1aeeb5d9c5938b2488a761fbbb"),
+		},
+		{
+			// getUserOpHash of the EntryPoint v0.7 runtime code
+			Name:       "v0.7",
+			Op:         userOpV07,
+			EntryPoint: entryPointV07,
+			ChainID:    1,
+			Want:       w3.H("0x6b03c50179d21e68cc82f8ee7aefd647f9f1f0c221b8f80c4f781f0f699c34c5"),
+		},
+		{
+			// userOpHash passed to validateUserOp by EntryPoint v0.7 in the
+			// go-ethereum ERC-7562 tracer test "simple"
+			Name: "v0.7-handleOps",
+			Op: &w3types.UserOperation{
+				Sender:               w3.A("0x8c9d927336adc963536122f8e0d269319e79ed7a"),
+				Nonce:                w3.I("0"),
+				CallData:             w3.B("0xa9e966b7000000000000000000000000000000000000000000000000000000000010f447"),
+				CallGasLimit:         300_000,
+				VerificationGasLimit: 1_000_000,
+				PreVerificationGas:   300_000,
+				MaxFeePerGas:         w3.I("4 gwei"),
+				MaxPriorityFeePerGas: w3.I("3 gwei"),
+				Signature:            w3.B("0xface"),
+			},
+			EntryPoint: entryPointV07,
+			ChainID:    1337,
+			Want:       w3.H("0x88a9b2626e43da02f978ae6cc89feffb68afcd5860cb9239337352db4b694fe1"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if got := test.Op.Hash(test.EntryPoint, test.ChainID); test.Want != got {
+				t.Fatalf("want %s, got %s", test.Want, got)
+			}
+		})
+	}
+}
+
+func TestUserOperationPaymasterAndData(t *testing.T) {
+	tests := []struct {
+		Name string
+		Op   *w3types.UserOperation
+		Want []byte
+	}{
+		{
+			Name: "v0.6",
+			Op:   userOpV06,
+			Want: w3.B("0x000000000000000000000000000000000000fee001"),
+		},
+		{
+			Name: "v0.7",
+			Op:   userOpV07,
+			Want: w3.B("0x000000000000000000000000000000000000fee0", "0x0000000000000000000000000000ea60", "0x00000000000000000000000000002710", "0x01"),
+		},
+		{
+			Name: "no-paymaster",
+			Op:   &w3types.UserOperation{},
+			Want: []byte{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			if diff := cmp.Diff(test.Want, test.Op.PaymasterAndData()); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUserOperationJSON(t *testing.T) {
+	tests := []struct {
+		Name string
+		Op   *w3types.UserOperation
+		JSON string
+	}{
+		{
+			Name: "v0.6",
+			Op:   userOpV06,
+			JSON: userOpV06JSON,
+		},
+		{
+			Name: "v0.7",
+			Op:   userOpV07,
+			JSON: userOpV07JSON,
+		},
+		{
+			Name: "v0.7-minimal",
+			Op: &w3types.UserOperation{
+				Sender:               w3.A("0x000000000000000000000000000000000000c0Fe"),
+				Nonce:                w3.I("0"),
+				CallData:             []byte{},
+				MaxFeePerGas:         w3.I("0"),
+				MaxPriorityFeePerGas: w3.I("0"),
+				Signature:            []byte{},
+			},
+			JSON: `{"sender":"0x000000000000000000000000000000000000c0fe","nonce":"0x0","callData":"0x","callGasLimit":"0x0","verificationGasLimit":"0x0","preVerificationGas":"0x0","maxFeePerGas":"0x0","maxPriorityFeePerGas":"0x0","signature":"0x"}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			gotJSON, err := json.Marshal(test.Op)
+			if err != nil {
+				t.Fatalf("Failed to marshal: %v", err)
+			}
+			if test.JSON != string(gotJSON) {
+				t.Fatalf("JSON: want %s, got %s", test.JSON, gotJSON)
+			}
+
+			gotOp := new(w3types.UserOperation)
+			if err := json.Unmarshal(gotJSON, gotOp); err != nil {
+				t.Fatalf("Failed to unmarshal: %v", err)
+			}
+			if diff := cmp.Diff(test.Op, gotOp, cmp.Comparer(func(x, y *big.Int) bool { return x.Cmp(y) == 0 })); diff != "" {
+				t.Fatalf("(-want, +got)\n%s", diff)
+			}
+		})
+	}
+}
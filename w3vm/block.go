@@ -0,0 +1,215 @@
+package w3vm
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/beacon"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// ErrReceiptMismatch is returned by [CompareReceipts] if the receipts diverge.
+var ErrReceiptMismatch = errors.New("receipt mismatch")
+
+// engine applies block rewards of pre-merge blocks and withdrawals of
+// post-merge blocks.
+var engine = beacon.New(ethash.NewFaker())
+
+// ApplyBlock replays all transactions of the given block in order, and returns
+// their receipts. The VM must contain the state of the parent block, e.g. by
+// forking the block using [WithFork] with the blocks number. Multiple tracing
+// hooks may be given to trace the execution of the block.
+//
+// The EIP-4788 beacon root and EIP-2935 parent block hash system calls are
+// applied before the transactions, and the EIP-7002 and EIP-7251 system calls,
+// withdrawals, and block rewards are applied after the transactions.
+//
+// ApplyBlock only replays the receipts of the block. As the VM only contains the
+// partial state that was fetched during execution, no state root is computed:
+// neither the state root of the block is verified, nor is the post state root
+// of pre-Byzantium receipts set.
+func (v *VM) ApplyBlock(block *types.Block, hooks ...*tracing.Hooks) (types.Receipts, error) {
+	if v.db.Error() != nil {
+		return nil, ErrFetch
+	}
+
+	var (
+		config      = v.opts.chainConfig
+		header      = block.Header()
+		blockNumber = block.Number()
+		signer      = types.MakeSigner(config, blockNumber, block.Time())
+		gp          = new(core.GasPool).AddGas(block.GasLimit())
+		hook        = joinHooks(hooks)
+	)
+
+	var db vm.StateDB
+	if hook != nil {
+		db = state.NewHookedState(v.db, hook)
+	} else {
+		db = v.db
+	}
+
+	if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(blockNumber) == 0 {
+		misc.ApplyDAOHardFork(db)
+	}
+
+	getHash := v.opts.blockCtx.GetHash
+	if v.opts.fetcher != nil {
+		getHash = fetcherHashFunc(v.opts.fetcher)
+	}
+	evm := vm.NewEVM(*newBlockContext(config, header, getHash), db, config, vm.Config{
+		Tracer: hook,
+	})
+	if len(v.opts.precompiles) > 0 {
+		evm.SetPrecompiles(v.opts.precompiles)
+	}
+	if v.opts.jumpDestCache != nil {
+		evm.SetJumpDestCache(v.opts.jumpDestCache)
+	}
+
+	// pre-execution system calls
+	if beaconRoot := block.BeaconRoot(); beaconRoot != nil {
+		core.ProcessBeaconBlockRoot(*beaconRoot, evm)
+	}
+	if config.IsPrague(blockNumber, block.Time()) {
+		core.ProcessParentBlockHash(block.ParentHash(), evm)
+	}
+
+	// apply transactions
+	var (
+		receipts = make(types.Receipts, 0, len(block.Transactions()))
+		usedGas  uint64
+		logIndex uint
+	)
+	for i, tx := range block.Transactions() {
+		msg, err := core.TransactionToMessage(tx, signer, header.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d (%s): %w", i, tx.Hash(), err)
+		}
+		v.db.SetTxContext(tx.Hash(), i)
+
+		receipt, err := applyTx(evm, gp, v.db, block, tx, msg, &usedGas)
+		if err != nil {
+			return nil, fmt.Errorf("tx %d (%s): %w", i, tx.Hash(), err)
+		}
+
+		// normalize the log indices, as the state DB may contain logs of
+		// previously applied messages
+		for _, log := range receipt.Logs {
+			log.Index = logIndex
+			logIndex++
+		}
+		receipts = append(receipts, receipt)
+	}
+
+	// post-execution system calls
+	if config.IsPrague(blockNumber, block.Time()) {
+		var requests [][]byte
+		if err := core.ProcessWithdrawalQueue(&requests, evm); err != nil {
+			return nil, err
+		}
+		if err := core.ProcessConsolidationQueue(&requests, evm); err != nil {
+			return nil, err
+		}
+	}
+
+	// apply withdrawals and block rewards
+	engine.Finalize(chainHeaderReader{config}, header, db, block.Body())
+	v.db.Finalise(true)
+
+	if v.db.Error() != nil {
+		return nil, ErrFetch
+	}
+	return receipts, nil
+}
+
+// applyTx applies the given transaction message to the EVM and returns its
+// receipt.
+func applyTx(evm *vm.EVM, gp *core.GasPool, db *state.StateDB, block *types.Block, tx *types.Transaction, msg *core.Message, usedGas *uint64) (receipt *types.Receipt, err error) {
+	if hooks := evm.Config.Tracer; hooks != nil {
+		if hooks.OnTxStart != nil {
+			hooks.OnTxStart(evm.GetVMContext(), tx, msg.From)
+		}
+		if hooks.OnTxEnd != nil {
+			defer func() { hooks.OnTxEnd(receipt, err) }()
+		}
+	}
+
+	result, err := core.ApplyMessage(evm, msg, gp)
+	if err != nil {
+		return nil, err
+	}
+	evm.StateDB.Finalise(true)
+	*usedGas += result.UsedGas
+
+	return core.MakeReceipt(evm, result, db, block.Number(), block.Hash(), block.Time(), tx, *usedGas, nil), nil
+}
+
+// CompareReceipts compares the receipts got, e.g. returned by [VM.ApplyBlock],
+// with the receipts want, e.g. fetched from a node, and returns an error wrapping
+// [ErrReceiptMismatch] describing the first divergence, if any. The post state
+// roots of the receipts are not compared.
+func CompareReceipts(want, got types.Receipts) error {
+	if len(want) != len(got) {
+		return fmt.Errorf("%w: got %d receipts, want %d", ErrReceiptMismatch, len(got), len(want))
+	}
+
+	for i := range want {
+		if err := compareReceipt(want[i], got[i]); err != nil {
+			return fmt.Errorf("%w: tx %d (%s): %s", ErrReceiptMismatch, i, want[i].TxHash, err)
+		}
+	}
+	return nil
+}
+
+func compareReceipt(want, got *types.Receipt) error {
+	switch {
+	case want.Status != got.Status:
+		return fmt.Errorf("got status %d, want %d", got.Status, want.Status)
+	case want.GasUsed != got.GasUsed:
+		return fmt.Errorf("got gas used %d, want %d", got.GasUsed, want.GasUsed)
+	case want.CumulativeGasUsed != got.CumulativeGasUsed:
+		return fmt.Errorf("got cumulative gas used %d, want %d", got.CumulativeGasUsed, want.CumulativeGasUsed)
+	case want.ContractAddress != got.ContractAddress:
+		return fmt.Errorf("got contract address %s, want %s", got.ContractAddress, want.ContractAddress)
+	case len(want.Logs) != len(got.Logs):
+		return fmt.Errorf("got %d logs, want %d", len(got.Logs), len(want.Logs))
+	}
+
+	for i := range want.Logs {
+		wantLog, gotLog := want.Logs[i], got.Logs[i]
+		if wantLog.Address != gotLog.Address ||
+			wantLog.Index != gotLog.Index ||
+			!bytes.Equal(wantLog.Data, gotLog.Data) ||
+			!slices.Equal(wantLog.Topics, gotLog.Topics) {
+			return fmt.Errorf("log %d mismatch", i)
+		}
+	}
+
+	if want.Bloom != got.Bloom {
+		return errors.New("bloom mismatch")
+	}
+	return nil
+}
+
+// chainHeaderReader implements the consensus.ChainHeaderReader interface
+// required by the consensus engine to finalize a block.
+type chainHeaderReader struct {
+	config *params.ChainConfig
+}
+
+func (r chainHeaderReader) Config() *params.ChainConfig               { return r.config }
+func (chainHeaderReader) CurrentHeader() *types.Header                { return nil }
+func (chainHeaderReader) GetHeader(common.Hash, uint64) *types.Header { return nil }
+func (chainHeaderReader) GetHeaderByNumber(uint64) *types.Header      { return nil }
+func (chainHeaderReader) GetHeaderByHash(common.Hash) *types.Header   { return nil }
@@ -380,6 +380,38 @@ func ExampleVM_traceBlock() {
 	}
 }
 
+// Replay a block and compare the receipts with the receipts of the node.
+func ExampleVM_ApplyBlock() {
+	blockNumber := big.NewInt(20_000_000)
+
+	var (
+		block    *types.Block
+		receipts types.Receipts
+	)
+	if err := client.Call(
+		eth.BlockByNumber(blockNumber).Returns(&block),
+		eth.BlockReceipts(blockNumber).Returns(&receipts),
+	); err != nil {
+		// ...
+	}
+
+	vm, err := w3vm.New(
+		w3vm.WithFork(client, blockNumber),
+	)
+	if err != nil {
+		// ...
+	}
+
+	gotReceipts, err := vm.ApplyBlock(block)
+	if err != nil {
+		// ...
+	}
+
+	if err := w3vm.CompareReceipts(receipts, gotReceipts); err != nil {
+		fmt.Println("Receipts diverged:", err)
+	}
+}
+
 func TestWETHDeposit(t *testing.T) {
 	// setup VM
 	vm, _ := w3vm.New(
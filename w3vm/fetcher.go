@@ -2,6 +2,7 @@ package w3vm
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -44,6 +45,7 @@ type Fetcher interface {
 }
 
 type rpcFetcher struct {
+	ctx         context.Context
 	client      *w3.Client
 	blockNumber *big.Int
 
@@ -69,11 +71,18 @@ type rpcFetcher struct {
 // Note, that the returned state for a given block number is the state after the
 // execution of that block.
 func NewRPCFetcher(client *w3.Client, blockNumber *big.Int) Fetcher {
-	return newRPCFetcher(client, blockNumber)
+	return newRPCFetcher(context.Background(), client, blockNumber)
 }
 
-func newRPCFetcher(client *w3.Client, blockNumber *big.Int) *rpcFetcher {
+// NewRPCFetcherCtx is like [NewRPCFetcher], but all requests of the returned
+// [Fetcher] are sent using the given ctx.
+func NewRPCFetcherCtx(ctx context.Context, client *w3.Client, blockNumber *big.Int) Fetcher {
+	return newRPCFetcher(ctx, client, blockNumber)
+}
+
+func newRPCFetcher(ctx context.Context, client *w3.Client, blockNumber *big.Int) *rpcFetcher {
 	return &rpcFetcher{
+		ctx:          ctx,
 		client:       client,
 		blockNumber:  blockNumber,
 		accounts:     make(map[common.Address]func() (*types.StateAccount, error)),
@@ -195,7 +204,7 @@ func (f *rpcFetcher) HeaderHash(blockNumber uint64) (common.Hash, error) {
 }
 
 func (f *rpcFetcher) call(calls ...w3types.RPCCaller) error {
-	return f.client.Call(calls...)
+	return f.client.CallCtx(f.ctx, calls...)
 }
 
 ////////////////////////////////////////////////////////////////////////////////////////////////////
@@ -209,7 +218,7 @@ func NewTestingRPCFetcher(tb testing.TB, chainID uint64, client *w3.Client, bloc
 		panic("w3vm: NewTestingRPCFetcher must be used in a module test")
 	}
 
-	fetcher := newRPCFetcher(client, blockNumber)
+	fetcher := newRPCFetcher(context.Background(), client, blockNumber)
 	if err := fetcher.loadTestdataState(chainID); err != nil {
 		tb.Fatalf("w3vm: failed to load state from testdata: %v", err)
 	}
@@ -0,0 +1,214 @@
+package hooks
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/tracing"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/lmittmann/w3/tokens"
+)
+
+// NativeToken is the token address used for native ETH balance changes.
+var NativeToken = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+
+// Token identifies a token by its contract address and token ID. The ID is
+// zero for native ETH and ERC-20 tokens.
+type Token struct {
+	Address common.Address
+	ID      uint256.Int
+}
+
+// Transfer is a single token transfer that was decoded from a log.
+type Transfer struct {
+	Token Token
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+}
+
+// BalanceTracker is a hook that tracks native ETH balance changes and ERC-20,
+// ERC-721 and ERC-1155 token transfers. Balance changes and transfers of
+// reverted call frames are discarded.
+//
+// The embedded [tracing.Hooks] can be passed to [w3vm.VM.Apply]. Balance
+// changes and transfers accumulate over multiple applied messages.
+type BalanceTracker struct {
+	*tracing.Hooks
+
+	deltas    map[common.Address]map[Token]*big.Int
+	transfers []*Transfer
+
+	// logStack contains the logs of each call frame on the call stack.
+	logStack [][]*types.Log
+}
+
+// NewBalanceTracker returns a new BalanceTracker hook.
+func NewBalanceTracker() *BalanceTracker {
+	bt := &BalanceTracker{
+		deltas: make(map[common.Address]map[Token]*big.Int),
+	}
+
+	// the journal emits reverse balance changes for reverted call frames
+	hooks, err := tracing.WrapWithJournal(&tracing.Hooks{
+		OnEnter:         bt.onEnter,
+		OnExit:          bt.onExit,
+		OnBalanceChange: bt.onBalanceChange,
+		OnLog:           bt.onLog,
+	})
+	if err != nil {
+		panic(err)
+	}
+	bt.Hooks = hooks
+	return bt
+}
+
+// Deltas returns the net balance change per address and token. Native ETH
+// balance changes are keyed by [NativeToken]. Zero deltas are omitted.
+func (bt *BalanceTracker) Deltas() map[common.Address]map[Token]*big.Int {
+	deltas := make(map[common.Address]map[Token]*big.Int)
+	for addr, tokenDeltas := range bt.deltas {
+		for token, delta := range tokenDeltas {
+			if delta.Sign() == 0 {
+				continue
+			}
+			if _, ok := deltas[addr]; !ok {
+				deltas[addr] = make(map[Token]*big.Int)
+			}
+			deltas[addr][token] = new(big.Int).Set(delta)
+		}
+	}
+	return deltas
+}
+
+// Transfers returns all token transfers in the order they were emitted.
+func (bt *BalanceTracker) Transfers() []*Transfer {
+	return bt.transfers
+}
+
+func (bt *BalanceTracker) onEnter(depth int, typ byte, from, to common.Address, input []byte, gas uint64, value *big.Int) {
+	bt.logStack = append(bt.logStack, nil)
+}
+
+func (bt *BalanceTracker) onExit(depth int, output []byte, gasUsed uint64, err error, reverted bool) {
+	if len(bt.logStack) == 0 {
+		return
+	}
+	logs := bt.logStack[len(bt.logStack)-1]
+	bt.logStack = bt.logStack[:len(bt.logStack)-1]
+	if reverted {
+		return
+	}
+
+	if len(bt.logStack) > 0 {
+		// pass logs to parent call frame
+		bt.logStack[len(bt.logStack)-1] = append(bt.logStack[len(bt.logStack)-1], logs...)
+		return
+	}
+	for _, log := range logs {
+		bt.addLog(log)
+	}
+}
+
+func (bt *BalanceTracker) onBalanceChange(addr common.Address, prev, new *big.Int, reason tracing.BalanceChangeReason) {
+	bt.add(addr, Token{Address: NativeToken}, new)
+	bt.sub(addr, Token{Address: NativeToken}, prev)
+}
+
+func (bt *BalanceTracker) onLog(log *types.Log) {
+	if len(bt.logStack) == 0 {
+		bt.addLog(log)
+		return
+	}
+	bt.logStack[len(bt.logStack)-1] = append(bt.logStack[len(bt.logStack)-1], log)
+}
+
+// addLog decodes token transfers from the given log and records them.
+func (bt *BalanceTracker) addLog(log *types.Log) {
+	if len(log.Topics) == 0 {
+		return
+	}
+
+	switch log.Topics[0] {
+	case tokens.ERC20TransferEvent.Topic0:
+		switch len(log.Topics) {
+		case 3: // ERC-20
+			var value big.Int
+			if err := tokens.ERC20TransferEvent.DecodeArgs(log, nil, nil, &value); err != nil {
+				return
+			}
+			bt.addTransfer(Token{Address: log.Address}, topicAddr(log.Topics[1]), topicAddr(log.Topics[2]), &value)
+		case 4: // ERC-721
+			token := Token{Address: log.Address}
+			token.ID.SetBytes32(log.Topics[3][:])
+			bt.addTransfer(token, topicAddr(log.Topics[1]), topicAddr(log.Topics[2]), big.NewInt(1))
+		}
+	case tokens.ERC1155TransferSingleEvent.Topic0:
+		var (
+			from, to  common.Address
+			id, value big.Int
+		)
+		if err := tokens.ERC1155TransferSingleEvent.DecodeArgs(log, nil, &from, &to, &id, &value); err != nil {
+			return
+		}
+		bt.addTransfer(erc1155Token(log.Address, &id), from, to, &value)
+	case tokens.ERC1155TransferBatchEvent.Topic0:
+		var (
+			from, to    common.Address
+			ids, values []*big.Int
+		)
+		if err := tokens.ERC1155TransferBatchEvent.DecodeArgs(log, nil, &from, &to, &ids, &values); err != nil ||
+			len(ids) != len(values) {
+			return
+		}
+		for i := range ids {
+			bt.addTransfer(erc1155Token(log.Address, ids[i]), from, to, values[i])
+		}
+	}
+}
+
+func (bt *BalanceTracker) addTransfer(token Token, from, to common.Address, value *big.Int) {
+	bt.transfers = append(bt.transfers, &Transfer{
+		Token: token,
+		From:  from,
+		To:    to,
+		Value: value,
+	})
+	bt.sub(from, token, value)
+	bt.add(to, token, value)
+}
+
+func (bt *BalanceTracker) add(addr common.Address, token Token, value *big.Int) {
+	delta := bt.delta(addr, token)
+	delta.Add(delta, value)
+}
+
+func (bt *BalanceTracker) sub(addr common.Address, token Token, value *big.Int) {
+	delta := bt.delta(addr, token)
+	delta.Sub(delta, value)
+}
+
+func (bt *BalanceTracker) delta(addr common.Address, token Token) *big.Int {
+	tokenDeltas, ok := bt.deltas[addr]
+	if !ok {
+		tokenDeltas = make(map[Token]*big.Int)
+		bt.deltas[addr] = tokenDeltas
+	}
+	delta, ok := tokenDeltas[token]
+	if !ok {
+		delta = new(big.Int)
+		tokenDeltas[token] = delta
+	}
+	return delta
+}
+
+func erc1155Token(addr common.Address, id *big.Int) Token {
+	token := Token{Address: addr}
+	token.ID.SetFromBig(id)
+	return token
+}
+
+func topicAddr(topic common.Hash) common.Address {
+	return common.BytesToAddress(topic[:])
+}
@@ -0,0 +1,147 @@
+package hooks_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/google/go-cmp/cmp"
+	"github.com/holiman/uint256"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/tokens"
+	"github.com/lmittmann/w3/w3types"
+	"github.com/lmittmann/w3/w3vm"
+	"github.com/lmittmann/w3/w3vm/hooks"
+)
+
+var (
+	addrSender  = common.Address{0xc0, 0xfe}
+	addrDead    = common.HexToAddress("0xdead")
+	addrERC20   = common.Address{0x20}
+	addrERC721  = common.Address{0x72, 0x1}
+	addrERC1155 = common.Address{0x11, 0x55}
+	addrRevert  = common.Address{0xfd}
+	addrProxy   = common.Address{0xca}
+
+	// emits Transfer(caller, 0xdead, 100)
+	codeERC20 = w3.B("0x606460005261dead337f" + topic0Hex(tokens.ERC20TransferEvent) + "60206000a300")
+
+	// emits Transfer(0x0, caller, 42)
+	codeERC721 = w3.B("0x602a3360007f" + topic0Hex(tokens.ERC721TransferEvent) + "60006000a400")
+
+	// emits TransferSingle(caller, 0x0, caller, 7, 5)
+	codeERC1155 = w3.B("0x60076000526005602052336000337f" + topic0Hex(tokens.ERC1155TransferSingleEvent) + "60406000a400")
+
+	// calls ERC20 and reverts
+	codeRevert = w3.B("0x60006000600060006000" + "73" + addrERC20.Hex()[2:] + "5af150" + "60006000fd")
+
+	// calls Revert and ERC20
+	codeProxy = w3.B("0x60006000600060006000" + "73" + addrRevert.Hex()[2:] + "5af150" +
+		"60006000600060006000" + "73" + addrERC20.Hex()[2:] + "5af150" + "00")
+)
+
+func TestBalanceTracker(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Msg           *w3types.Message
+		WantDeltas    map[common.Address]map[hooks.Token]*big.Int
+		WantTransfers []*hooks.Transfer
+	}{
+		{
+			Name: "native",
+			Msg:  &w3types.Message{From: addrSender, To: &addrDead, Value: w3.I("1 ether")},
+			WantDeltas: map[common.Address]map[hooks.Token]*big.Int{
+				addrSender: {{Address: hooks.NativeToken}: w3.I("-1 ether")},
+				addrDead:   {{Address: hooks.NativeToken}: w3.I("1 ether")},
+			},
+		},
+		{
+			Name: "erc20",
+			Msg:  &w3types.Message{From: addrSender, To: &addrERC20},
+			WantDeltas: map[common.Address]map[hooks.Token]*big.Int{
+				addrSender: {{Address: addrERC20}: big.NewInt(-100)},
+				addrDead:   {{Address: addrERC20}: big.NewInt(100)},
+			},
+			WantTransfers: []*hooks.Transfer{
+				{Token: hooks.Token{Address: addrERC20}, From: addrSender, To: addrDead, Value: big.NewInt(100)},
+			},
+		},
+		{
+			Name: "erc721",
+			Msg:  &w3types.Message{From: addrSender, To: &addrERC721},
+			WantDeltas: map[common.Address]map[hooks.Token]*big.Int{
+				{}:         {{Address: addrERC721, ID: *uint256.NewInt(42)}: big.NewInt(-1)},
+				addrSender: {{Address: addrERC721, ID: *uint256.NewInt(42)}: big.NewInt(1)},
+			},
+			WantTransfers: []*hooks.Transfer{
+				{Token: hooks.Token{Address: addrERC721, ID: *uint256.NewInt(42)}, From: common.Address{}, To: addrSender, Value: big.NewInt(1)},
+			},
+		},
+		{
+			Name: "erc1155",
+			Msg:  &w3types.Message{From: addrSender, To: &addrERC1155},
+			WantDeltas: map[common.Address]map[hooks.Token]*big.Int{
+				{}:         {{Address: addrERC1155, ID: *uint256.NewInt(7)}: big.NewInt(-5)},
+				addrSender: {{Address: addrERC1155, ID: *uint256.NewInt(7)}: big.NewInt(5)},
+			},
+			WantTransfers: []*hooks.Transfer{
+				{Token: hooks.Token{Address: addrERC1155, ID: *uint256.NewInt(7)}, From: common.Address{}, To: addrSender, Value: big.NewInt(5)},
+			},
+		},
+		{
+			Name:       "revert",
+			Msg:        &w3types.Message{From: addrSender, To: &addrRevert, Value: w3.I("1 ether")},
+			WantDeltas: map[common.Address]map[hooks.Token]*big.Int{},
+		},
+		{
+			Name: "partial-revert",
+			Msg:  &w3types.Message{From: addrSender, To: &addrProxy, Value: w3.I("1 ether")},
+			WantDeltas: map[common.Address]map[hooks.Token]*big.Int{
+				addrSender: {{Address: hooks.NativeToken}: w3.I("-1 ether")},
+				addrProxy: {
+					{Address: hooks.NativeToken}: w3.I("1 ether"),
+					{Address: addrERC20}:         big.NewInt(-100),
+				},
+				addrDead: {{Address: addrERC20}: big.NewInt(100)},
+			},
+			WantTransfers: []*hooks.Transfer{
+				{Token: hooks.Token{Address: addrERC20}, From: addrProxy, To: addrDead, Value: big.NewInt(100)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			vm, err := w3vm.New(
+				w3vm.WithNoBaseFee(),
+				w3vm.WithState(w3types.State{
+					addrSender:  {Balance: w3.I("10 ether")},
+					addrERC20:   {Code: codeERC20},
+					addrERC721:  {Code: codeERC721},
+					addrERC1155: {Code: codeERC1155},
+					addrRevert:  {Code: codeRevert},
+					addrProxy:   {Code: codeProxy},
+				}),
+			)
+			if err != nil {
+				t.Fatalf("Failed to create VM: %v", err)
+			}
+
+			bt := hooks.NewBalanceTracker()
+			vm.Apply(test.Msg, bt.Hooks)
+
+			if diff := cmp.Diff(test.WantDeltas, bt.Deltas(), cmpBig); diff != "" {
+				t.Fatalf("Deltas (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(test.WantTransfers, bt.Transfers(), cmpBig); diff != "" {
+				t.Fatalf("Transfers (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+var cmpBig = cmp.Comparer(func(a, b *big.Int) bool { return a.Cmp(b) == 0 })
+
+func topic0Hex(event *w3.Event) string {
+	return event.Topic0.Hex()[2:]
+}
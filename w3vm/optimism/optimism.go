@@ -0,0 +1,40 @@
+/*
+Package optimism implements helpers to compute OP Stack specific values, such as
+the L1 data fee, in a [w3vm.VM] that contains the state of an OP Stack chain.
+*/
+package optimism
+
+import (
+	"math/big"
+
+	"github.com/lmittmann/w3/module/optimism"
+	"github.com/lmittmann/w3/w3types"
+	"github.com/lmittmann/w3/w3vm"
+)
+
+// L1Fee returns the L1 data fee of the given message as transaction on the OP
+// Stack chain with the given chain ID, by calling getL1Fee on the
+// GasPriceOracle predeploy. The VM must contain the state of the OP Stack
+// chain, e.g. by forking it using [w3vm.WithFork].
+//
+// See [optimism.L1FeeInput] for how the message is encoded as transaction.
+func L1Fee(vm *w3vm.VM, msg *w3types.Message, chainID uint64) (*big.Int, error) {
+	input, err := optimism.L1FeeInput(msg, chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := vm.Call(&w3types.Message{
+		To:    &optimism.GasPriceOracle,
+		Input: input,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var fee *big.Int
+	if err := optimism.FuncGetL1Fee.DecodeReturns(receipt.Output, &fee); err != nil {
+		return nil, err
+	}
+	return fee, nil
+}
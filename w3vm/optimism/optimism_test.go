@@ -0,0 +1,43 @@
+package optimism_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/module/optimism"
+	"github.com/lmittmann/w3/w3types"
+	"github.com/lmittmann/w3/w3vm"
+	vmoptimism "github.com/lmittmann/w3/w3vm/optimism"
+)
+
+func TestL1Fee(t *testing.T) {
+	// GasPriceOracle that returns the size of its calldata as L1 fee:
+	// CALLDATASIZE PUSH1 0 MSTORE PUSH1 32 PUSH1 0 RETURN
+	vm, _ := w3vm.New(
+		w3vm.WithState(w3types.State{
+			optimism.GasPriceOracle: {Code: w3.B("0x3660005260206000f3")},
+		}),
+	)
+
+	const chainID = 10
+	msg := &w3types.Message{
+		GasFeeCap: w3.I("1 gwei"),
+		Gas:       21_000,
+		To:        &common.Address{0x01},
+		Value:     w3.I("1 ether"),
+	}
+	input, err := optimism.L1FeeInput(msg, chainID)
+	if err != nil {
+		t.Fatalf("Failed to encode input: %v", err)
+	}
+
+	gotFee, err := vmoptimism.L1Fee(vm, msg, chainID)
+	if err != nil {
+		t.Fatalf("Failed to get L1 fee: %v", err)
+	}
+	if wantFee := big.NewInt(int64(len(input))); wantFee.Cmp(gotFee) != 0 {
+		t.Fatalf("L1 fee: want %v, got %v", wantFee, gotFee)
+	}
+}
@@ -0,0 +1,161 @@
+package w3vm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/lmittmann/w3"
+	"github.com/lmittmann/w3/w3types"
+)
+
+var (
+	funcHandleOpsV06 = w3.MustNewFunc("handleOps((address sender, uint256 nonce, bytes initCode, bytes callData, uint256 callGasLimit, uint256 verificationGasLimit, uint256 preVerificationGas, uint256 maxFeePerGas, uint256 maxPriorityFeePerGas, bytes paymasterAndData, bytes signature)[] ops, address beneficiary)", "")
+	funcHandleOpsV07 = w3.MustNewFunc("handleOps((address sender, uint256 nonce, bytes initCode, bytes callData, bytes32 accountGasLimits, uint256 preVerificationGas, bytes32 gasFees, bytes paymasterAndData, bytes signature)[] ops, address beneficiary)", "")
+
+	errFailedOp           = w3.MustNewFunc("FailedOp(uint256 opIndex, string reason)", "")
+	errFailedOpWithRevert = w3.MustNewFunc("FailedOpWithRevert(uint256 opIndex, string reason, bytes inner)", "")
+)
+
+// FailedOpError is returned by [VM.SimulateHandleOps] if the EntryPoint
+// rejected a user operation.
+type FailedOpError struct {
+	OpIndex uint64 // Index of the rejected operation
+	Reason  string // Reason of the rejection, e.g. "AA21 didn't pay prefund"
+	Inner   []byte // Revert data of the account or paymaster, if any
+}
+
+func (err *FailedOpError) Error() string {
+	return fmt.Sprintf("%s: failed op %d: %s", ErrRevert, err.OpIndex, err.Reason)
+}
+
+func (err *FailedOpError) Unwrap() error { return ErrRevert }
+
+// SimulateHandleOps simulates the execution of the given user operations by
+// calling handleOps on the given ERC-4337 EntryPoint. The call is sent from
+// the given beneficiary, that receives the gas refunds and must be an
+// externally owned account. Any state changes are reverted.
+//
+// All operations must be of the same EntryPoint version. A [FailedOpError] is
+// returned, if the EntryPoint rejected an operation.
+func (vm *VM) SimulateHandleOps(entryPoint, beneficiary common.Address, ops ...*w3types.UserOperation) (*Receipt, error) {
+	if len(ops) == 0 {
+		return nil, errors.New("w3vm: no user operations")
+	}
+
+	input, err := encodeHandleOps(beneficiary, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	receipt, err := vm.Call(&w3types.Message{
+		From:  beneficiary,
+		To:    &entryPoint,
+		Input: input,
+	})
+	if errors.Is(err, ErrRevert) {
+		if failedOpErr := decodeFailedOp(receipt.Output); failedOpErr != nil {
+			receipt.Err = failedOpErr
+			return receipt, failedOpErr
+		}
+	}
+	return receipt, err
+}
+
+type userOpV06 struct {
+	Sender               common.Address
+	Nonce                *big.Int
+	InitCode             []byte
+	CallData             []byte
+	CallGasLimit         *big.Int
+	VerificationGasLimit *big.Int
+	PreVerificationGas   *big.Int
+	MaxFeePerGas         *big.Int
+	MaxPriorityFeePerGas *big.Int
+	PaymasterAndData     []byte
+	Signature            []byte
+}
+
+type userOpV07 struct {
+	Sender             common.Address
+	Nonce              *big.Int
+	InitCode           []byte
+	CallData           []byte
+	AccountGasLimits   [32]byte
+	PreVerificationGas *big.Int
+	GasFees            [32]byte
+	PaymasterAndData   []byte
+	Signature          []byte
+}
+
+// encodeHandleOps encodes the handleOps call of the given operations for their
+// EntryPoint version.
+func encodeHandleOps(beneficiary common.Address, ops []*w3types.UserOperation) ([]byte, error) {
+	version := ops[0].Version
+	for _, op := range ops {
+		if op.Version != version {
+			return nil, errors.New("w3vm: user operations of mixed EntryPoint versions")
+		}
+	}
+
+	if version == w3types.EntryPointV06 {
+		encOps := make([]userOpV06, len(ops))
+		for i, op := range ops {
+			encOps[i] = userOpV06{
+				Sender:               op.Sender,
+				Nonce:                nilToZero(op.Nonce),
+				InitCode:             op.InitCode(),
+				CallData:             op.CallData,
+				CallGasLimit:         new(big.Int).SetUint64(op.CallGasLimit),
+				VerificationGasLimit: new(big.Int).SetUint64(op.VerificationGasLimit),
+				PreVerificationGas:   new(big.Int).SetUint64(op.PreVerificationGas),
+				MaxFeePerGas:         nilToZero(op.MaxFeePerGas),
+				MaxPriorityFeePerGas: nilToZero(op.MaxPriorityFeePerGas),
+				PaymasterAndData:     op.PaymasterAndData(),
+				Signature:            op.Signature,
+			}
+		}
+		return funcHandleOpsV06.EncodeArgs(encOps, beneficiary)
+	}
+
+	encOps := make([]userOpV07, len(ops))
+	for i, op := range ops {
+		encOps[i] = userOpV07{
+			Sender:             op.Sender,
+			Nonce:              nilToZero(op.Nonce),
+			InitCode:           op.InitCode(),
+			CallData:           op.CallData,
+			AccountGasLimits:   op.AccountGasLimits(),
+			PreVerificationGas: new(big.Int).SetUint64(op.PreVerificationGas),
+			GasFees:            op.GasFees(),
+			PaymasterAndData:   op.PaymasterAndData(),
+			Signature:          op.Signature,
+		}
+	}
+	return funcHandleOpsV07.EncodeArgs(encOps, beneficiary)
+}
+
+// decodeFailedOp decodes the given revert data as FailedOp or
+// FailedOpWithRevert error. Nil is returned, if the revert data is neither.
+func decodeFailedOp(output []byte) *FailedOpError {
+	var (
+		opIndex *big.Int
+		reason  string
+		inner   []byte
+	)
+	if err := errFailedOp.DecodeArgs(output, &opIndex, &reason); err == nil {
+		return &FailedOpError{OpIndex: opIndex.Uint64(), Reason: reason}
+	}
+	if err := errFailedOpWithRevert.DecodeArgs(output, &opIndex, &reason, &inner); err == nil {
+		return &FailedOpError{OpIndex: opIndex.Uint64(), Reason: reason, Inner: inner}
+	}
+	return nil
+}
+
+func nilToZero(x *big.Int) *big.Int {
+	if x == nil {
+		return new(big.Int)
+	}
+	return x
+}
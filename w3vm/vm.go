@@ -4,6 +4,7 @@ Package w3vm provides a VM for executing EVM messages.
 package w3vm
 
 import (
+	"bytes"
 	"cmp"
 	"crypto/rand"
 	"encoding/binary"
@@ -81,7 +82,7 @@ func New(opts ...Option) (*VM, error) {
 // Apply the given message to the VM, and return its receipt. Multiple tracing hooks
 // may be given to trace the execution of the message.
 func (vm *VM) Apply(msg *w3types.Message, hooks ...*tracing.Hooks) (*Receipt, error) {
-	return vm.apply(msg, false, joinHooks(hooks))
+	return vm.apply(msg, false, false, joinHooks(hooks))
 }
 
 // ApplyTx is like [VM.Apply], but takes a transaction instead of a message.
@@ -93,7 +94,10 @@ func (vm *VM) ApplyTx(tx *types.Transaction, hooks ...*tracing.Hooks) (*Receipt,
 	return vm.Apply(msg, hooks...)
 }
 
-func (v *VM) apply(msg *w3types.Message, isCall bool, hooks *tracing.Hooks) (*Receipt, error) {
+// apply applies the given message. If isCall is true, account and base fee
+// checks are skipped. If revert is true, the state changes of a successful
+// message are reverted.
+func (v *VM) apply(msg *w3types.Message, isCall, revert bool, hooks *tracing.Hooks) (*Receipt, error) {
 	if v.db.Error() != nil {
 		return nil, ErrFetch
 	}
@@ -165,7 +169,7 @@ func (v *VM) apply(msg *w3types.Message, isCall bool, hooks *tracing.Hooks) (*Re
 		receipt.ContractAddress = &contractAddr
 	}
 
-	if isCall && !result.Failed() {
+	if revert && !result.Failed() {
 		v.db.RevertToSnapshot(snap)
 	}
 	v.db.Finalise(false)
@@ -177,7 +181,7 @@ func (v *VM) apply(msg *w3types.Message, isCall bool, hooks *tracing.Hooks) (*Re
 // of a call are reverted. Multiple tracing hooks may be given to trace the execution
 // of the message.
 func (vm *VM) Call(msg *w3types.Message, hooks ...*tracing.Hooks) (*Receipt, error) {
-	return vm.apply(msg, true, joinHooks(hooks))
+	return vm.apply(msg, true, true, joinHooks(hooks))
 }
 
 // CallFunc is a utility function for [VM.Call] that calls the given function
@@ -214,6 +218,77 @@ func (cff *CallFuncFactory) Returns(returns ...any) error {
 	return cff.receipt.DecodeReturns(returns...)
 }
 
+// VerifySignature reports whether sig is a valid signature of the given hash by
+// the account with the given address. Signatures of externally owned accounts
+// are verified using ecrecover, signatures of contract accounts are verified
+// using ERC-1271 by calling the contract on the VM.
+//
+// ERC-6492 signatures of counterfactual contract accounts are verified by
+// deploying the account using its factory before verifying the wrapped
+// signature. The deployment is reverted afterwards.
+func (vm *VM) VerifySignature(addr common.Address, hash common.Hash, sig []byte) (bool, error) {
+	if signer, err := w3.RecoverHash(hash, sig); err == nil && signer == addr {
+		return true, nil
+	}
+
+	if bytes.HasSuffix(sig, w3.MagicValueERC6492[:]) {
+		factory, factoryCalldata, innerSig, err := unwrapERC6492(sig)
+		if err != nil {
+			return false, nil
+		}
+		sig = innerSig
+
+		code, err := vm.Code(addr)
+		if err != nil {
+			return false, err
+		}
+		if len(code) == 0 {
+			snap := vm.Snapshot()
+			defer vm.Rollback(snap)
+
+			if _, err := vm.apply(&w3types.Message{To: &factory, Input: factoryCalldata}, true, false, nil); errors.Is(err, ErrFetch) {
+				return false, err
+			} else if err != nil {
+				// the deployment failed
+				return false, nil
+			}
+		}
+	}
+
+	var magicValue [4]byte
+	if err := vm.CallFunc(addr, w3.FuncIsValidSignature, hash, sig).Returns(&magicValue); errors.Is(err, ErrFetch) {
+		return false, err
+	} else if err != nil {
+		// the call reverted or returned no valid magic value
+		return false, nil
+	}
+	return magicValue == w3.MagicValueERC1271, nil
+}
+
+var argsERC6492 = abi.Arguments{
+	{Type: mustNewType("address")},
+	{Type: mustNewType("bytes")},
+	{Type: mustNewType("bytes")},
+}
+
+// unwrapERC6492 decodes the factory, factory calldata and inner signature of
+// the given ERC-6492 signature.
+func unwrapERC6492(sig []byte) (common.Address, []byte, []byte, error) {
+	values, err := argsERC6492.Unpack(sig[:len(sig)-len(w3.MagicValueERC6492)])
+	if err != nil {
+		return common.Address{}, nil, nil, err
+	}
+	return values[0].(common.Address), values[1].([]byte), values[2].([]byte), nil
+}
+
+func mustNewType(t string) abi.Type {
+	typ, err := abi.NewType(t, "", nil)
+	if err != nil {
+		panic(err)
+	}
+	return typ
+}
+
 // Nonce returns the nonce of the given address.
 func (vm *VM) Nonce(addr common.Address) (uint64, error) {
 	nonce := vm.db.GetNonce(addr)
@@ -9,11 +9,13 @@ import (
 	"math"
 	"math/big"
 	"os"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
@@ -22,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/params"
+	"github.com/ethereum/go-ethereum/trie"
 	gocmp "github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/lmittmann/w3"
@@ -637,6 +640,269 @@ func TestVMCallFunc(t *testing.T) {
 	}
 }
 
+func TestVMVerifySignature(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer := w3.NewKeySigner(key)
+	hash := common.Hash{0xc0, 0xfe}
+	sig, _ := signer.SignHash(hash)
+
+	// ERC-1271 wallet that accepts any signature:
+	// PUSH4 0x1626ba7e PUSH1 0xe0 SHL PUSH1 0 MSTORE PUSH1 0x20 PUSH1 0 RETURN
+	addrWallet := common.Address{0xc0, 0xfe}
+
+	// ERC-6492 factory that deploys the wallet using CREATE2 with salt 0:
+	// PUSH25 <initcode> PUSH1 0 MSTORE PUSH1 0 PUSH1 25 PUSH1 7 PUSH1 0 CREATE2 STOP
+	walletInitcode := w3.B("0x6f631626ba7e60e01b60005260206000f360005260106010f3")
+	addrFactory := common.Address{0xfa, 0xc7}
+	addrCounterfactualWallet := crypto.CreateAddress2(addrFactory, common.Hash{}, crypto.Keccak256(walletInitcode))
+
+	vm, _ := w3vm.New(
+		w3vm.WithState(w3types.State{
+			addrWallet:  {Code: w3.B("0x631626ba7e60e01b60005260206000f3")},
+			addrFactory: {Code: append(append([]byte{0x78}, walletInitcode...), w3.B("0x6000526000601960076000f500")...)},
+		}),
+	)
+
+	bytesT, _ := abi.NewType("bytes", "", nil)
+	addressT, _ := abi.NewType("address", "", nil)
+	wrappedSig, _ := abi.Arguments{{Type: addressT}, {Type: bytesT}, {Type: bytesT}}.Pack(addrFactory, []byte{}, sig)
+	sig6492 := append(wrappedSig, w3.MagicValueERC6492[:]...)
+	badSig6492 := append(append([]byte{}, wrappedSig...), w3.MagicValueERC6492[:]...)
+	copy(badSig6492[12:32], addr1[:]) // wrong factory
+
+	tests := []struct {
+		Addr common.Address
+		Sig  []byte
+		Want bool
+	}{
+		{Addr: signer.Address(), Sig: sig, Want: true},
+		{Addr: addr1, Sig: sig, Want: false},
+		{Addr: addrWallet, Sig: sig, Want: true},
+		{Addr: addrWETH, Sig: sig, Want: false},
+		{Addr: addrCounterfactualWallet, Sig: sig, Want: false},
+		{Addr: addrCounterfactualWallet, Sig: sig6492, Want: true},
+		{Addr: addrCounterfactualWallet, Sig: badSig6492, Want: false},
+		{Addr: addrWallet, Sig: sig6492, Want: true},
+	}
+
+	for i, test := range tests {
+		got, err := vm.VerifySignature(test.Addr, hash, test.Sig)
+		if err != nil {
+			t.Fatalf("%d: failed to verify signature: %v", i, err)
+		}
+		if test.Want != got {
+			t.Fatalf("%d: want %t, got %t", i, test.Want, got)
+		}
+	}
+
+	// the deployment of the counterfactual wallet is reverted
+	if code, _ := vm.Code(addrCounterfactualWallet); len(code) > 0 {
+		t.Fatalf("Want no code at %s, got %x", addrCounterfactualWallet, code)
+	}
+}
+
+func TestVMSimulateHandleOps(t *testing.T) {
+	// EntryPoint that logs its calldata:
+	// CALLDATASIZE PUSH1 0 PUSH1 0 CALLDATACOPY CALLDATASIZE PUSH1 0 LOG0 STOP
+	addrLogEntryPoint := common.Address{0xe0}
+
+	// EntryPoint that reverts with FailedOp(0, "AA21 didn't pay prefund"):
+	// PUSH2 132 PUSH1 14 PUSH1 0 CODECOPY PUSH2 132 PUSH1 0 REVERT <revert data>
+	addrFailingEntryPoint := common.Address{0xe1}
+	failedOp := w3.B("0x220266b6",
+		"0x0000000000000000000000000000000000000000000000000000000000000000",
+		"0x0000000000000000000000000000000000000000000000000000000000000040",
+		"0x0000000000000000000000000000000000000000000000000000000000000017",
+		"0x41413231206469646e2774207061792070726566756e64000000000000000000",
+	)
+
+	vm, _ := w3vm.New(
+		w3vm.WithState(w3types.State{
+			addrLogEntryPoint:     {Code: w3.B("0x366000600037366000a000")},
+			addrFailingEntryPoint: {Code: append(w3.B("0x610084600e6000396100846000fd"), failedOp...)},
+		}),
+	)
+
+	opV06 := &w3types.UserOperation{Version: w3types.EntryPointV06, Sender: addr1, Nonce: big.NewInt(1)}
+	opV07 := &w3types.UserOperation{Sender: addr1, Nonce: big.NewInt(1)}
+
+	tests := []struct {
+		Name         string
+		EntryPoint   common.Address
+		Ops          []*w3types.UserOperation
+		WantSelector []byte
+		WantErr      error
+	}{
+		{
+			Name:         "v0.6",
+			EntryPoint:   addrLogEntryPoint,
+			Ops:          []*w3types.UserOperation{opV06, opV06},
+			WantSelector: w3.B("0x1fad948c"),
+		},
+		{
+			Name:         "v0.7",
+			EntryPoint:   addrLogEntryPoint,
+			Ops:          []*w3types.UserOperation{opV07},
+			WantSelector: w3.B("0x765e827f"),
+		},
+		{
+			Name:       "failed-op",
+			EntryPoint: addrFailingEntryPoint,
+			Ops:        []*w3types.UserOperation{opV07},
+			WantErr:    &w3vm.FailedOpError{OpIndex: 0, Reason: "AA21 didn't pay prefund"},
+		},
+		{
+			Name:       "mixed-versions",
+			EntryPoint: addrLogEntryPoint,
+			Ops:        []*w3types.UserOperation{opV06, opV07},
+			WantErr:    errors.New("w3vm: user operations of mixed EntryPoint versions"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			receipt, err := vm.SimulateHandleOps(test.EntryPoint, addr0, test.Ops...)
+			if diff := gocmp.Diff(test.WantErr, err,
+				internal.EquateErrors(),
+			); diff != "" {
+				t.Fatalf("Err: (-want, +got)\n%s", diff)
+			}
+			if test.WantErr != nil {
+				return
+			}
+
+			if len(receipt.Logs) != 1 || !bytes.HasPrefix(receipt.Logs[0].Data, test.WantSelector) {
+				t.Fatalf("Want calldata with selector %x, got logs %v", test.WantSelector, receipt.Logs)
+			}
+		})
+	}
+
+	// FailedOpError wraps ErrRevert
+	_, err := vm.SimulateHandleOps(addrFailingEntryPoint, addr0, opV07)
+	if !errors.Is(err, w3vm.ErrRevert) {
+		t.Fatalf("Want ErrRevert, got %v", err)
+	}
+}
+
+func TestVMApplyBlock(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+
+	var (
+		config       = params.MergedTestChainConfig
+		signer       = types.LatestSigner(config)
+		addrLogger   = common.Address{0x10}
+		addrCoinbase = common.Address{0xcb}
+		addrW        = common.Address{0xee}
+		beaconRoot   = common.Hash{0xbe}
+		parentHash   = common.Hash{0xaa}
+	)
+
+	// MSTORE(0, 0xff) LOG0(0, 32) LOG0(0, 32)
+	codeLogger := w3.B("0x60ff5f5260205fa060205fa000")
+
+	vm, _ := w3vm.New(
+		w3vm.WithState(w3types.State{
+			sender:                       {Balance: w3.I("10 ether")},
+			addrLogger:                   {Code: codeLogger},
+			params.BeaconRootsAddress:    {Code: params.BeaconRootsCode},
+			params.HistoryStorageAddress: {Code: params.HistoryStorageCode},
+		}),
+	)
+
+	txs := make([]*types.Transaction, 3)
+	for i, to := range []common.Address{addr1, addrLogger, addrLogger} {
+		txs[i] = types.MustSignNewTx(key, signer, &types.DynamicFeeTx{
+			ChainID:   config.ChainID,
+			Nonce:     uint64(i),
+			GasTipCap: w3.I("1 gwei"),
+			GasFeeCap: w3.I("2 gwei"),
+			Gas:       100_000,
+			To:        &to,
+			Value:     w3.I("1 ether"),
+		})
+	}
+
+	header := &types.Header{
+		ParentHash:       parentHash,
+		Coinbase:         addrCoinbase,
+		Number:           big.NewInt(1),
+		GasLimit:         30_000_000,
+		Time:             1_000,
+		Difficulty:       new(big.Int),
+		BaseFee:          w3.I("1 gwei"),
+		ExcessBlobGas:    new(uint64),
+		BlobGasUsed:      new(uint64),
+		ParentBeaconRoot: &beaconRoot,
+	}
+	block := types.NewBlock(header, &types.Body{
+		Transactions: txs,
+		Withdrawals:  []*types.Withdrawal{{Index: 0, Address: addrW, Amount: 1}},
+	}, nil, trie.NewStackTrie(nil))
+
+	receipts, err := vm.ApplyBlock(block)
+	if err != nil {
+		t.Fatalf("Failed to apply block: %v", err)
+	}
+	if len(receipts) != len(txs) {
+		t.Fatalf("Receipts: want %d, got %d", len(txs), len(receipts))
+	}
+
+	// check receipts
+	var (
+		cumulativeGasUsed uint64
+		logIndex          uint
+	)
+	for i, receipt := range receipts {
+		cumulativeGasUsed += receipt.GasUsed
+		if receipt.CumulativeGasUsed != cumulativeGasUsed {
+			t.Errorf("Receipt %d: cumulative gas used: want %d, got %d", i, cumulativeGasUsed, receipt.CumulativeGasUsed)
+		}
+		if receipt.TxHash != txs[i].Hash() || receipt.TransactionIndex != uint(i) || receipt.BlockHash != block.Hash() {
+			t.Errorf("Receipt %d: unexpected tx hash, tx index or block hash", i)
+		}
+		if receipt.Bloom != types.CreateBloom(receipt) {
+			t.Errorf("Receipt %d: bloom mismatch", i)
+		}
+		for _, log := range receipt.Logs {
+			if log.Index != logIndex || log.TxIndex != uint(i) {
+				t.Errorf("Receipt %d: log index: want %d, got %d", i, logIndex, log.Index)
+			}
+			logIndex++
+		}
+	}
+	if logIndex != 4 {
+		t.Fatalf("Logs: want 4, got %d", logIndex)
+	}
+
+	// check withdrawal and coinbase tip
+	if balance, _ := vm.Balance(addrW); balance.Cmp(w3.I("1 gwei")) != 0 {
+		t.Errorf("Withdrawal balance: want 1 gwei, got %v", balance)
+	}
+	wantTip := new(big.Int).Mul(new(big.Int).SetUint64(cumulativeGasUsed), w3.I("1 gwei"))
+	if balance, _ := vm.Balance(addrCoinbase); balance.Cmp(wantTip) != 0 {
+		t.Errorf("Coinbase balance: want %v, got %v", wantTip, balance)
+	}
+
+	// check EIP-4788 beacon root and EIP-2935 parent block hash
+	if root, _ := vm.StorageAt(params.BeaconRootsAddress, common.BigToHash(big.NewInt(1_000%8191+8191))); root != beaconRoot {
+		t.Errorf("Beacon root: want %s, got %s", beaconRoot, root)
+	}
+	if hash, _ := vm.StorageAt(params.HistoryStorageAddress, common.Hash{}); hash != parentHash {
+		t.Errorf("Parent hash: want %s, got %s", parentHash, hash)
+	}
+
+	// compare receipts
+	if err := w3vm.CompareReceipts(receipts, receipts); err != nil {
+		t.Fatalf("Compare receipts: %v", err)
+	}
+	wantReceipts := slices.Clone(receipts)
+	wantReceipts[1] = &types.Receipt{Status: receipts[1].Status, GasUsed: receipts[1].GasUsed, CumulativeGasUsed: 1}
+	if err := w3vm.CompareReceipts(wantReceipts, receipts); !errors.Is(err, w3vm.ErrReceiptMismatch) {
+		t.Fatalf("Compare receipts: want ErrReceiptMismatch, got %v", err)
+	}
+}
+
 func TestVM_Fetcher(t *testing.T) {
 	f := new(testFetcher)
 	vm, err := w3vm.New(